@@ -0,0 +1,98 @@
+// Package api adapts the public brandfetch SDK to the CLI: it re-exports the
+// SDK types under their historical names and maps the CLI's network flags to
+// client options.
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/salmonumbrella/brandfetch-cli/pkg/brandfetch"
+)
+
+// Types shared with the SDK.
+type (
+	Client           = brandfetch.Client
+	Brand            = brandfetch.Brand
+	Logo             = brandfetch.Logo
+	LogoFormat       = brandfetch.LogoFormat
+	Color            = brandfetch.Color
+	Font             = brandfetch.Font
+	Link             = brandfetch.Link
+	Image            = brandfetch.Image
+	Company          = brandfetch.Company
+	Location         = brandfetch.Location
+	Industry         = brandfetch.Industry
+	Tag              = brandfetch.Tag
+	LogoResult       = brandfetch.LogoResult
+	ResolvedLogo     = brandfetch.ResolvedLogo
+	LogoVariant      = brandfetch.LogoVariant
+	LogoOptions      = brandfetch.LogoOptions
+	SearchResult     = brandfetch.SearchResult
+	KeyCheck         = brandfetch.KeyCheck
+	GraphQLError     = brandfetch.GraphQLError
+	TransportOptions = brandfetch.TransportOptions
+)
+
+// Sentinel errors shared with the SDK.
+var (
+	ErrUnauthorized = brandfetch.ErrUnauthorized
+	ErrNotFound     = brandfetch.ErrNotFound
+	ErrRateLimited  = brandfetch.ErrRateLimited
+)
+
+// DefaultTimeout is the per-request timeout used when ClientOptions.Timeout is zero.
+const DefaultTimeout = brandfetch.DefaultTimeout
+
+// NoTimeout, as ClientOptions.Timeout, disables the per-request timeout.
+const NoTimeout time.Duration = -1
+
+// ClientOptions overrides client defaults. Zero values keep the defaults.
+type ClientOptions struct {
+	BaseURL     string            // Brand, Search and Transaction API base URL
+	LogoBaseURL string            // Logo API CDN base URL
+	GraphQLURL  string            // GraphQL endpoint
+	Timeout     time.Duration     // Per-request timeout; NoTimeout disables it
+	Transport   http.RoundTripper // HTTP transport, e.g. from NewTransport
+}
+
+// NewClient creates a new Brandfetch API client.
+func NewClient(clientID, apiKey string) *Client {
+	return NewClientWithOptions(clientID, apiKey, ClientOptions{})
+}
+
+// NewClientWithOptions creates a new Brandfetch API client with the given options.
+func NewClientWithOptions(clientID, apiKey string, opts ClientOptions) *Client {
+	var sdkOpts []brandfetch.Option
+	if opts.BaseURL != "" {
+		sdkOpts = append(sdkOpts, brandfetch.WithBaseURL(opts.BaseURL))
+	}
+	if opts.LogoBaseURL != "" {
+		sdkOpts = append(sdkOpts, brandfetch.WithLogoBaseURL(opts.LogoBaseURL))
+	}
+	if opts.GraphQLURL != "" {
+		sdkOpts = append(sdkOpts, brandfetch.WithGraphQLURL(opts.GraphQLURL))
+	}
+	if opts.Timeout != 0 {
+		sdkOpts = append(sdkOpts, brandfetch.WithTimeout(opts.Timeout))
+	}
+	if opts.Transport != nil {
+		sdkOpts = append(sdkOpts, brandfetch.WithTransport(opts.Transport))
+	}
+	return brandfetch.NewClient(clientID, apiKey, sdkOpts...)
+}
+
+// NewTransport builds an HTTP transport from opts.
+func NewTransport(opts TransportOptions) (*http.Transport, error) {
+	return brandfetch.NewTransport(opts)
+}
+
+// NormalizeIdentifier keeps non-domain identifiers intact while normalizing domains.
+func NormalizeIdentifier(identifier string) string {
+	return brandfetch.NormalizeIdentifier(identifier)
+}
+
+// ParseLogoVariants parses a logo preference list such as "svg:dark,png".
+func ParseLogoVariants(s string) ([]LogoVariant, error) {
+	return brandfetch.ParseLogoVariants(s)
+}
@@ -0,0 +1,35 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewClientWithOptions(t *testing.T) {
+	var gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithOptions("cid", "key", ClientOptions{
+		BaseURL:     server.URL,
+		LogoBaseURL: "http://cdn.local/",
+		Timeout:     5 * time.Second,
+	})
+	if _, err := client.Search(context.Background(), "github", 0); err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if gotPath != "/v2/search/github" {
+		t.Errorf("request path = %q, want the BaseURL server", gotPath)
+	}
+	u, err := client.BuildLogoURL(LogoOptions{Identifier: "github.com"})
+	if err != nil || !strings.HasPrefix(u, "http://cdn.local/github.com") {
+		t.Errorf("BuildLogoURL() = %s, %v", u, err)
+	}
+}
@@ -1,11 +1,17 @@
 package authserver
 
 import (
+	"bytes"
 	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
 	"fmt"
+	"html/template"
 	"net"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -15,6 +21,26 @@ type Credentials struct {
 	APIKey   string
 }
 
+// Form field names, also used as keys for per-field validation errors.
+const (
+	FieldClientID = "client_id"
+	FieldAPIKey   = "api_key"
+)
+
+// Validator checks submitted credentials before they are accepted. It returns
+// an error message per form field that failed; an empty map accepts them.
+type Validator func(ctx context.Context, creds Credentials) map[string]string
+
+// Options configures the auth handler and server.
+type Options struct {
+	// Validate, if set, rejects credentials that fail validation and shows
+	// the errors in the form instead of returning them to the CLI.
+	Validate Validator
+	// Port is the loopback port to listen on; 0 picks a free port.
+	// A fixed port makes SSH port forwarding predictable.
+	Port int
+}
+
 const formHTML = `<!DOCTYPE html>
 <html lang="en">
 <head>
@@ -24,7 +50,7 @@ const formHTML = `<!DOCTYPE html>
     <link rel="preconnect" href="https://fonts.googleapis.com">
     <link rel="preconnect" href="https://fonts.gstatic.com" crossorigin>
     <link href="https://fonts.googleapis.com/css2?family=DM+Sans:ital,opsz,wght@0,9..40,400;0,9..40,500;0,9..40,600;0,9..40,700&display=swap" rel="stylesheet">
-    <style>
+    <style nonce="{{.Nonce}}">
         :root {
             --bg: #F8F7F4;
             --card-bg: #FFFFFF;
@@ -342,6 +368,16 @@ const formHTML = `<!DOCTYPE html>
 
         .error.show { display: block; }
 
+        .field-error {
+            color: #DC2626;
+            font-size: 0.75rem;
+            margin-top: 0.5rem;
+            display: none;
+            line-height: 1.4;
+        }
+
+        .field-error.show { display: block; }
+
         .validation-hint {
             text-align: center;
             font-size: 0.75rem;
@@ -383,6 +419,12 @@ const formHTML = `<!DOCTYPE html>
             border-radius: 50%;
         }
 
+        .accent-blue { background: #2B75E2; }
+        .accent-purple { background: #C377FF; }
+        .accent-pink { background: #F03063; }
+        .accent-green { background: #22C55E; }
+        .accent-yellow { background: #FFDA00; }
+
         .github-link {
             display: flex;
             align-items: center;
@@ -425,9 +467,11 @@ const formHTML = `<!DOCTYPE html>
                 </p>
             </div>
 
-            <div id="error" class="error"></div>
+            <div id="error" class="error{{if .Error}} show{{end}}">{{.Error}}</div>
 
             <form method="POST" action="/auth" id="authForm">
+                <input type="hidden" name="state" value="{{.State}}">
+                <input type="hidden" name="copied" id="copied" value="">
                 <div class="api-section" id="logoSection">
                     <div class="api-header">
                         <div class="api-icon logo-api">
@@ -456,6 +500,7 @@ const formHTML = `<!DOCTYPE html>
                         placeholder="Enter Logo API key"
                         autocomplete="off"
                     >
+                    <div class="field-error{{with index .FieldErrors "client_id"}} show{{end}}" id="client_id_error">{{index .FieldErrors "client_id"}}</div>
                 </div>
 
                 <div class="api-section" id="brandSection">
@@ -487,6 +532,7 @@ const formHTML = `<!DOCTYPE html>
                         placeholder="Enter Brand API key"
                         autocomplete="off"
                     >
+                    <div class="field-error{{with index .FieldErrors "api_key"}} show{{end}}" id="api_key_error">{{index .FieldErrors "api_key"}}</div>
                 </div>
 
                 <button type="submit" class="submit-btn" id="submitBtn" disabled>Save Credentials</button>
@@ -499,11 +545,11 @@ const formHTML = `<!DOCTYPE html>
             </div>
 
             <div class="accent-bar">
-                <div class="accent-dot" style="background: #2B75E2"></div>
-                <div class="accent-dot" style="background: #C377FF"></div>
-                <div class="accent-dot" style="background: #F03063"></div>
-                <div class="accent-dot" style="background: #22C55E"></div>
-                <div class="accent-dot" style="background: #FFDA00"></div>
+                <div class="accent-dot accent-blue"></div>
+                <div class="accent-dot accent-purple"></div>
+                <div class="accent-dot accent-pink"></div>
+                <div class="accent-dot accent-green"></div>
+                <div class="accent-dot accent-yellow"></div>
             </div>
         </div>
 
@@ -515,10 +561,11 @@ const formHTML = `<!DOCTYPE html>
         </a>
     </div>
 
-    <script>
+    <script nonce="{{.Nonce}}">
         const form = document.getElementById('authForm');
         const clientIdInput = document.getElementById('client_id');
         const apiKeyInput = document.getElementById('api_key');
+        const copiedInput = document.getElementById('copied');
         const submitBtn = document.getElementById('submitBtn');
         const logoSection = document.getElementById('logoSection');
         const brandSection = document.getElementById('brandSection');
@@ -550,12 +597,19 @@ const formHTML = `<!DOCTYPE html>
                 return;
             }
 
-            // If only one is provided, use it for both
+            // If only one is provided, use it for both and tell the server
+            // which field was copied so only the entered key is validated
+            copiedInput.value = '';
             if (!hasBrand && hasLogo) {
                 apiKeyInput.value = clientIdInput.value.trim();
+                copiedInput.value = 'api_key';
             } else if (!hasLogo && hasBrand) {
                 clientIdInput.value = apiKeyInput.value.trim();
+                copiedInput.value = 'client_id';
             }
+
+            submitBtn.disabled = true;
+            submitBtn.textContent = 'Validating...';
         });
 
         function showError(msg) {
@@ -575,7 +629,7 @@ const successHTML = `<!DOCTYPE html>
     <link rel="preconnect" href="https://fonts.googleapis.com">
     <link rel="preconnect" href="https://fonts.gstatic.com" crossorigin>
     <link href="https://fonts.googleapis.com/css2?family=DM+Sans:ital,opsz,wght@0,9..40,400;0,9..40,500;0,9..40,600;0,9..40,700&display=swap" rel="stylesheet">
-    <style>
+    <style nonce="{{.Nonce}}">
         :root {
             --bg: #F8F7F4;
             --card-bg: #FFFFFF;
@@ -788,6 +842,12 @@ const successHTML = `<!DOCTYPE html>
             border-radius: 50%;
         }
 
+        .accent-blue { background: #2B75E2; }
+        .accent-purple { background: #C377FF; }
+        .accent-pink { background: #F03063; }
+        .accent-green { background: #22C55E; }
+        .accent-yellow { background: #FFDA00; }
+
         .github-link {
             display: flex;
             align-items: center;
@@ -841,11 +901,11 @@ const successHTML = `<!DOCTYPE html>
             </div>
 
             <div class="accent-bar">
-                <div class="accent-dot" style="background: #2B75E2"></div>
-                <div class="accent-dot" style="background: #C377FF"></div>
-                <div class="accent-dot" style="background: #F03063"></div>
-                <div class="accent-dot" style="background: #22C55E"></div>
-                <div class="accent-dot" style="background: #FFDA00"></div>
+                <div class="accent-dot accent-blue"></div>
+                <div class="accent-dot accent-purple"></div>
+                <div class="accent-dot accent-pink"></div>
+                <div class="accent-dot accent-green"></div>
+                <div class="accent-dot accent-yellow"></div>
             </div>
         </div>
 
@@ -859,25 +919,79 @@ const successHTML = `<!DOCTYPE html>
 </body>
 </html>`
 
+var (
+	formTemplate    = template.Must(template.New("form").Parse(formHTML))
+	successTemplate = template.Must(template.New("success").Parse(successHTML))
+)
+
+// pageData is the template data for the form and success pages.
+type pageData struct {
+	Nonce       string
+	State       string
+	Error       string
+	FieldErrors map[string]string
+}
+
 // Handler handles auth form requests.
+//
+// Every request must carry the handler's state token (in the URL for GET, in
+// the form for POST) and a loopback Host header, and POSTs from another
+// origin are refused. After one accepted submission the handler stops serving.
 type Handler struct {
 	resultChan chan<- Credentials
+	validate   Validator
+	state      string
+	onSuccess  func()
+
+	mu   sync.Mutex
+	used bool
 }
 
 // NewHandler creates a new auth handler.
 func NewHandler(resultChan chan<- Credentials) *Handler {
-	return &Handler{resultChan: resultChan}
+	return NewHandlerWithOptions(resultChan, Options{})
+}
+
+// NewHandlerWithOptions creates a new auth handler with the given options.
+func NewHandlerWithOptions(resultChan chan<- Credentials, opts Options) *Handler {
+	return &Handler{resultChan: resultChan, validate: opts.Validate, state: randomToken()}
+}
+
+// State returns the per-session token that requests must present.
+func (h *Handler) State() string {
+	return h.state
 }
 
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("X-Frame-Options", "DENY")
+
 	if r.URL.Path != "/auth" {
 		http.NotFound(w, r)
 		return
 	}
+	if !isLoopbackHost(r.Host) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	h.mu.Lock()
+	used := h.used
+	h.mu.Unlock()
+	if used {
+		http.Error(w, "Credentials were already submitted. Return to your terminal.", http.StatusGone)
+		return
+	}
 
 	switch r.Method {
 	case http.MethodGet:
-		h.showForm(w, r, "")
+		if !h.validState(r.URL.Query().Get("state")) {
+			http.Error(w, "Invalid or missing state token. Use the URL printed in your terminal.", http.StatusForbidden)
+			return
+		}
+		h.showForm(w, http.StatusOK, "", nil)
 	case http.MethodPost:
 		h.handleSubmit(w, r)
 	default:
@@ -885,72 +999,173 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func (h *Handler) showForm(w http.ResponseWriter, _ *http.Request, errorMsg string) {
-	w.Header().Set("Content-Type", "text/html")
-	// Simple template replacement
-	html := formHTML
-	if errorMsg != "" {
-		html = `<!DOCTYPE html><html><body><div class="error">` + errorMsg + `</div>` + formHTML[strings.Index(formHTML, "<form"):]
+func (h *Handler) validState(state string) bool {
+	return state != "" && subtle.ConstantTimeCompare([]byte(state), []byte(h.state)) == 1
+}
+
+// showForm renders the form with an optional general error and per-field errors.
+func (h *Handler) showForm(w http.ResponseWriter, status int, errorMsg string, fieldErrors map[string]string) {
+	h.render(w, status, formTemplate, pageData{
+		State:       h.state,
+		Error:       errorMsg,
+		FieldErrors: fieldErrors,
+	})
+}
+
+// render executes a page template under a CSP that only allows this
+// response's inline style and script blocks, identified by a fresh nonce.
+func (h *Handler) render(w http.ResponseWriter, status int, tmpl *template.Template, data pageData) {
+	data.Nonce = randomToken()
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
 	}
-	_, _ = w.Write([]byte(html))
+
+	w.Header().Set("Content-Security-Policy", fmt.Sprintf(
+		"default-src 'none'; script-src 'nonce-%[1]s'; style-src 'nonce-%[1]s' https://fonts.googleapis.com; "+
+			"font-src https://fonts.gstatic.com; img-src 'self' data:; form-action 'self'; "+
+			"frame-ancestors 'none'; base-uri 'none'", data.Nonce))
+	w.Header().Set("Content-Type", "text/html")
+	w.WriteHeader(status)
+	_, _ = w.Write(buf.Bytes())
 }
 
 func (h *Handler) handleSubmit(w http.ResponseWriter, r *http.Request) {
+	if origin := r.Header.Get("Origin"); origin != "" && origin != "http://"+r.Host {
+		http.Error(w, "Cross-origin request refused", http.StatusForbidden)
+		return
+	}
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
 		return
 	}
+	if !h.validState(r.PostFormValue("state")) {
+		http.Error(w, "Invalid or missing state token. Use the URL printed in your terminal.", http.StatusForbidden)
+		return
+	}
 
-	clientID := r.FormValue("client_id")
-	apiKey := r.FormValue("api_key")
+	creds := Credentials{
+		ClientID: strings.TrimSpace(r.PostFormValue(FieldClientID)),
+		APIKey:   strings.TrimSpace(r.PostFormValue(FieldAPIKey)),
+	}
 
 	// At least one key must be provided
-	if clientID == "" && apiKey == "" {
+	if creds.ClientID == "" && creds.APIKey == "" {
 		http.Error(w, "At least one API key is required", http.StatusBadRequest)
 		return
 	}
 
-	// Send credentials to channel
-	h.resultChan <- Credentials{
-		ClientID: clientID,
-		APIKey:   apiKey,
+	// Submissions are serialized so only one can be accepted.
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.used {
+		http.Error(w, "Credentials were already submitted. Return to your terminal.", http.StatusGone)
+		return
 	}
 
-	w.Header().Set("Content-Type", "text/html")
-	_, _ = w.Write([]byte(successHTML))
+	if h.validate != nil {
+		if fieldErrors := h.validate(r.Context(), validatedCredentials(creds, r.PostFormValue("copied"))); len(fieldErrors) > 0 {
+			h.showForm(w, http.StatusUnprocessableEntity, "Some keys were rejected. Fix them and try again.", fieldErrors)
+			return
+		}
+	}
+
+	h.used = true
+	h.resultChan <- creds
+
+	h.render(w, http.StatusOK, successTemplate, pageData{})
+	if h.onSuccess != nil {
+		go h.onSuccess()
+	}
+}
+
+// validatedCredentials returns the keys to validate for a submission. A key
+// the form copied from the other field is left out, since a Logo API client
+// ID is not a valid Brand API key or the reverse.
+func validatedCredentials(creds Credentials, copied string) Credentials {
+	if creds.ClientID != creds.APIKey {
+		return creds
+	}
+	switch copied {
+	case FieldClientID:
+		creds.ClientID = ""
+	case FieldAPIKey:
+		creds.APIKey = ""
+	}
+	return creds
+}
+
+// isLoopbackHost reports whether a Host header names this machine, which
+// rejects DNS-rebinding requests that reach the port under another name.
+func isLoopbackHost(host string) bool {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(strings.Trim(host, "[]"))
+	return ip != nil && ip.IsLoopback()
+}
+
+// randomToken returns 32 random bytes, hex encoded.
+func randomToken() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("crypto/rand failed: %v", err))
+	}
+	return hex.EncodeToString(b)
 }
 
 // Server runs the auth server.
 type Server struct {
 	listener   net.Listener
 	server     *http.Server
+	handler    *Handler
 	resultChan chan Credentials
 }
 
 // NewServer creates a new auth server on an ephemeral port.
 func NewServer() (*Server, error) {
-	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	return NewServerWithOptions(Options{})
+}
+
+// NewServerWithOptions creates a new auth server with the given options.
+// The server shuts itself down after one successful submission.
+func NewServerWithOptions(opts Options) (*Server, error) {
+	if opts.Port < 0 || opts.Port > 65535 {
+		return nil, fmt.Errorf("invalid port %d", opts.Port)
+	}
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", opts.Port))
 	if err != nil {
 		return nil, err
 	}
 
 	resultChan := make(chan Credentials, 1)
-	handler := NewHandler(resultChan)
-
-	server := &http.Server{
-		Handler: handler,
+	handler := NewHandlerWithOptions(resultChan, opts)
+
+	s := &Server{
+		listener: listener,
+		server: &http.Server{
+			Handler:           handler,
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+		handler:    handler,
+		resultChan: resultChan,
 	}
+	handler.onSuccess = func() { _ = s.Shutdown() }
+	return s, nil
+}
 
-	return &Server{
-		listener:   listener,
-		server:     server,
-		resultChan: resultChan,
-	}, nil
+// Port returns the port the server listens on.
+func (s *Server) Port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
 }
 
-// URL returns the server URL.
+// URL returns the server URL, including the session's state token.
 func (s *Server) URL() string {
-	return fmt.Sprintf("http://%s/auth", s.listener.Addr().String())
+	return fmt.Sprintf("http://%s/auth?state=%s", s.listener.Addr().String(), s.handler.State())
 }
 
 // Start starts the server in the background.
@@ -1,6 +1,9 @@
 package authserver
 
 import (
+	"context"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -9,11 +12,21 @@ import (
 	"time"
 )
 
+// newAuthRequest builds a request addressed to the loopback server.
+func newAuthRequest(method, target string, body io.Reader) *http.Request {
+	req := httptest.NewRequest(method, target, body)
+	req.Host = "127.0.0.1:8085"
+	if body != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	return req
+}
+
 func TestAuthServer_FormPage(t *testing.T) {
 	resultChan := make(chan Credentials, 1)
 	handler := NewHandler(resultChan)
 
-	req := httptest.NewRequest("GET", "/auth", nil)
+	req := newAuthRequest("GET", "/auth?state="+handler.State(), nil)
 	w := httptest.NewRecorder()
 
 	handler.ServeHTTP(w, req)
@@ -29,6 +42,9 @@ func TestAuthServer_FormPage(t *testing.T) {
 	if !strings.Contains(body, "api_key") {
 		t.Errorf("form page missing api_key field")
 	}
+	if !strings.Contains(body, `name="copied"`) {
+		t.Errorf("form page missing copied field")
+	}
 }
 
 func TestAuthServer_Submit(t *testing.T) {
@@ -39,7 +55,8 @@ func TestAuthServer_Submit(t *testing.T) {
 	form.Set("client_id", "test_client_id")
 	form.Set("api_key", "test_api_key")
 
-	req := httptest.NewRequest("POST", "/auth", strings.NewReader(form.Encode()))
+	form.Set("state", handler.State())
+	req := newAuthRequest("POST", "/auth", strings.NewReader(form.Encode()))
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	w := httptest.NewRecorder()
 
@@ -63,13 +80,68 @@ func TestAuthServer_Submit(t *testing.T) {
 	}
 }
 
+func TestAuthServer_SubmitSingleKeyCopied(t *testing.T) {
+	tests := []struct {
+		name          string
+		copied        string
+		wantValidated Credentials
+	}{
+		{"client id entered", FieldAPIKey, Credentials{ClientID: "only_key"}},
+		{"api key entered", FieldClientID, Credentials{APIKey: "only_key"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resultChan := make(chan Credentials, 1)
+			var validated Credentials
+			handler := NewHandlerWithOptions(resultChan, Options{
+				Validate: func(ctx context.Context, creds Credentials) map[string]string {
+					validated = creds
+					// A key checked against the wrong API is rejected.
+					if creds.ClientID != "" && creds.APIKey != "" {
+						return map[string]string{FieldAPIKey: "Rejected by the API"}
+					}
+					return nil
+				},
+			})
+
+			// The form copies a single entered key into the other field.
+			form := url.Values{}
+			form.Set("client_id", "only_key")
+			form.Set("api_key", "only_key")
+			form.Set("copied", tt.copied)
+			form.Set("state", handler.State())
+			req := newAuthRequest("POST", "/auth", strings.NewReader(form.Encode()))
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("POST /auth status = %d, want 200", w.Code)
+			}
+			if validated != tt.wantValidated {
+				t.Errorf("validated %+v, want %+v", validated, tt.wantValidated)
+			}
+			select {
+			case creds := <-resultChan:
+				if creds.ClientID != "only_key" || creds.APIKey != "only_key" {
+					t.Errorf("credentials = %+v, want the key in both fields", creds)
+				}
+			default:
+				t.Error("credentials not received on channel")
+			}
+		})
+	}
+}
+
 func TestAuthServer_SubmitValidation(t *testing.T) {
 	resultChan := make(chan Credentials, 1)
 	handler := NewHandler(resultChan)
 
 	// Empty form
 	form := url.Values{}
-	req := httptest.NewRequest("POST", "/auth", strings.NewReader(form.Encode()))
+	form.Set("state", handler.State())
+	req := newAuthRequest("POST", "/auth", strings.NewReader(form.Encode()))
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	w := httptest.NewRecorder()
 
@@ -80,6 +152,82 @@ func TestAuthServer_SubmitValidation(t *testing.T) {
 	}
 }
 
+func TestAuthServer_SubmitRejectedByValidator(t *testing.T) {
+	resultChan := make(chan Credentials, 1)
+	handler := NewHandlerWithOptions(resultChan, Options{
+		Validate: func(ctx context.Context, creds Credentials) map[string]string {
+			if creds.APIKey == "bad_key" {
+				return map[string]string{FieldAPIKey: "Rejected <by> the API"}
+			}
+			return nil
+		},
+	})
+
+	form := url.Values{}
+	form.Set("client_id", "good_client")
+	form.Set("api_key", "bad_key")
+	form.Set("state", handler.State())
+	req := newAuthRequest("POST", "/auth", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("POST /auth status = %d, want 422", w.Code)
+	}
+	body := w.Body.String()
+	if !strings.Contains(body, `<div class="field-error show" id="api_key_error">Rejected &lt;by&gt; the API</div>`) {
+		t.Errorf("form missing escaped api_key error")
+	}
+	if strings.Contains(body, `<div class="field-error show" id="client_id_error">`) {
+		t.Errorf("client_id should not show an error")
+	}
+	if strings.Contains(body, "bad_key") {
+		t.Errorf("form must not echo submitted keys")
+	}
+	select {
+	case creds := <-resultChan:
+		t.Errorf("rejected credentials sent to channel: %+v", creds)
+	default:
+	}
+}
+
+func TestAuthServer_SubmitAcceptedByValidator(t *testing.T) {
+	resultChan := make(chan Credentials, 1)
+	var validated Credentials
+	handler := NewHandlerWithOptions(resultChan, Options{
+		Validate: func(ctx context.Context, creds Credentials) map[string]string {
+			validated = creds
+			return nil
+		},
+	})
+
+	form := url.Values{}
+	form.Set("client_id", " test_client ")
+	form.Set("state", handler.State())
+	req := newAuthRequest("POST", "/auth", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("POST /auth status = %d, want 200", w.Code)
+	}
+	if validated.ClientID != "test_client" {
+		t.Errorf("validated ClientID = %q, want trimmed test_client", validated.ClientID)
+	}
+	select {
+	case creds := <-resultChan:
+		if creds.ClientID != "test_client" {
+			t.Errorf("ClientID = %q, want test_client", creds.ClientID)
+		}
+	default:
+		t.Error("credentials not received on channel")
+	}
+}
+
 func TestAuthServer_NotFound(t *testing.T) {
 	resultChan := make(chan Credentials, 1)
 	handler := NewHandler(resultChan)
@@ -95,7 +243,7 @@ func TestAuthServer_NotFound(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			req := httptest.NewRequest("GET", tt.path, nil)
+			req := newAuthRequest("GET", tt.path, nil)
 			w := httptest.NewRecorder()
 
 			handler.ServeHTTP(w, req)
@@ -121,7 +269,7 @@ func TestAuthServer_MethodNotAllowed(t *testing.T) {
 
 	for _, method := range methods {
 		t.Run(method, func(t *testing.T) {
-			req := httptest.NewRequest(method, "/auth", nil)
+			req := newAuthRequest(method, "/auth", nil)
 			w := httptest.NewRecorder()
 
 			handler.ServeHTTP(w, req)
@@ -173,7 +321,8 @@ func TestAuthServer_PartialCredentials(t *testing.T) {
 				form.Set("api_key", tt.apiKey)
 			}
 
-			req := httptest.NewRequest("POST", "/auth", strings.NewReader(form.Encode()))
+			form.Set("state", handler.State())
+			req := newAuthRequest("POST", "/auth", strings.NewReader(form.Encode()))
 			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 			w := httptest.NewRecorder()
 
@@ -207,7 +356,8 @@ func TestAuthServer_SuccessPageContent(t *testing.T) {
 	form.Set("client_id", "test_client_id")
 	form.Set("api_key", "test_api_key")
 
-	req := httptest.NewRequest("POST", "/auth", strings.NewReader(form.Encode()))
+	form.Set("state", handler.State())
+	req := newAuthRequest("POST", "/auth", strings.NewReader(form.Encode()))
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	w := httptest.NewRecorder()
 
@@ -251,8 +401,8 @@ func TestServer_URL(t *testing.T) {
 		t.Errorf("URL = %q, want prefix http://127.0.0.1:", url)
 	}
 
-	if !strings.HasSuffix(url, "/auth") {
-		t.Errorf("URL = %q, want suffix /auth", url)
+	if !strings.Contains(url, "/auth?state="+server.handler.State()) {
+		t.Errorf("URL = %q, want /auth?state=<token>", url)
 	}
 
 	// Verify URL contains a valid port
@@ -360,3 +510,199 @@ func TestServer_WaitForCredentials_Timeout(t *testing.T) {
 		t.Errorf("elapsed time = %v, want approximately %v", elapsed, timeout)
 	}
 }
+
+func TestAuthServer_RejectsBadState(t *testing.T) {
+	resultChan := make(chan Credentials, 1)
+	handler := NewHandler(resultChan)
+
+	for _, target := range []string{"/auth", "/auth?state=wrong"} {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newAuthRequest("GET", target, nil))
+		if w.Code != http.StatusForbidden {
+			t.Errorf("GET %s status = %d, want 403", target, w.Code)
+		}
+	}
+
+	form := url.Values{}
+	form.Set("client_id", "test_client")
+	form.Set("state", "wrong")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newAuthRequest("POST", "/auth", strings.NewReader(form.Encode())))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("POST with wrong state status = %d, want 403", w.Code)
+	}
+
+	// The state in the URL is not enough for a POST; it must be in the form.
+	form.Del("state")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, newAuthRequest("POST", "/auth?state="+handler.State(), strings.NewReader(form.Encode())))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("POST with state only in URL status = %d, want 403", w.Code)
+	}
+
+	select {
+	case creds := <-resultChan:
+		t.Errorf("credentials accepted without a valid state: %+v", creds)
+	default:
+	}
+}
+
+func TestAuthServer_RejectsForeignHostAndOrigin(t *testing.T) {
+	resultChan := make(chan Credentials, 1)
+	handler := NewHandler(resultChan)
+
+	req := newAuthRequest("GET", "/auth?state="+handler.State(), nil)
+	req.Host = "evil.example:8085"
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("GET with foreign Host status = %d, want 403", w.Code)
+	}
+
+	form := url.Values{}
+	form.Set("client_id", "test_client")
+	form.Set("state", handler.State())
+	req = newAuthRequest("POST", "/auth", strings.NewReader(form.Encode()))
+	req.Header.Set("Origin", "https://evil.example")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("cross-origin POST status = %d, want 403", w.Code)
+	}
+
+	req = newAuthRequest("POST", "/auth", strings.NewReader(form.Encode()))
+	req.Header.Set("Origin", "http://127.0.0.1:8085")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("same-origin POST status = %d, want 200", w.Code)
+	}
+}
+
+func TestAuthServer_SecurityHeaders(t *testing.T) {
+	handler := NewHandler(make(chan Credentials, 1))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newAuthRequest("GET", "/auth?state="+handler.State(), nil))
+
+	csp := w.Header().Get("Content-Security-Policy")
+	if !strings.Contains(csp, "default-src 'none'") || !strings.Contains(csp, "frame-ancestors 'none'") {
+		t.Errorf("Content-Security-Policy = %q", csp)
+	}
+	start := strings.Index(csp, "'nonce-")
+	if start < 0 {
+		t.Fatalf("CSP has no nonce: %q", csp)
+	}
+	nonce := csp[start+len("'nonce-"):]
+	nonce = nonce[:strings.Index(nonce, "'")]
+	body := w.Body.String()
+	if !strings.Contains(body, `<script nonce="`+nonce+`">`) || !strings.Contains(body, `<style nonce="`+nonce+`">`) {
+		t.Errorf("inline script and style should carry the CSP nonce")
+	}
+	if strings.Contains(body, ` style="`) {
+		t.Errorf("inline style attributes are blocked by the CSP")
+	}
+	if !strings.Contains(body, `name="state" value="`+handler.State()+`"`) {
+		t.Errorf("form missing state field")
+	}
+	if w.Header().Get("Referrer-Policy") != "no-referrer" {
+		t.Errorf("Referrer-Policy = %q, want no-referrer", w.Header().Get("Referrer-Policy"))
+	}
+}
+
+func TestAuthServer_SingleUse(t *testing.T) {
+	resultChan := make(chan Credentials, 2)
+	handler := NewHandler(resultChan)
+
+	submit := func() int {
+		form := url.Values{}
+		form.Set("client_id", "test_client")
+		form.Set("state", handler.State())
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newAuthRequest("POST", "/auth", strings.NewReader(form.Encode())))
+		return w.Code
+	}
+
+	if code := submit(); code != http.StatusOK {
+		t.Fatalf("first POST status = %d, want 200", code)
+	}
+	if code := submit(); code != http.StatusGone {
+		t.Errorf("second POST status = %d, want 410", code)
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newAuthRequest("GET", "/auth?state="+handler.State(), nil))
+	if w.Code != http.StatusGone {
+		t.Errorf("GET after submit status = %d, want 410", w.Code)
+	}
+	if len(resultChan) != 1 {
+		t.Errorf("received %d submissions, want 1", len(resultChan))
+	}
+}
+
+func TestServer_ShutsDownAfterSubmit(t *testing.T) {
+	server, err := NewServer()
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+	defer server.Shutdown()
+	server.Start()
+
+	form := url.Values{}
+	form.Set("client_id", "test_client")
+	form.Set("state", server.handler.State())
+	resp, err := http.PostForm(strings.Split(server.URL(), "?")[0], form)
+	if err != nil {
+		t.Fatalf("POST error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("POST status = %d, want 200", resp.StatusCode)
+	}
+
+	creds, err := server.WaitForCredentials(time.Second)
+	if err != nil || creds.ClientID != "test_client" {
+		t.Fatalf("WaitForCredentials() = %v, %v", creds, err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		resp, err := http.Get(server.URL())
+		if err != nil {
+			break
+		}
+		resp.Body.Close()
+		if time.Now().After(deadline) {
+			t.Fatal("server still serving after a successful submission")
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func TestServer_FixedPort(t *testing.T) {
+	// Find a free port, then ask for it explicitly.
+	probe, err := NewServer()
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+	port := probe.Port()
+	probe.listener.Close()
+
+	server, err := NewServerWithOptions(Options{Port: port})
+	if err != nil {
+		t.Fatalf("NewServerWithOptions() error = %v", err)
+	}
+	defer server.Shutdown()
+	if server.Port() != port {
+		t.Errorf("Port() = %d, want %d", server.Port(), port)
+	}
+	if !strings.HasPrefix(server.URL(), fmt.Sprintf("http://127.0.0.1:%d/auth?state=", port)) {
+		t.Errorf("URL() = %q", server.URL())
+	}
+
+	if _, err := NewServerWithOptions(Options{Port: port}); err == nil {
+		t.Error("expected error when the port is in use")
+	}
+	if _, err := NewServerWithOptions(Options{Port: 70000}); err == nil {
+		t.Error("expected error for an invalid port")
+	}
+}
@@ -0,0 +1,333 @@
+// Package branddiff compares two brand snapshots field by field and renders
+// the differences as text, Markdown or an RFC 6902 JSON patch.
+package branddiff
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/salmonumbrella/brandfetch-cli/pkg/brandfetch"
+)
+
+// Kind says how a field changed.
+type Kind string
+
+// Change kinds.
+const (
+	Added   Kind = "added"
+	Removed Kind = "removed"
+	Changed Kind = "changed"
+)
+
+// Change is one difference between two brands. Field is the brand field
+// ("colors", "description", ...) and Key identifies the entry within it,
+// such as a color type or a logo's type/theme/format.
+type Change struct {
+	Kind  Kind   `json:"kind"`
+	Field string `json:"field"`
+	Key   string `json:"key,omitempty"`
+	Old   string `json:"old,omitempty"`
+	New   string `json:"new,omitempty"`
+	// Distance is the CIE76 colour difference (ΔE) of a changed colour.
+	Distance *float64 `json:"distance,omitempty"`
+}
+
+// Diff is the result of comparing two brands.
+type Diff struct {
+	Name    string   `json:"name"`
+	Domain  string   `json:"domain"`
+	Changes []Change `json:"changes"`
+
+	ops []Operation
+}
+
+// Options tune the comparison.
+type Options struct {
+	// Hash returns a digest of the content at a logo URL. When set, logos
+	// whose URL changed but whose content did not are not reported. A logo
+	// that cannot be hashed counts as changed.
+	Hash func(url string) (string, error)
+}
+
+// Compare lists the differences from old to new.
+func Compare(old, new *brandfetch.Brand, opts Options) *Diff {
+	d := &Diff{Name: new.Name, Domain: new.Domain}
+	if d.Name == "" {
+		d.Name = old.Name
+	}
+	if d.Domain == "" {
+		d.Domain = old.Domain
+	}
+
+	d.compareScalar("name", "/name", old.Name, new.Name)
+	d.compareScalar("domain", "/domain", old.Domain, new.Domain)
+	d.compareScalar("description", "/description", old.Description, new.Description)
+	d.compareScalar("longDescription", "/longDescription", old.LongDescription, new.LongDescription)
+	d.compareColors(old.Colors, new.Colors)
+	d.compareFonts(old.Fonts, new.Fonts)
+	d.compareLogos(old.Logos, new.Logos, opts)
+	d.compareLinks(old.Links, new.Links)
+	return d
+}
+
+func (d *Diff) add(c Change) {
+	d.Changes = append(d.Changes, c)
+}
+
+func (d *Diff) op(phase int, op, path string, value interface{}) {
+	var raw json.RawMessage
+	if op != "remove" {
+		raw, _ = json.Marshal(value)
+	}
+	d.ops = append(d.ops, Operation{Op: op, Path: path, Value: raw, phase: phase})
+}
+
+// setOp returns the patch operation that sets an object member. An empty old
+// value may be absent from the snapshot, and RFC 6902 only allows "replace"
+// on members that exist; "add" sets the member either way.
+func setOp(old string) string {
+	if old == "" {
+		return "add"
+	}
+	return "replace"
+}
+
+func (d *Diff) compareScalar(field, path, old, new string) {
+	if old == new {
+		return
+	}
+	d.add(Change{Kind: Changed, Field: field, Old: old, New: new})
+	d.op(phaseReplace, setOp(old), path, new)
+}
+
+func (d *Diff) compareColors(old, new []brandfetch.Color) {
+	oldUsed := make([]bool, len(old))
+	newUsed := make([]bool, len(new))
+
+	// Same hex: at most the type changed.
+	for i, oc := range old {
+		for j, nc := range new {
+			if newUsed[j] || !strings.EqualFold(oc.Hex, nc.Hex) {
+				continue
+			}
+			oldUsed[i], newUsed[j] = true, true
+			if oc.Type != nc.Type {
+				d.add(Change{Kind: Changed, Field: "colors", Key: nc.Hex, Old: oc.Type, New: nc.Type})
+				d.op(phaseReplace, "replace", fmt.Sprintf("/colors/%d", i), nc)
+			}
+			break
+		}
+	}
+	// Same type, different hex: the colour was changed.
+	for i, oc := range old {
+		if oldUsed[i] {
+			continue
+		}
+		for j, nc := range new {
+			if newUsed[j] || oc.Type != nc.Type {
+				continue
+			}
+			oldUsed[i], newUsed[j] = true, true
+			c := Change{Kind: Changed, Field: "colors", Key: nc.Type, Old: oc.Hex, New: nc.Hex}
+			if dist, ok := ColorDistance(oc.Hex, nc.Hex); ok {
+				c.Distance = &dist
+			}
+			d.add(c)
+			d.op(phaseReplace, "replace", fmt.Sprintf("/colors/%d", i), nc)
+			break
+		}
+	}
+	for i, oc := range old {
+		if !oldUsed[i] {
+			d.add(Change{Kind: Removed, Field: "colors", Key: oc.Type, Old: oc.Hex})
+			d.op(phaseRemove, "remove", fmt.Sprintf("/colors/%d", i), nil)
+		}
+	}
+	for j, nc := range new {
+		if !newUsed[j] {
+			d.add(Change{Kind: Added, Field: "colors", Key: nc.Type, New: nc.Hex})
+			d.op(phaseAppend, "add", "/colors/-", nc)
+		}
+	}
+}
+
+func (d *Diff) compareFonts(old, new []brandfetch.Font) {
+	oldUsed := make([]bool, len(old))
+	newUsed := make([]bool, len(new))
+
+	for i, of := range old {
+		for j, nf := range new {
+			if !newUsed[j] && of.Type == nf.Type && of.Name == nf.Name {
+				oldUsed[i], newUsed[j] = true, true
+				break
+			}
+		}
+	}
+	for i, of := range old {
+		if oldUsed[i] || of.Type == "" {
+			continue
+		}
+		for j, nf := range new {
+			if newUsed[j] || of.Type != nf.Type {
+				continue
+			}
+			oldUsed[i], newUsed[j] = true, true
+			d.add(Change{Kind: Changed, Field: "fonts", Key: nf.Type, Old: of.Name, New: nf.Name})
+			d.op(phaseReplace, "replace", fmt.Sprintf("/fonts/%d", i), nf)
+			break
+		}
+	}
+	for i, of := range old {
+		if !oldUsed[i] {
+			d.add(Change{Kind: Removed, Field: "fonts", Key: of.Type, Old: of.Name})
+			d.op(phaseRemove, "remove", fmt.Sprintf("/fonts/%d", i), nil)
+		}
+	}
+	for j, nf := range new {
+		if !newUsed[j] {
+			d.add(Change{Kind: Added, Field: "fonts", Key: nf.Type, New: nf.Name})
+			d.op(phaseAppend, "add", "/fonts/-", nf)
+		}
+	}
+}
+
+func (d *Diff) compareLinks(old, new []brandfetch.Link) {
+	newUsed := make([]bool, len(new))
+	for i, ol := range old {
+		found := false
+		for j, nl := range new {
+			if newUsed[j] || ol.Name != nl.Name {
+				continue
+			}
+			newUsed[j], found = true, true
+			if ol.URL != nl.URL {
+				d.add(Change{Kind: Changed, Field: "links", Key: nl.Name, Old: ol.URL, New: nl.URL})
+				d.op(phaseReplace, setOp(ol.URL), fmt.Sprintf("/links/%d/url", i), nl.URL)
+			}
+			break
+		}
+		if !found {
+			d.add(Change{Kind: Removed, Field: "links", Key: ol.Name, Old: ol.URL})
+			d.op(phaseRemove, "remove", fmt.Sprintf("/links/%d", i), nil)
+		}
+	}
+	for j, nl := range new {
+		if !newUsed[j] {
+			d.add(Change{Kind: Added, Field: "links", Key: nl.Name, New: nl.URL})
+			d.op(phaseAppend, "add", "/links/-", nl)
+		}
+	}
+}
+
+// logoFormat is one format of one logo entry, addressed by its indices.
+type logoFormat struct {
+	logo, format int
+	key          string
+	src          string
+}
+
+func flattenLogos(logos []brandfetch.Logo) []logoFormat {
+	var flat []logoFormat
+	for i, l := range logos {
+		for j, f := range l.Formats {
+			flat = append(flat, logoFormat{logo: i, format: j, key: logoKey(l, f), src: f.Src})
+		}
+	}
+	return flat
+}
+
+func logoKey(l brandfetch.Logo, f brandfetch.LogoFormat) string {
+	return fmt.Sprintf("%s/%s/%s", l.Type, l.Theme, f.Format)
+}
+
+func (d *Diff) compareLogos(old, new []brandfetch.Logo, opts Options) {
+	oldFlat := flattenLogos(old)
+	newFlat := flattenLogos(new)
+	oldUsed := make([]bool, len(oldFlat))
+	newUsed := make([]bool, len(newFlat))
+
+	for i, of := range oldFlat {
+		for j, nf := range newFlat {
+			if newUsed[j] || of.key != nf.key {
+				continue
+			}
+			oldUsed[i], newUsed[j] = true, true
+			if of.src == nf.src {
+				break
+			}
+			if opts.Hash != nil && sameContent(opts.Hash, of.src, nf.src) {
+				break
+			}
+			d.add(Change{Kind: Changed, Field: "logos", Key: nf.key, Old: of.src, New: nf.src})
+			d.op(phaseReplace, setOp(of.src), fmt.Sprintf("/logos/%d/formats/%d/src", of.logo, of.format), nf.src)
+			break
+		}
+	}
+
+	// A logo entry whose formats all went away is removed as a whole.
+	remaining := make([]int, len(old))
+	for i, of := range oldFlat {
+		if oldUsed[i] {
+			remaining[of.logo]++
+		}
+	}
+	for i, of := range oldFlat {
+		if oldUsed[i] {
+			continue
+		}
+		d.add(Change{Kind: Removed, Field: "logos", Key: of.key, Old: of.src})
+		if remaining[of.logo] > 0 {
+			d.op(phaseRemove, "remove", fmt.Sprintf("/logos/%d/formats/%d", of.logo, of.format), nil)
+		}
+	}
+	for i, l := range old {
+		if remaining[i] == 0 && len(l.Formats) > 0 {
+			d.op(phaseRemove, "remove", fmt.Sprintf("/logos/%d", i), nil)
+		}
+	}
+
+	// New formats join a surviving logo entry of the same type and theme, or
+	// are appended as a new entry.
+	appended := make(map[int]*brandfetch.Logo)
+	var order []int
+	for j, nf := range newFlat {
+		if newUsed[j] {
+			continue
+		}
+		nl := new[nf.logo]
+		format := nl.Formats[nf.format]
+		d.add(Change{Kind: Added, Field: "logos", Key: nf.key, New: nf.src})
+
+		target := -1
+		for i, ol := range old {
+			if ol.Type == nl.Type && ol.Theme == nl.Theme && remaining[i] > 0 {
+				target = i
+				break
+			}
+		}
+		if target >= 0 {
+			d.op(phaseNestedAdd, "add", fmt.Sprintf("/logos/%d/formats/-", target), format)
+			continue
+		}
+		entry, ok := appended[nf.logo]
+		if !ok {
+			entry = &brandfetch.Logo{Type: nl.Type, Theme: nl.Theme, Tags: nl.Tags}
+			appended[nf.logo] = entry
+			order = append(order, nf.logo)
+		}
+		entry.Formats = append(entry.Formats, format)
+	}
+	for _, k := range order {
+		d.op(phaseAppend, "add", "/logos/-", appended[k])
+	}
+}
+
+func sameContent(hash func(string) (string, error), oldURL, newURL string) bool {
+	oldSum, err := hash(oldURL)
+	if err != nil {
+		return false
+	}
+	newSum, err := hash(newURL)
+	return err == nil && oldSum == newSum
+}
@@ -0,0 +1,321 @@
+package branddiff
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/brandfetch-cli/pkg/brandfetch"
+)
+
+func oldBrand() *brandfetch.Brand {
+	return &brandfetch.Brand{
+		Name:        "Stripe",
+		Domain:      "stripe.com",
+		Description: "Payments infrastructure",
+		Colors: []brandfetch.Color{
+			{Hex: "#635bff", Type: "accent"},
+			{Hex: "#0a2540", Type: "dark"},
+			{Hex: "#ffffff", Type: "light"},
+		},
+		Fonts: []brandfetch.Font{
+			{Name: "Sohne", Type: "title"},
+			{Name: "Sohne", Type: "body"},
+		},
+		Logos: []brandfetch.Logo{
+			{Type: "logo", Theme: "light", Formats: []brandfetch.LogoFormat{
+				{Src: "https://asset/logo-light-v1.svg", Format: "svg"},
+				{Src: "https://asset/logo-light-v1.png", Format: "png"},
+			}},
+			{Type: "logo", Theme: "dark", Formats: []brandfetch.LogoFormat{
+				{Src: "https://asset/logo-dark-v1.svg", Format: "svg"},
+			}},
+			{Type: "icon", Theme: "light", Formats: []brandfetch.LogoFormat{
+				{Src: "https://asset/icon-v1.png", Format: "png"},
+			}},
+		},
+		Links: []brandfetch.Link{
+			{Name: "twitter", URL: "https://twitter.com/stripe"},
+			{Name: "github", URL: "https://github.com/stripe"},
+		},
+	}
+}
+
+func newBrand() *brandfetch.Brand {
+	return &brandfetch.Brand{
+		Name:        "Stripe",
+		Domain:      "stripe.com",
+		Description: "Financial infrastructure for the internet",
+		Colors: []brandfetch.Color{
+			{Hex: "#533AFD", Type: "accent"},
+			{Hex: "#ffffff", Type: "brand"},
+			{Hex: "#00d4ff", Type: "dark"},
+			{Hex: "#fcd669", Type: "highlight"},
+		},
+		Fonts: []brandfetch.Font{
+			{Name: "Sohne", Type: "title"},
+			{Name: "Inter", Type: "body"},
+		},
+		Logos: []brandfetch.Logo{
+			{Type: "logo", Theme: "light", Formats: []brandfetch.LogoFormat{
+				{Src: "https://asset/logo-light-v2.svg", Format: "svg"},
+				{Src: "https://asset/logo-light-v1.png", Format: "png"},
+				{Src: "https://asset/logo-light-v2.webp", Format: "webp"},
+			}},
+			{Type: "icon", Theme: "light", Formats: []brandfetch.LogoFormat{
+				{Src: "https://asset/icon-v2.png", Format: "png"},
+			}},
+			{Type: "symbol", Theme: "dark", Formats: []brandfetch.LogoFormat{
+				{Src: "https://asset/symbol-dark.svg", Format: "svg"},
+			}},
+		},
+		Links: []brandfetch.Link{
+			{Name: "twitter", URL: "https://x.com/stripe"},
+			{Name: "linkedin", URL: "https://linkedin.com/company/stripe"},
+		},
+	}
+}
+
+func TestCompare(t *testing.T) {
+	d := Compare(oldBrand(), newBrand(), Options{})
+
+	got := make(map[string]Change)
+	for _, c := range d.Changes {
+		got[string(c.Kind)+" "+c.label()] = c
+	}
+	want := map[string][2]string{
+		"changed description":           {"Payments infrastructure", "Financial infrastructure for the internet"},
+		"changed colors[accent]":        {"#635bff", "#533AFD"},
+		"changed colors[#ffffff]":       {"light", "brand"},
+		"changed colors[dark]":          {"#0a2540", "#00d4ff"},
+		"added colors[highlight]":       {"", "#fcd669"},
+		"changed fonts[body]":           {"Sohne", "Inter"},
+		"changed logos[logo/light/svg]": {"https://asset/logo-light-v1.svg", "https://asset/logo-light-v2.svg"},
+		"added logos[logo/light/webp]":  {"", "https://asset/logo-light-v2.webp"},
+		"removed logos[logo/dark/svg]":  {"https://asset/logo-dark-v1.svg", ""},
+		"changed logos[icon/light/png]": {"https://asset/icon-v1.png", "https://asset/icon-v2.png"},
+		"added logos[symbol/dark/svg]":  {"", "https://asset/symbol-dark.svg"},
+		"changed links[twitter]":        {"https://twitter.com/stripe", "https://x.com/stripe"},
+		"removed links[github]":         {"https://github.com/stripe", ""},
+		"added links[linkedin]":         {"", "https://linkedin.com/company/stripe"},
+	}
+	for key, w := range want {
+		c, ok := got[key]
+		if !ok {
+			t.Errorf("missing change %q", key)
+			continue
+		}
+		if c.Old != w[0] || c.New != w[1] {
+			t.Errorf("%s: %q -> %q, want %q -> %q", key, c.Old, c.New, w[0], w[1])
+		}
+	}
+	if len(d.Changes) != len(want) {
+		t.Errorf("got %d changes, want %d: %+v", len(d.Changes), len(want), d.Changes)
+	}
+
+	accent := got["changed colors[accent]"]
+	if accent.Distance == nil || math.Abs(*accent.Distance-20.17) > 0.01 {
+		t.Errorf("accent distance = %v, want ΔE 20.17", accent.Distance)
+	}
+}
+
+func TestCompare_NoChanges(t *testing.T) {
+	d := Compare(oldBrand(), oldBrand(), Options{})
+	if len(d.Changes) != 0 {
+		t.Errorf("Changes = %+v, want none", d.Changes)
+	}
+	if ops := d.Patch(); len(ops) != 0 {
+		t.Errorf("Patch() = %+v, want none", ops)
+	}
+	if !strings.Contains(d.Text(), "no changes") {
+		t.Errorf("Text() = %q", d.Text())
+	}
+}
+
+func TestCompare_HashIgnoresMovedLogos(t *testing.T) {
+	old := oldBrand()
+	new := oldBrand()
+	new.Logos[0].Formats[0].Src = "https://asset/logo-light-moved.svg"
+	new.Logos[2].Formats[0].Src = "https://asset/icon-redrawn.png"
+
+	hashes := map[string]string{
+		"https://asset/logo-light-v1.svg":    "aaa",
+		"https://asset/logo-light-moved.svg": "aaa",
+		"https://asset/icon-v1.png":          "bbb",
+		"https://asset/icon-redrawn.png":     "ccc",
+	}
+	d := Compare(old, new, Options{Hash: func(url string) (string, error) {
+		if h, ok := hashes[url]; ok {
+			return h, nil
+		}
+		return "", fmt.Errorf("not found")
+	}})
+	if len(d.Changes) != 1 || d.Changes[0].Key != "icon/light/png" {
+		t.Errorf("Changes = %+v, want only the redrawn icon", d.Changes)
+	}
+}
+
+func TestPatch_AppliesToOldBrand(t *testing.T) {
+	old, new := oldBrand(), newBrand()
+	d := Compare(old, new, Options{})
+
+	doc := toJSONValue(t, old)
+	for _, op := range d.Patch() {
+		var err error
+		if doc, err = applyOp(doc, op); err != nil {
+			t.Fatalf("applying %s %s: %v", op.Op, op.Path, err)
+		}
+	}
+
+	got, _ := json.Marshal(normalize(doc))
+	want, _ := json.Marshal(normalize(toJSONValue(t, new)))
+	if string(got) != string(want) {
+		t.Errorf("patched old brand =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestPatch_AddsFieldsMissingFromOldBrand(t *testing.T) {
+	old, new := oldBrand(), oldBrand()
+	new.LongDescription = "Stripe builds economic infrastructure for the internet."
+	d := Compare(old, new, Options{})
+
+	// Snapshots of the raw API response may omit empty fields.
+	doc := toJSONValue(t, old)
+	delete(doc.(map[string]interface{}), "longDescription")
+	for _, op := range d.Patch() {
+		if op.Op != "add" {
+			t.Errorf("%s %s, want add for a field the old brand lacks", op.Op, op.Path)
+		}
+		var err error
+		if doc, err = applyOp(doc, op); err != nil {
+			t.Fatalf("applying %s %s: %v", op.Op, op.Path, err)
+		}
+	}
+	if got := doc.(map[string]interface{})["longDescription"]; got != new.LongDescription {
+		t.Errorf("longDescription = %v, want %q", got, new.LongDescription)
+	}
+}
+
+func TestColorDistance(t *testing.T) {
+	if d, ok := ColorDistance("#000000", "#ffffff"); !ok || math.Abs(d-100) > 0.01 {
+		t.Errorf("ColorDistance(black, white) = %v, %v, want 100", d, ok)
+	}
+	if d, ok := ColorDistance("#fff", "#FFFFFF"); !ok || d > 1e-9 {
+		t.Errorf("ColorDistance(#fff, #FFFFFF) = %v, %v, want 0", d, ok)
+	}
+	if _, ok := ColorDistance("#12345", "#000000"); ok {
+		t.Error("ColorDistance accepted an invalid hex")
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	old := oldBrand()
+	new := oldBrand()
+	new.Description = "Pipes | and `ticks`"
+	new.Colors[0].Hex = "#533afd"
+
+	md := Compare(old, new, Options{}).Markdown()
+	for _, want := range []string{
+		"### Stripe (stripe.com)",
+		"| Field | Change | Before | After |",
+		"| description | changed | `Payments infrastructure` | `Pipes \\| and 'ticks'` |",
+		"| colors[accent] | changed | `#635bff` | `#533afd` (ΔE ",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("Markdown() missing %q:\n%s", want, md)
+		}
+	}
+}
+
+func toJSONValue(t *testing.T, v interface{}) interface{} {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out interface{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+// applyOp is a minimal RFC 6902 add/remove/replace implementation.
+func applyOp(doc interface{}, op Operation) (interface{}, error) {
+	var value interface{}
+	if len(op.Value) > 0 {
+		if err := json.Unmarshal(op.Value, &value); err != nil {
+			return nil, err
+		}
+	}
+	parts := strings.Split(op.Path, "/")[1:]
+	return applyAt(doc, parts, op.Op, value)
+}
+
+func applyAt(node interface{}, parts []string, op string, value interface{}) (interface{}, error) {
+	key := parts[0]
+	last := len(parts) == 1
+	switch n := node.(type) {
+	case map[string]interface{}:
+		if last {
+			if _, ok := n[key]; !ok && op == "replace" {
+				return nil, fmt.Errorf("replace of missing member %q", key)
+			}
+			if op == "remove" {
+				delete(n, key)
+			} else {
+				n[key] = value
+			}
+			return n, nil
+		}
+		child, err := applyAt(n[key], parts[1:], op, value)
+		n[key] = child
+		return n, err
+	case []interface{}:
+		if last && key == "-" && op == "add" {
+			return append(n, value), nil
+		}
+		i, err := strconv.Atoi(key)
+		if err != nil || i < 0 || i >= len(n) {
+			return nil, fmt.Errorf("bad index %q", key)
+		}
+		if last {
+			switch op {
+			case "remove":
+				return append(n[:i:i], n[i+1:]...), nil
+			case "replace":
+				n[i] = value
+				return n, nil
+			}
+			return nil, fmt.Errorf("unsupported %s at index", op)
+		}
+		child, err := applyAt(n[i], parts[1:], op, value)
+		n[i] = child
+		return n, err
+	}
+	return nil, fmt.Errorf("cannot descend into %T", node)
+}
+
+// normalize sorts arrays so documents compare equal regardless of entry order.
+func normalize(v interface{}) interface{} {
+	switch n := v.(type) {
+	case map[string]interface{}:
+		for k, child := range n {
+			n[k] = normalize(child)
+		}
+	case []interface{}:
+		for i, child := range n {
+			n[i] = normalize(child)
+		}
+		sort.Slice(n, func(i, j int) bool {
+			a, _ := json.Marshal(n[i])
+			b, _ := json.Marshal(n[j])
+			return string(a) < string(b)
+		})
+	}
+	return v
+}
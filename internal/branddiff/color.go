@@ -0,0 +1,60 @@
+package branddiff
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ColorDistance returns the CIE76 difference (ΔE*ab) between two hex
+// colours. A ΔE around 2.3 is the smallest difference most people notice.
+func ColorDistance(a, b string) (float64, bool) {
+	la, ok := hexToLab(a)
+	if !ok {
+		return 0, false
+	}
+	lb, ok := hexToLab(b)
+	if !ok {
+		return 0, false
+	}
+	return math.Sqrt(sq(la[0]-lb[0]) + sq(la[1]-lb[1]) + sq(la[2]-lb[2])), true
+}
+
+func sq(x float64) float64 { return x * x }
+
+// hexToLab converts #rgb or #rrggbb to CIELAB under the D65 white point.
+func hexToLab(hex string) ([3]float64, bool) {
+	hex = strings.TrimPrefix(strings.TrimSpace(hex), "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) != 6 {
+		return [3]float64{}, false
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return [3]float64{}, false
+	}
+
+	linear := func(c uint64) float64 {
+		s := float64(c) / 255
+		if s <= 0.04045 {
+			return s / 12.92
+		}
+		return math.Pow((s+0.055)/1.055, 2.4)
+	}
+	r, g, b := linear(v>>16&0xff), linear(v>>8&0xff), linear(v&0xff)
+
+	x := (0.4124564*r + 0.3575761*g + 0.1804375*b) / 0.95047
+	y := 0.2126729*r + 0.7151522*g + 0.0721750*b
+	z := (0.0193339*r + 0.1191920*g + 0.9503041*b) / 1.08883
+
+	f := func(t float64) float64 {
+		if t > 216.0/24389 {
+			return math.Cbrt(t)
+		}
+		return (24389.0/27*t + 16) / 116
+	}
+	fx, fy, fz := f(x), f(y), f(z)
+	return [3]float64{116*fy - 16, 500 * (fx - fy), 200 * (fy - fz)}, true
+}
@@ -0,0 +1,64 @@
+package branddiff
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Operation is one RFC 6902 JSON patch operation.
+type Operation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value,omitempty"`
+
+	phase int
+}
+
+// Operations are applied in phases so every path is valid when it is
+// reached: replacements and additions inside existing entries first, then
+// removals from the highest index down, then appends of new entries.
+const (
+	phaseReplace = iota
+	phaseNestedAdd
+	phaseRemove
+	phaseAppend
+)
+
+// Patch returns a JSON patch that turns the old brand's JSON into the new
+// one's, up to the order of array entries.
+func (d *Diff) Patch() []Operation {
+	ops := append([]Operation(nil), d.ops...)
+	sort.SliceStable(ops, func(i, j int) bool {
+		if ops[i].phase != ops[j].phase {
+			return ops[i].phase < ops[j].phase
+		}
+		if ops[i].phase == phaseRemove {
+			return pathAfter(ops[i].Path, ops[j].Path)
+		}
+		return false
+	})
+	if ops == nil {
+		ops = []Operation{}
+	}
+	return ops
+}
+
+// pathAfter orders JSON pointers so that higher array indices come first.
+func pathAfter(a, b string) bool {
+	as := strings.Split(a, "/")
+	bs := strings.Split(b, "/")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if as[i] == bs[i] {
+			continue
+		}
+		an, aErr := strconv.Atoi(as[i])
+		bn, bErr := strconv.Atoi(bs[i])
+		if aErr == nil && bErr == nil {
+			return an > bn
+		}
+		return as[i] < bs[i]
+	}
+	return len(as) > len(bs)
+}
@@ -0,0 +1,101 @@
+package branddiff
+
+import (
+	"fmt"
+	"strings"
+)
+
+var kindSymbols = map[Kind]string{Added: "+", Removed: "-", Changed: "~"}
+
+// Text renders the diff as one line per change.
+func (d *Diff) Text() string {
+	var sb strings.Builder
+	sb.WriteString(d.title())
+	if len(d.Changes) == 0 {
+		sb.WriteString(": no changes\n")
+		return sb.String()
+	}
+	sb.WriteString(fmt.Sprintf(": %s\n", plural(len(d.Changes), "change")))
+	for _, c := range d.Changes {
+		sb.WriteString(fmt.Sprintf("  %s %s  %s\n", kindSymbols[c.Kind], c.label(), c.summary()))
+	}
+	return sb.String()
+}
+
+// Markdown renders the diff as a heading and a table of changes.
+func (d *Diff) Markdown() string {
+	var sb strings.Builder
+	sb.WriteString("### " + escapeMarkdown(d.title()) + "\n\n")
+	if len(d.Changes) == 0 {
+		sb.WriteString("No changes.\n")
+		return sb.String()
+	}
+	sb.WriteString("| Field | Change | Before | After |\n")
+	sb.WriteString("| --- | --- | --- | --- |\n")
+	for _, c := range d.Changes {
+		after := code(c.New)
+		if c.Distance != nil {
+			after += fmt.Sprintf(" (ΔE %.1f)", *c.Distance)
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", escapeMarkdown(c.label()), c.Kind, code(c.Old), after))
+	}
+	return sb.String()
+}
+
+func (d *Diff) title() string {
+	switch {
+	case d.Name != "" && d.Domain != "":
+		return fmt.Sprintf("%s (%s)", d.Name, d.Domain)
+	case d.Domain != "":
+		return d.Domain
+	case d.Name != "":
+		return d.Name
+	}
+	return "brand"
+}
+
+func (c Change) label() string {
+	if c.Key == "" {
+		return c.Field
+	}
+	return fmt.Sprintf("%s[%s]", c.Field, c.Key)
+}
+
+func (c Change) summary() string {
+	old, new := c.Old, c.New
+	if c.Field == "description" || c.Field == "longDescription" || c.Field == "name" {
+		old, new = fmt.Sprintf("%q", old), fmt.Sprintf("%q", new)
+	}
+	var s string
+	switch c.Kind {
+	case Added:
+		s = new
+	case Removed:
+		s = old
+	default:
+		s = old + " -> " + new
+	}
+	if c.Distance != nil {
+		s += fmt.Sprintf(" (ΔE %.1f)", *c.Distance)
+	}
+	return s
+}
+
+func code(s string) string {
+	if s == "" {
+		return ""
+	}
+	s = strings.Join(strings.Fields(s), " ")
+	return "`" + strings.NewReplacer("`", "'", "|", `\|`).Replace(s) + "`"
+}
+
+func escapeMarkdown(s string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(s)
+}
+
+func plural(n int, word string) string {
+	if n == 1 {
+		return "1 " + word
+	}
+	return fmt.Sprintf("%d %ss", n, word)
+}
@@ -0,0 +1,322 @@
+// Package cassette records HTTP interactions to fixture files and replays them,
+// so tools built on the API client can be tested deterministically.
+package cassette
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Redacted replaces credentials in recorded interactions.
+const Redacted = "REDACTED"
+
+// recordedRequestHeaders are the request headers kept in a cassette.
+var recordedRequestHeaders = []string{"Accept", "Authorization", "Content-Type"}
+
+// Interaction is one recorded request and its response, stored as
+// <sequence>-<method>-<path>.json in the cassette directory.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is the recorded request, with credentials redacted.
+type Request struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+// Response is the recorded response. Binary bodies are base64 encoded.
+type Response struct {
+	Status       int         `json:"status"`
+	Headers      http.Header `json:"headers,omitempty"`
+	Body         string      `json:"body,omitempty"`
+	BodyEncoding string      `json:"body_encoding,omitempty"`
+}
+
+// Recorder is an http.RoundTripper that saves every interaction to a directory.
+type Recorder struct {
+	dir  string
+	next http.RoundTripper
+
+	mu  sync.Mutex
+	seq int
+}
+
+// NewRecorder records interactions sent through next (http.DefaultTransport
+// when nil) into dir, continuing after any interactions already there.
+func NewRecorder(dir string, next http.RoundTripper) (*Recorder, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create cassette directory: %w", err)
+	}
+	existing, err := interactionFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Recorder{dir: dir, next: next, seq: len(existing)}, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	secrets := requestSecrets(req)
+	interaction := Interaction{
+		Request: Request{
+			Method:  req.Method,
+			URL:     redactURL(req.URL),
+			Headers: redactHeaders(req.Header),
+			Body:    redact(string(reqBody), secrets),
+		},
+		Response: Response{
+			Status:  resp.StatusCode,
+			Headers: resp.Header.Clone(),
+		},
+	}
+	if utf8.Valid(respBody) {
+		interaction.Response.Body = redact(string(respBody), secrets)
+	} else {
+		interaction.Response.Body = base64.StdEncoding.EncodeToString(respBody)
+		interaction.Response.BodyEncoding = "base64"
+	}
+
+	if err := r.save(req, interaction); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (r *Recorder) save(req *http.Request, interaction Interaction) error {
+	data, err := json.MarshalIndent(interaction, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.seq++
+	name := fmt.Sprintf("%04d-%s-%s.json", r.seq, strings.ToLower(req.Method), slug(req.URL.Path))
+	if err := os.WriteFile(filepath.Join(r.dir, name), append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	return nil
+}
+
+// Player is an http.RoundTripper that answers requests from recorded
+// interactions and fails on requests that were not recorded.
+type Player struct {
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// NewPlayer loads the interactions recorded in dir.
+func NewPlayer(dir string) (*Player, error) {
+	files, err := interactionFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no recorded interactions in %s", dir)
+	}
+
+	p := &Player{}
+	for _, name := range files {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		var interaction Interaction
+		if err := json.Unmarshal(data, &interaction); err != nil {
+			return nil, fmt.Errorf("invalid cassette %s: %w", name, err)
+		}
+		p.interactions = append(p.interactions, interaction)
+	}
+	p.used = make([]bool, len(p.interactions))
+	return p, nil
+}
+
+// RoundTrip implements http.RoundTripper. Identical requests are answered
+// in recorded order; once all are used, the last one is repeated.
+func (p *Player) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	method := req.Method
+	u := redactURL(req.URL)
+	body := redact(string(reqBody), requestSecrets(req))
+
+	p.mu.Lock()
+	match := -1
+	for i, interaction := range p.interactions {
+		if interaction.Request.Method != method || interaction.Request.URL != u || interaction.Request.Body != body {
+			continue
+		}
+		match = i
+		if !p.used[i] {
+			break
+		}
+	}
+	if match >= 0 {
+		p.used[match] = true
+	}
+	p.mu.Unlock()
+
+	if match < 0 {
+		return nil, fmt.Errorf("cassette: no recorded response for %s %s", method, u)
+	}
+
+	recorded := p.interactions[match].Response
+	respBody := []byte(recorded.Body)
+	if recorded.BodyEncoding == "base64" {
+		if respBody, err = base64.StdEncoding.DecodeString(recorded.Body); err != nil {
+			return nil, fmt.Errorf("cassette: invalid body for %s %s: %w", method, u, err)
+		}
+	}
+	header := recorded.Headers.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.Status, http.StatusText(recorded.Status)),
+		StatusCode:    recorded.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(respBody)),
+		ContentLength: int64(len(respBody)),
+		Request:       req,
+	}, nil
+}
+
+func interactionFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cassette directory: %w", err)
+	}
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// readRequestBody reads the body and restores it for the next transport.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	data, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+// requestSecrets returns the credentials carried by req, which are redacted
+// wherever they appear in the recording.
+func requestSecrets(req *http.Request) []string {
+	var secrets []string
+	if c := req.URL.Query().Get("c"); c != "" {
+		secrets = append(secrets, c)
+	}
+	if auth := req.Header.Get("Authorization"); auth != "" {
+		if _, token, ok := strings.Cut(auth, " "); ok && token != "" {
+			secrets = append(secrets, token)
+		} else {
+			secrets = append(secrets, auth)
+		}
+	}
+	return secrets
+}
+
+// redactURL replaces the c= client ID and returns the URL with sorted query
+// parameters, so equivalent requests match on replay.
+func redactURL(u *url.URL) string {
+	redacted := *u
+	query := redacted.Query()
+	if query.Has("c") {
+		query.Set("c", Redacted)
+	}
+	redacted.RawQuery = query.Encode()
+	return redacted.String()
+}
+
+func redactHeaders(h http.Header) http.Header {
+	kept := make(http.Header)
+	for _, name := range recordedRequestHeaders {
+		if v := h.Values(name); len(v) > 0 {
+			kept[name] = append([]string(nil), v...)
+		}
+	}
+	if auth := kept.Get("Authorization"); auth != "" {
+		scheme, _, ok := strings.Cut(auth, " ")
+		if ok {
+			kept.Set("Authorization", scheme+" "+Redacted)
+		} else {
+			kept.Set("Authorization", Redacted)
+		}
+	}
+	if len(kept) == 0 {
+		return nil
+	}
+	return kept
+}
+
+func redact(s string, secrets []string) string {
+	for _, secret := range secrets {
+		s = strings.ReplaceAll(s, secret, Redacted)
+	}
+	return s
+}
+
+var nonSlug = regexp.MustCompile(`[^a-z0-9.]+`)
+
+func slug(path string) string {
+	s := strings.Trim(nonSlug.ReplaceAllString(strings.ToLower(path), "-"), "-")
+	if len(s) > 60 {
+		s = s[:60]
+	}
+	if s == "" {
+		s = "root"
+	}
+	return s
+}
@@ -0,0 +1,152 @@
+package cassette_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/brandfetch-cli/internal/api"
+	"github.com/salmonumbrella/brandfetch-cli/internal/cassette"
+	"github.com/salmonumbrella/brandfetch-cli/internal/mockserver"
+	"github.com/salmonumbrella/brandfetch-cli/pkg/brandfetch/brandfetchtest"
+)
+
+func clientFor(baseURL string, transport http.RoundTripper) *api.Client {
+	endpoints := mockserver.EndpointsFor(baseURL)
+	return api.NewClientWithOptions("secret_client_id", "secret_api_key", api.ClientOptions{
+		BaseURL:     endpoints.API,
+		LogoBaseURL: endpoints.CDN,
+		GraphQLURL:  endpoints.GraphQL,
+		Transport:   transport,
+	})
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	dir := t.TempDir()
+	server := brandfetchtest.NewServer(t, mockserver.Options{})
+	ctx := context.Background()
+
+	recorder, err := cassette.NewRecorder(dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	live := clientFor(server.URL, recorder)
+	brand, err := live.GetBrand(ctx, "github.com")
+	if err != nil {
+		t.Fatalf("GetBrand() error = %v", err)
+	}
+	if _, err := live.Search(ctx, "stripe", 0); err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	logoURL, _ := live.BuildLogoURL(api.LogoOptions{Identifier: "github.com", Type: "icon", Format: "png"})
+	liveLogo := fetch(t, &http.Client{Transport: recorder}, logoURL)
+	server.Close()
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 3 {
+		t.Fatalf("recorded %d interactions, want 3", len(files))
+	}
+	for _, f := range files {
+		data, _ := os.ReadFile(f)
+		if bytes.Contains(data, []byte("secret_client_id")) || bytes.Contains(data, []byte("secret_api_key")) {
+			t.Errorf("%s contains an unredacted credential", filepath.Base(f))
+		}
+	}
+
+	player, err := cassette.NewPlayer(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replay := clientFor(server.URL, player)
+	replayed, err := replay.GetBrand(ctx, "github.com")
+	if err != nil {
+		t.Fatalf("replayed GetBrand() error = %v", err)
+	}
+	if replayed.Name != brand.Name || len(replayed.Logos) != len(brand.Logos) {
+		t.Errorf("replayed brand differs: %+v", replayed)
+	}
+	results, err := replay.Search(ctx, "stripe", 0)
+	if err != nil || len(results) != 1 {
+		t.Errorf("replayed Search() = %v, %v", results, err)
+	}
+	if replayedLogo := fetch(t, &http.Client{Transport: player}, logoURL); !bytes.Equal(replayedLogo, liveLogo) {
+		t.Error("replayed binary body differs from the recording")
+	}
+
+	// Repeated requests reuse the last recording.
+	if _, err := replay.GetBrand(ctx, "github.com"); err != nil {
+		t.Errorf("repeated GetBrand() error = %v", err)
+	}
+
+	_, err = replay.GetBrand(ctx, "stripe.com")
+	if err == nil || !strings.Contains(err.Error(), "no recorded response for GET") {
+		t.Errorf("unmatched request error = %v", err)
+	}
+}
+
+func TestReplayMatchesOtherCredentials(t *testing.T) {
+	dir := t.TempDir()
+	server := brandfetchtest.NewServer(t, mockserver.Options{})
+	recorder, err := cassette.NewRecorder(dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := clientFor(server.URL, recorder).Search(context.Background(), "git", 0); err != nil {
+		t.Fatal(err)
+	}
+
+	player, err := cassette.NewPlayer(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other := api.NewClientWithOptions("another_id", "another_key", api.ClientOptions{BaseURL: server.URL, Transport: player})
+	if _, err := other.Search(context.Background(), "git", 0); err != nil {
+		t.Errorf("replay with different credentials error = %v", err)
+	}
+}
+
+func TestRecorderAppends(t *testing.T) {
+	dir := t.TempDir()
+	server := brandfetchtest.NewServer(t, mockserver.Options{})
+	for i := 0; i < 2; i++ {
+		recorder, err := cassette.NewRecorder(dir, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := clientFor(server.URL, recorder).GetBrand(context.Background(), "github.com"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 2 || !strings.HasPrefix(filepath.Base(files[1]), "0002-get-") {
+		t.Errorf("files = %v", files)
+	}
+}
+
+func TestNewPlayerErrors(t *testing.T) {
+	if _, err := cassette.NewPlayer(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("expected error for a missing directory")
+	}
+	if _, err := cassette.NewPlayer(t.TempDir()); err == nil {
+		t.Error("expected error for an empty cassette")
+	}
+}
+
+func fetch(t *testing.T, client *http.Client, u string) []byte {
+	t.Helper()
+	resp, err := client.Get(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
@@ -1,21 +1,28 @@
 package cmd
 
 import (
-	"bufio"
+	"context"
 	"fmt"
-	"io"
-	"os/exec"
-	"runtime"
 	"strings"
-	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/salmonumbrella/brandfetch-cli/internal/api"
 	"github.com/salmonumbrella/brandfetch-cli/internal/authserver"
+	"github.com/salmonumbrella/brandfetch-cli/internal/config"
 	"github.com/salmonumbrella/brandfetch-cli/internal/secrets"
 )
 
-var authStdin bool
+var (
+	authStdin        bool
+	authSkipValidate bool
+	authNoBrowser    bool
+	authPrompt       bool
+	authPort         int
+)
+
+// credentialKeys are the keychain keys stored for each profile.
+var credentialKeys = []string{"client_id", "api_key"}
 
 // SecretsStore interface for dependency injection.
 type SecretsStore interface {
@@ -31,7 +38,12 @@ func NewAuthCmd() *cobra.Command {
 		Short: "Manage API credentials",
 		Long: `Manage Brandfetch API credentials.
 
-Credentials are stored in the OS keychain by default.
+Credentials are stored in the OS keychain by default. Use --keyring-backend
+(or BRANDFETCH_KEYRING_BACKEND) to pick another secrets backend, such as the
+passphrase-encrypted file backend on headless machines.
+
+Use --profile NAME (or BRANDFETCH_PROFILE) to keep separate credentials per
+account, e.g. staging and production. See 'brandfetch auth profiles'.
 
 Get your API keys at https://brandfetch.com/developers`,
 	}
@@ -40,12 +52,15 @@ Get your API keys at https://brandfetch.com/developers`,
 	cmd.AddCommand(newAuthSetCmd())
 	cmd.AddCommand(newAuthStatusCmd())
 	cmd.AddCommand(newAuthClearCmd())
+	cmd.AddCommand(newAuthRotateCmd())
+	cmd.AddCommand(newAuthRollbackCmd())
+	cmd.AddCommand(newAuthProfilesCmd())
 
 	return cmd
 }
 
 func newAuthLoginCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "login",
 		Short: "Authenticate via browser",
 		Long: `Opens a browser window to configure API credentials interactively.
@@ -58,19 +73,32 @@ Brandfetch has two API endpoints with separate keys:
   - Logo API: High quota, used for logo and search queries
   - Brand API: Limited quota, used for full brand data (colors, fonts, etc.)
 
-You can configure one or both keys depending on your needs.
+You can configure one or both keys depending on your needs. Keys are checked
+against the API before they are saved; use --skip-validate when offline.
+
+Over SSH, use --no-browser to print the form URL and --port to fix the port
+for forwarding, or --prompt to type the keys at a hidden terminal prompt.
+Sessions without a browser fall back to the prompt automatically.
 
 Examples:
-  brandfetch auth login`,
+  brandfetch auth login
+  brandfetch auth login --no-browser --port 8085
+  brandfetch auth login --prompt`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			store, err := secrets.NewStore()
+			store, err := openSecretsStore()
 			if err != nil {
-				return fmt.Errorf("failed to open keychain: %w", err)
+				return err
 			}
 			authStdin = false
-			return runAuthSetCmd(cmd, store)
+			scoped, err := profileStore(store)
+			if err != nil {
+				return err
+			}
+			return runAuthSetCmd(cmd, scoped, defaultCredentialChecker)
 		},
 	}
+	addLoginFlags(cmd)
+	return cmd
 }
 
 func newAuthSetCmd() *cobra.Command {
@@ -82,78 +110,87 @@ func newAuthSetCmd() *cobra.Command {
 You can configure the Logo API client ID, the Brand API key, or both.
 For headless environments, use --stdin to read credentials from stdin.
 
+Before saving, the client ID is checked with a search request and the API
+key with a GraphQL query, which spends no Brand API quota. Use
+--skip-validate to save without network access.
+
 Examples:
   brandfetch auth set          # Opens browser for credential entry
-  brandfetch auth set --stdin  # Read from stdin (client_id, then api_key)`,
+  brandfetch auth set --stdin  # Read from stdin (client_id, then api_key)
+  brandfetch auth set --stdin --skip-validate
+  brandfetch auth set --prompt # Hidden terminal prompt
+  brandfetch auth set --no-browser --port 8085  # Web form over SSH port forwarding
+  brandfetch auth set --profile staging`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			store, err := secrets.NewStore()
+			store, err := openSecretsStore()
+			if err != nil {
+				return err
+			}
+			scoped, err := profileStore(store)
 			if err != nil {
-				return fmt.Errorf("failed to open keychain: %w", err)
+				return err
 			}
-			return runAuthSetCmd(cmd, store)
+			return runAuthSetCmd(cmd, scoped, defaultCredentialChecker)
 		},
 	}
 
 	cmd.Flags().BoolVar(&authStdin, "stdin", false, "Read credentials from stdin")
+	addLoginFlags(cmd)
 
 	return cmd
 }
 
 func newAuthSetCmdWithStore(store SecretsStore) *cobra.Command {
+	return newAuthSetCmdWithChecker(store, defaultCredentialChecker)
+}
+
+func newAuthSetCmdWithChecker(store SecretsStore, checker newCredentialChecker) *cobra.Command {
 	cmd := &cobra.Command{
 		Use: "set",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runAuthSetCmd(cmd, store)
+			scoped, err := profileStore(store)
+			if err != nil {
+				return err
+			}
+			return runAuthSetCmd(cmd, scoped, checker)
 		},
 	}
 	cmd.Flags().BoolVar(&authStdin, "stdin", false, "Read from stdin")
+	addLoginFlags(cmd)
 	return cmd
 }
 
-func runAuthSetCmd(cmd *cobra.Command, store SecretsStore) error {
-	var clientID, apiKey string
-
-	if authStdin {
-		// Read from stdin
-		reader := bufio.NewReader(cmd.InOrStdin())
-
-		line1, err := reader.ReadString('\n')
-		if err != nil && err != io.EOF {
-			return fmt.Errorf("failed to read client_id: %w", err)
-		}
-		clientID = strings.TrimSpace(line1)
-
-		line2, err := reader.ReadString('\n')
-		if err != nil && err != io.EOF {
-			return fmt.Errorf("failed to read api_key: %w", err)
-		}
-		apiKey = strings.TrimSpace(line2)
-	} else {
-		// Browser-based flow
-		server, err := authserver.NewServer()
-		if err != nil {
-			return fmt.Errorf("failed to start auth server: %w", err)
-		}
-		defer func() { _ = server.Shutdown() }()
-
-		server.Start()
-		url := server.URL()
-
-		fmt.Fprintf(cmd.OutOrStdout(), "Opening browser to configure credentials...\n")
-		fmt.Fprintf(cmd.OutOrStdout(), "If browser doesn't open, visit: %s\n\n", url)
-
-		// Try to open browser
-		openBrowser(url)
+func runAuthSetCmd(cmd *cobra.Command, store *secrets.ProfileStore, checker newCredentialChecker) error {
+	if authStdin && authPrompt {
+		return fmt.Errorf("--stdin and --prompt cannot be used together")
+	}
+	if (authStdin || authPrompt) && (authNoBrowser || authPort != 0) {
+		return fmt.Errorf("--no-browser and --port only apply to the browser form")
+	}
 
-		fmt.Fprintf(cmd.OutOrStdout(), "Waiting for credentials...\n")
-		creds, err := server.WaitForCredentials(5 * time.Minute)
-		if err != nil {
-			return err
+	var validate authserver.Validator
+	if !authSkipValidate {
+		validate = func(ctx context.Context, creds authserver.Credentials) map[string]string {
+			return validateCredentials(ctx, checker, creds)
 		}
+	}
 
-		clientID = creds.ClientID
-		apiKey = creds.APIKey
+	var (
+		creds authserver.Credentials
+		err   error
+	)
+	switch {
+	case authStdin:
+		creds, err = readStdinCredentials(cmd, validate)
+	case authPrompt:
+		creds, err = promptCredentials(cmd, validate)
+	default:
+		creds, err = browserCredentials(cmd, validate)
 	}
+	if err != nil {
+		return err
+	}
+	clientID, apiKey := creds.ClientID, creds.APIKey
 
 	if clientID == "" && apiKey == "" {
 		return fmt.Errorf("at least one of client_id or api_key is required")
@@ -170,66 +207,72 @@ func runAuthSetCmd(cmd *cobra.Command, store SecretsStore) error {
 		}
 	}
 
+	if store.Profile() != config.DefaultProfile {
+		if err := registerProfile(store.Profile()); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Credentials saved successfully (profile: %s).\n", store.Profile())
+		return nil
+	}
+
 	fmt.Fprintln(cmd.OutOrStdout(), "Credentials saved successfully.")
 	return nil
 }
 
-func openBrowser(url string) {
-	var cmd *exec.Cmd
-	switch runtime.GOOS {
-	case "darwin":
-		cmd = exec.Command("open", url)
-	case "linux":
-		cmd = exec.Command("xdg-open", url)
-	case "windows":
-		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
-	default:
-		return
+// validateCredentials checks each provided key against the API and returns an
+// error message per rejected field, keyed like the auth form fields.
+func validateCredentials(ctx context.Context, checker newCredentialChecker, creds authserver.Credentials) map[string]string {
+	fieldErrors := make(map[string]string)
+	// Secret references are stored as given but validated against what they resolve to.
+	clientID, err := config.ResolveSecret(creds.ClientID)
+	if err != nil {
+		fieldErrors[authserver.FieldClientID] = err.Error()
 	}
-	_ = cmd.Start()
-}
-
-func newAuthStatusCmd() *cobra.Command {
-	return &cobra.Command{
-		Use:   "status",
-		Short: "Show credential status",
-		RunE: func(cmd *cobra.Command, args []string) error {
-			store, err := secrets.NewStore()
-			if err != nil {
-				return fmt.Errorf("failed to open keychain: %w", err)
-			}
-			return runAuthStatusCmd(cmd, store)
-		},
+	apiKey, err := config.ResolveSecret(creds.APIKey)
+	if err != nil {
+		fieldErrors[authserver.FieldAPIKey] = err.Error()
+	}
+	if len(fieldErrors) > 0 {
+		return fieldErrors
 	}
-}
 
-func newAuthStatusCmdWithStore(store SecretsStore) *cobra.Command {
-	return &cobra.Command{
-		Use: "status",
-		RunE: func(cmd *cobra.Command, args []string) error {
-			return runAuthStatusCmd(cmd, store)
-		},
+	client := checker(clientID, apiKey)
+	if creds.ClientID != "" {
+		if msg := keyCheckError(ctx, "Logo API key", client.ValidateClientID); msg != "" {
+			fieldErrors[authserver.FieldClientID] = msg
+		}
+	}
+	if creds.APIKey != "" {
+		if msg := keyCheckError(ctx, "Brand API key", client.ValidateAPIKey); msg != "" {
+			fieldErrors[authserver.FieldAPIKey] = msg
+		}
 	}
+	return fieldErrors
 }
 
-func runAuthStatusCmd(cmd *cobra.Command, store SecretsStore) error {
-	clientID, _ := store.Get("client_id")
-	apiKey, _ := store.Get("api_key")
-
-	clientStatus := "not configured"
-	if clientID != "" {
-		clientStatus = "configured"
+func keyCheckError(ctx context.Context, label string, validate func(context.Context) (*api.KeyCheck, error)) string {
+	check, err := validate(ctx)
+	if err != nil {
+		return fmt.Sprintf("%s could not be validated: %v", label, err)
 	}
-
-	apiStatus := "not configured"
-	if apiKey != "" {
-		apiStatus = "configured"
+	if !check.Valid {
+		if check.Message != "" {
+			return fmt.Sprintf("%s was rejected (HTTP %d): %s", label, check.Status, check.Message)
+		}
+		return fmt.Sprintf("%s was rejected (HTTP %d)", label, check.Status)
 	}
+	return ""
+}
 
-	fmt.Fprintf(cmd.OutOrStdout(), "Logo API Key (client_id): %s\n", clientStatus)
-	fmt.Fprintf(cmd.OutOrStdout(), "Brand API Key (api_key): %s\n", apiStatus)
-
-	return nil
+// credentialValidationError lists rejected keys in form field order.
+func credentialValidationError(fieldErrors map[string]string) error {
+	var lines []string
+	for _, field := range []string{authserver.FieldClientID, authserver.FieldAPIKey} {
+		if msg, ok := fieldErrors[field]; ok {
+			lines = append(lines, fmt.Sprintf("  %s: %s", field, msg))
+		}
+	}
+	return fmt.Errorf("credentials not saved:\n%s\nuse --skip-validate to save them anyway", strings.Join(lines, "\n"))
 }
 
 func newAuthClearCmd() *cobra.Command {
@@ -237,11 +280,15 @@ func newAuthClearCmd() *cobra.Command {
 		Use:   "clear",
 		Short: "Remove stored credentials",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			store, err := secrets.NewStore()
+			store, err := openSecretsStore()
+			if err != nil {
+				return err
+			}
+			scoped, err := profileStore(store)
 			if err != nil {
-				return fmt.Errorf("failed to open keychain: %w", err)
+				return err
 			}
-			return runAuthClearCmd(cmd, store)
+			return runAuthClearCmd(cmd, scoped)
 		},
 	}
 }
@@ -250,14 +297,27 @@ func newAuthClearCmdWithStore(store SecretsStore) *cobra.Command {
 	return &cobra.Command{
 		Use: "clear",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runAuthClearCmd(cmd, store)
+			scoped, err := profileStore(store)
+			if err != nil {
+				return err
+			}
+			return runAuthClearCmd(cmd, scoped)
 		},
 	}
 }
 
-func runAuthClearCmd(cmd *cobra.Command, store SecretsStore) error {
-	_ = store.Delete("client_id")
-	_ = store.Delete("api_key")
+func runAuthClearCmd(cmd *cobra.Command, store *secrets.ProfileStore) error {
+	for _, key := range storedKeys() {
+		_ = store.Delete(key)
+	}
+	if rotations, err := loadRotations(); err == nil {
+		rotations.RemoveProfile(store.Profile())
+		_ = rotations.Save()
+	}
+	if store.Profile() != config.DefaultProfile {
+		fmt.Fprintf(cmd.OutOrStdout(), "Credentials cleared (profile: %s).\n", store.Profile())
+		return nil
+	}
 	fmt.Fprintln(cmd.OutOrStdout(), "Credentials cleared.")
 	return nil
 }
@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/salmonumbrella/brandfetch-cli/internal/authserver"
+)
+
+// maxPromptAttempts bounds how often a rejected key is asked for again.
+const maxPromptAttempts = 3
+
+// addLoginFlags registers the flags that choose how keys are entered.
+func addLoginFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&authSkipValidate, "skip-validate", false, "Save credentials without checking them against the API")
+	cmd.Flags().BoolVar(&authNoBrowser, "no-browser", false, "Print the form URL instead of opening a browser (for SSH sessions)")
+	cmd.Flags().IntVar(&authPort, "port", 0, "Port for the local form server (default: random; fix it for SSH port forwarding)")
+	cmd.Flags().BoolVar(&authPrompt, "prompt", false, "Enter keys at a hidden terminal prompt instead of the browser form")
+}
+
+// readStdinCredentials reads the client ID and API key from the first two lines of stdin.
+func readStdinCredentials(cmd *cobra.Command, validate authserver.Validator) (authserver.Credentials, error) {
+	var creds authserver.Credentials
+	reader := bufio.NewReader(cmd.InOrStdin())
+
+	line1, err := reader.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return creds, fmt.Errorf("failed to read client_id: %w", err)
+	}
+	creds.ClientID = strings.TrimSpace(line1)
+
+	line2, err := reader.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return creds, fmt.Errorf("failed to read api_key: %w", err)
+	}
+	creds.APIKey = strings.TrimSpace(line2)
+
+	if validate != nil && (creds.ClientID != "" || creds.APIKey != "") {
+		fmt.Fprintln(cmd.ErrOrStderr(), "Validating credentials...")
+		if fieldErrors := validate(cmd.Context(), creds); len(fieldErrors) > 0 {
+			return creds, credentialValidationError(fieldErrors)
+		}
+	}
+	return creds, nil
+}
+
+// browserCredentials collects keys through the local web form. It falls back
+// to the terminal prompt when no browser can be reached and stdin is a terminal.
+func browserCredentials(cmd *cobra.Command, validate authserver.Validator) (authserver.Credentials, error) {
+	noBrowser := authNoBrowser
+	if !noBrowser && authPort == 0 && browserUnavailable() {
+		if isTerminalReader(cmd.InOrStdin()) {
+			fmt.Fprintln(cmd.ErrOrStderr(), "No browser available in this session; enter your keys below.")
+			fmt.Fprintln(cmd.ErrOrStderr(), "(Use --no-browser --port N to use the web form over SSH port forwarding.)")
+			return promptCredentials(cmd, validate)
+		}
+		noBrowser = true
+	}
+
+	server, err := authserver.NewServerWithOptions(authserver.Options{Validate: validate, Port: authPort})
+	if err != nil {
+		return authserver.Credentials{}, fmt.Errorf("failed to start auth server: %w", err)
+	}
+	defer func() { _ = server.Shutdown() }()
+
+	server.Start()
+	url := server.URL()
+
+	out := cmd.OutOrStdout()
+	if noBrowser {
+		fmt.Fprintf(out, "Open this URL in a browser to configure credentials:\n\n  %s\n\n", url)
+		fmt.Fprintf(out, "Over SSH, forward the port from your machine first:\n\n  ssh -L %d:127.0.0.1:%d %s\n\n", server.Port(), server.Port(), sshHostHint())
+	} else {
+		fmt.Fprintf(out, "Opening browser to configure credentials...\n")
+		fmt.Fprintf(out, "If browser doesn't open, visit: %s\n\n", url)
+		if err := openBrowser(url); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Could not open a browser: %v\n", err)
+		}
+	}
+
+	fmt.Fprintf(out, "Waiting for credentials...\n")
+	creds, err := server.WaitForCredentials(5 * time.Minute)
+	if err != nil {
+		return authserver.Credentials{}, err
+	}
+	return *creds, nil
+}
+
+// promptCredentials asks for each key at the terminal without echoing it,
+// asking again for rejected keys.
+func promptCredentials(cmd *cobra.Command, validate authserver.Validator) (authserver.Credentials, error) {
+	var creds authserver.Credentials
+	prompter := newSecretPrompter(cmd.InOrStdin(), cmd.ErrOrStderr())
+
+	fmt.Fprintln(cmd.ErrOrStderr(), "Input is hidden. Leave a key empty to skip it.")
+	fields := []struct {
+		name  string
+		label string
+		value *string
+	}{
+		{authserver.FieldClientID, "Logo API key (client_id)", &creds.ClientID},
+		{authserver.FieldAPIKey, "Brand API key (api_key)", &creds.APIKey},
+	}
+	for _, f := range fields {
+		value, err := prompter.Prompt(f.label)
+		if err != nil {
+			return creds, fmt.Errorf("failed to read %s: %w", f.name, err)
+		}
+		*f.value = value
+	}
+
+	for attempt := 1; validate != nil && (creds.ClientID != "" || creds.APIKey != ""); attempt++ {
+		fmt.Fprintln(cmd.ErrOrStderr(), "Validating credentials...")
+		fieldErrors := validate(cmd.Context(), creds)
+		if len(fieldErrors) == 0 {
+			break
+		}
+		if attempt == maxPromptAttempts {
+			return creds, credentialValidationError(fieldErrors)
+		}
+		for _, f := range fields {
+			msg, ok := fieldErrors[f.name]
+			if !ok {
+				continue
+			}
+			fmt.Fprintln(cmd.ErrOrStderr(), msg)
+			value, err := prompter.Prompt(f.label)
+			if err != nil {
+				return creds, credentialValidationError(fieldErrors)
+			}
+			*f.value = value
+		}
+	}
+	return creds, nil
+}
+
+// secretPrompter reads secrets without echo from a terminal, or line by line
+// from any other reader.
+type secretPrompter struct {
+	in    io.Reader
+	out   io.Writer
+	lines *bufio.Reader
+}
+
+func newSecretPrompter(in io.Reader, out io.Writer) *secretPrompter {
+	return &secretPrompter{in: in, out: out, lines: bufio.NewReader(in)}
+}
+
+// Prompt prints label and returns the trimmed input.
+func (p *secretPrompter) Prompt(label string) (string, error) {
+	fmt.Fprintf(p.out, "%s: ", label)
+	if f, ok := p.in.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		b, err := term.ReadPassword(int(f.Fd()))
+		fmt.Fprintln(p.out)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(b)), nil
+	}
+
+	line, err := p.lines.ReadString('\n')
+	if err == io.EOF && line == "" {
+		return "", io.ErrUnexpectedEOF
+	}
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+func isTerminalReader(r io.Reader) bool {
+	f, ok := r.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
+// browserUnavailable reports whether this session cannot open a local
+// browser: SSH sessions, and Unix desktops without a display server.
+func browserUnavailable() bool {
+	if os.Getenv("SSH_CONNECTION") != "" || os.Getenv("SSH_TTY") != "" {
+		return true
+	}
+	switch runtime.GOOS {
+	case "darwin", "windows":
+		return false
+	}
+	return os.Getenv("DISPLAY") == "" && os.Getenv("WAYLAND_DISPLAY") == ""
+}
+
+// sshHostHint names this machine for the port-forwarding example.
+func sshHostHint() string {
+	if host, err := os.Hostname(); err == nil && host != "" {
+		return host
+	}
+	return "<host>"
+}
+
+func openBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "linux":
+		cmd = exec.Command("xdg-open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		return fmt.Errorf("unsupported platform %s", runtime.GOOS)
+	}
+	return cmd.Start()
+}
@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"bytes"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// syncBuffer is a bytes.Buffer safe for a command writing while the test reads.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestAuthSetCmd_Prompt(t *testing.T) {
+	isolateConfigDir(t)
+	store := NewMockSecretsStore()
+	server := newStubAPI(t, "", "bad_api_key")
+
+	var stderr bytes.Buffer
+	cmd := newAuthSetCmdWithChecker(store, stubAPIChecker(server))
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&stderr)
+	// The API key is rejected once, then re-entered.
+	cmd.SetIn(strings.NewReader("good_client_id\nbad_api_key\ngood_api_key\n"))
+	cmd.SetArgs([]string{"--prompt"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if store.data["client_id"] != "good_client_id" || store.data["api_key"] != "good_api_key" {
+		t.Errorf("credentials not stored: %v", store.data)
+	}
+	if strings.Count(stderr.String(), "Brand API key (api_key): ") != 2 {
+		t.Errorf("api_key should be asked for twice:\n%s", stderr.String())
+	}
+	if strings.Count(stderr.String(), "Logo API key (client_id): ") != 1 {
+		t.Errorf("accepted client_id should not be asked for again:\n%s", stderr.String())
+	}
+}
+
+func TestAuthSetCmd_PromptGivesUp(t *testing.T) {
+	isolateConfigDir(t)
+	store := NewMockSecretsStore()
+	server := newStubAPI(t, "", "bad_api_key")
+
+	cmd := newAuthSetCmdWithChecker(store, stubAPIChecker(server))
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetIn(strings.NewReader("\nbad_api_key\nbad_api_key\nbad_api_key\n"))
+	cmd.SetArgs([]string{"--prompt"})
+	err := cmd.Execute()
+	if err == nil || !containsStr(err.Error(), "credentials not saved") {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	if len(store.data) != 0 {
+		t.Errorf("nothing should be stored: %v", store.data)
+	}
+}
+
+func TestAuthSetCmd_ConflictingModes(t *testing.T) {
+	isolateConfigDir(t)
+	for _, args := range [][]string{
+		{"--stdin", "--prompt"},
+		{"--stdin", "--no-browser"},
+		{"--prompt", "--port", "8085"},
+	} {
+		cmd := newAuthSetCmdWithStore(NewMockSecretsStore())
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err == nil {
+			t.Errorf("%v: expected error", args)
+		}
+	}
+}
+
+func TestAuthSetCmd_NoBrowserFixedPort(t *testing.T) {
+	isolateConfigDir(t)
+	store := NewMockSecretsStore()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	stdout := &syncBuffer{}
+	cmd := newAuthSetCmdWithStore(store)
+	cmd.SetOut(stdout)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--no-browser", "--skip-validate", "--port", strconv.Itoa(port)})
+
+	done := make(chan error, 1)
+	go func() { done <- cmd.Execute() }()
+
+	urlPattern := regexp.MustCompile(`http://127\.0\.0\.1:(\d+)/auth\?state=([0-9a-f]+)`)
+	var match []string
+	for deadline := time.Now().Add(2 * time.Second); match == nil; {
+		match = urlPattern.FindStringSubmatch(stdout.String())
+		if time.Now().After(deadline) {
+			t.Fatalf("form URL not printed:\n%s", stdout.String())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if match[1] != strconv.Itoa(port) {
+		t.Errorf("URL port = %s, want %d", match[1], port)
+	}
+	if !containsStr(stdout.String(), "ssh -L "+strconv.Itoa(port)+":127.0.0.1:"+strconv.Itoa(port)) {
+		t.Errorf("missing port forwarding hint:\n%s", stdout.String())
+	}
+
+	form := url.Values{"client_id": {"remote_client_id"}, "state": {match[2]}}
+	resp, err := http.PostForm("http://127.0.0.1:"+match[1]+"/auth", form)
+	if err != nil {
+		t.Fatalf("POST error = %v", err)
+	}
+	resp.Body.Close()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("command did not finish after submission")
+	}
+	if store.data["client_id"] != "remote_client_id" {
+		t.Errorf("client_id not stored: %v", store.data)
+	}
+}
+
+func TestBrowserUnavailable(t *testing.T) {
+	t.Setenv("SSH_CONNECTION", "10.0.0.1 22 10.0.0.2 50000")
+	if !browserUnavailable() {
+		t.Error("SSH sessions should be treated as having no browser")
+	}
+}
@@ -0,0 +1,299 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/brandfetch-cli/internal/config"
+	"github.com/salmonumbrella/brandfetch-cli/internal/output"
+	"github.com/salmonumbrella/brandfetch-cli/internal/secrets"
+)
+
+type profileInfo struct {
+	Name     string `json:"name"`
+	Active   bool   `json:"active"`
+	ClientID bool   `json:"client_id"`
+	APIKey   bool   `json:"api_key"`
+}
+
+// loadProfiles reads the profile registry from the config directory.
+func loadProfiles() (*config.Profiles, error) {
+	path, err := config.ProfilesFilePath()
+	if err != nil {
+		return nil, err
+	}
+	return config.LoadProfiles(path)
+}
+
+// currentProfile resolves the credential profile from --profile,
+// BRANDFETCH_PROFILE and the active profile, in that order.
+func currentProfile() (string, error) {
+	profiles, err := loadProfiles()
+	if err != nil {
+		return "", err
+	}
+	profile := config.ResolveProfile(profileName, profiles)
+	if err := config.ValidateProfileName(profile); err != nil {
+		return "", err
+	}
+	return profile, nil
+}
+
+// profileStore scopes store to the selected credential profile.
+func profileStore(store SecretsStore) (*secrets.ProfileStore, error) {
+	profile, err := currentProfile()
+	if err != nil {
+		return nil, err
+	}
+	return secrets.NewProfileStore(store, profile), nil
+}
+
+// openSecretsStore opens the secrets backend selected by --keyring-backend.
+func openSecretsStore() (*secrets.Store, error) {
+	dir, err := config.KeyringDir()
+	if err != nil {
+		return nil, err
+	}
+	store, err := secrets.NewStoreWithOptions(secrets.Options{
+		Backend: keyringBackend,
+		FileDir: dir,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open keychain: %w", err)
+	}
+	return store, nil
+}
+
+// registerProfile records a named profile in the registry.
+func registerProfile(name string) error {
+	profiles, err := loadProfiles()
+	if err != nil {
+		return err
+	}
+	if profiles.Has(name) {
+		return nil
+	}
+	profiles.Add(name)
+	return profiles.Save()
+}
+
+func newAuthProfilesCmd() *cobra.Command {
+	return newAuthProfilesCmdWithStore(nil)
+}
+
+func newAuthProfilesCmdWithStore(store SecretsStore) *cobra.Command {
+	openStore := func() (SecretsStore, error) {
+		if store != nil {
+			return store, nil
+		}
+		return openSecretsStore()
+	}
+
+	cmd := &cobra.Command{
+		Use:   "profiles",
+		Short: "Manage credential profiles",
+		Long: `Manage named credential profiles.
+
+Each profile has its own client ID and API key in the keychain. Create a
+profile by storing credentials for it, then switch between profiles:
+
+Examples:
+  brandfetch auth set --profile staging
+  brandfetch auth profiles list
+  brandfetch auth profiles use staging
+  brandfetch --profile production brand stripe.com
+  brandfetch auth profiles rename staging acme-staging
+  brandfetch auth profiles delete acme-staging`,
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "List credential profiles",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s, err := openStore()
+			if err != nil {
+				return err
+			}
+			return runAuthProfilesListCmd(cmd, s)
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "use <name>",
+		Short: "Set the active profile",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAuthProfilesUseCmd(cmd, args[0])
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "rename <old> <new>",
+		Short: "Rename a profile",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s, err := openStore()
+			if err != nil {
+				return err
+			}
+			return runAuthProfilesRenameCmd(cmd, s, args[0], args[1])
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "delete <name>",
+		Short: "Delete a profile and its stored credentials",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s, err := openStore()
+			if err != nil {
+				return err
+			}
+			return runAuthProfilesDeleteCmd(cmd, s, args[0])
+		},
+	})
+
+	return cmd
+}
+
+func runAuthProfilesListCmd(cmd *cobra.Command, store SecretsStore) error {
+	profiles, err := loadProfiles()
+	if err != nil {
+		return err
+	}
+	current, err := currentProfile()
+	if err != nil {
+		return err
+	}
+
+	var infos []profileInfo
+	for _, name := range profiles.List() {
+		scoped := secrets.NewProfileStore(store, name)
+		clientID, _ := scoped.Get("client_id")
+		apiKey, _ := scoped.Get("api_key")
+		infos = append(infos, profileInfo{
+			Name:     name,
+			Active:   name == current,
+			ClientID: clientID != "",
+			APIKey:   apiKey != "",
+		})
+	}
+
+	format, _, err := resolveOutput(cmd)
+	if err != nil {
+		return err
+	}
+	if format == output.FormatJSON {
+		return output.PrintJSON(cmd.OutOrStdout(), infos)
+	}
+	renderProfiles(cmd.OutOrStdout(), infos)
+	return nil
+}
+
+func renderProfiles(w io.Writer, infos []profileInfo) {
+	configured := func(ok bool) string {
+		if ok {
+			return "configured"
+		}
+		return "not configured"
+	}
+	for _, info := range infos {
+		marker := " "
+		if info.Active {
+			marker = "*"
+		}
+		fmt.Fprintf(w, "%s %-16s client_id: %-15s api_key: %s\n", marker, info.Name, configured(info.ClientID), configured(info.APIKey))
+	}
+}
+
+func runAuthProfilesUseCmd(cmd *cobra.Command, name string) error {
+	profiles, err := loadProfiles()
+	if err != nil {
+		return err
+	}
+	if !profiles.Has(name) {
+		return fmt.Errorf("unknown profile %q (create it with 'brandfetch auth set --profile %s')", name, name)
+	}
+
+	profiles.Active = name
+	if name == config.DefaultProfile {
+		profiles.Active = ""
+	}
+	if err := profiles.Save(); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Active profile: %s\n", name)
+	return nil
+}
+
+func runAuthProfilesRenameCmd(cmd *cobra.Command, store SecretsStore, from, to string) error {
+	if from == config.DefaultProfile || to == config.DefaultProfile {
+		return fmt.Errorf("the %s profile cannot be renamed", config.DefaultProfile)
+	}
+	if err := config.ValidateProfileName(to); err != nil {
+		return err
+	}
+	profiles, err := loadProfiles()
+	if err != nil {
+		return err
+	}
+	if !profiles.Has(from) {
+		return fmt.Errorf("unknown profile %q", from)
+	}
+	if profiles.Has(to) {
+		return fmt.Errorf("profile %q already exists", to)
+	}
+
+	src := secrets.NewProfileStore(store, from)
+	dst := secrets.NewProfileStore(store, to)
+	for _, key := range storedKeys() {
+		value, err := src.Get(key)
+		if err != nil || value == "" {
+			continue
+		}
+		if err := dst.Set(key, value); err != nil {
+			return fmt.Errorf("failed to store %s: %w", key, err)
+		}
+		_ = src.Delete(key)
+	}
+
+	profiles.Rename(from, to)
+	if err := profiles.Save(); err != nil {
+		return err
+	}
+	if rotations, err := loadRotations(); err == nil {
+		rotations.RenameProfile(from, to)
+		_ = rotations.Save()
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Renamed profile %s to %s.\n", from, to)
+	return nil
+}
+
+func runAuthProfilesDeleteCmd(cmd *cobra.Command, store SecretsStore, name string) error {
+	if name == config.DefaultProfile {
+		return fmt.Errorf("the %s profile cannot be deleted (use 'brandfetch auth clear')", config.DefaultProfile)
+	}
+	profiles, err := loadProfiles()
+	if err != nil {
+		return err
+	}
+	if !profiles.Has(name) {
+		return fmt.Errorf("unknown profile %q", name)
+	}
+
+	scoped := secrets.NewProfileStore(store, name)
+	for _, key := range storedKeys() {
+		_ = scoped.Delete(key)
+	}
+
+	profiles.Remove(name)
+	if err := profiles.Save(); err != nil {
+		return err
+	}
+	if rotations, err := loadRotations(); err == nil {
+		rotations.RemoveProfile(name)
+		_ = rotations.Save()
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Deleted profile %s.\n", name)
+	return nil
+}
@@ -0,0 +1,326 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/brandfetch-cli/internal/authserver"
+	"github.com/salmonumbrella/brandfetch-cli/internal/config"
+	"github.com/salmonumbrella/brandfetch-cli/internal/secrets"
+)
+
+const defaultRotateGrace = 24 * time.Hour
+
+var (
+	rotateAPIKey   bool
+	rotateClientID bool
+	rotateStdin    bool
+	rotateGrace    time.Duration
+)
+
+// previousKey is the secrets key holding a credential's pre-rotation value.
+func previousKey(key string) string {
+	return key + "_previous"
+}
+
+// storedKeys lists every secrets key kept per profile, including rollback copies.
+func storedKeys() []string {
+	keys := append([]string{}, credentialKeys...)
+	for _, key := range credentialKeys {
+		keys = append(keys, previousKey(key))
+	}
+	return keys
+}
+
+// loadRotations reads the rotation records from the config directory.
+func loadRotations() (*config.Rotations, error) {
+	path, err := config.RotationsFilePath()
+	if err != nil {
+		return nil, err
+	}
+	return config.LoadRotations(path)
+}
+
+// purgeExpiredPreviousKeys deletes rollback copies whose grace period ended
+// before now, so they do not outlive it in the secrets backend.
+func purgeExpiredPreviousKeys(store *secrets.ProfileStore, rotations *config.Rotations, now time.Time) {
+	changed := false
+	for _, key := range credentialKeys {
+		rot, ok := rotations.Get(store.Profile(), key)
+		if !ok || rot.PreviousUntil == nil || rot.HasPrevious(now) {
+			continue
+		}
+		_ = store.Delete(previousKey(key))
+		rot.PreviousUntil = nil
+		rotations.Set(store.Profile(), key, rot)
+		changed = true
+	}
+	if changed {
+		_ = rotations.Save()
+	}
+}
+
+// credentialEnvVars are the environment variables that override stored keys.
+var credentialEnvVars = map[string]string{
+	"client_id": "BRANDFETCH_CLIENT_ID",
+	"api_key":   "BRANDFETCH_API_KEY",
+}
+
+func newAuthRotateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rotate",
+		Short: "Replace a stored key, keeping the old one for rollback",
+		Long: `Replace the stored Brand API key or Logo API client ID with a new one.
+
+The new key is checked against the API first (unless --skip-validate). The old
+key is kept for the --grace period so 'brandfetch auth rollback' can restore
+it; --grace 0 discards it immediately. The new key is read from a hidden
+prompt, or from the first line of stdin with --stdin.
+
+Examples:
+  brandfetch auth rotate --api-key
+  brandfetch auth rotate --client-id --grace 72h
+  echo "$NEW_KEY" | brandfetch auth rotate --api-key --stdin
+  brandfetch auth rollback --api-key`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := openSecretsStore()
+			if err != nil {
+				return err
+			}
+			scoped, err := profileStore(store)
+			if err != nil {
+				return err
+			}
+			return runAuthRotateCmd(cmd, scoped, defaultCredentialChecker)
+		},
+	}
+	addRotateFlags(cmd)
+	return cmd
+}
+
+func newAuthRotateCmdWithChecker(store SecretsStore, checker newCredentialChecker) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "rotate",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			scoped, err := profileStore(store)
+			if err != nil {
+				return err
+			}
+			return runAuthRotateCmd(cmd, scoped, checker)
+		},
+	}
+	addRotateFlags(cmd)
+	return cmd
+}
+
+func addRotateFlags(cmd *cobra.Command) {
+	addRotateKeyFlags(cmd)
+	cmd.Flags().BoolVar(&rotateStdin, "stdin", false, "Read the new key from stdin")
+	cmd.Flags().DurationVar(&rotateGrace, "grace", defaultRotateGrace, "How long to keep the old key for rollback (0 discards it)")
+	cmd.Flags().BoolVar(&authSkipValidate, "skip-validate", false, "Save the new key without checking it against the API")
+}
+
+func addRotateKeyFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&rotateAPIKey, "api-key", false, "Brand API key")
+	cmd.Flags().BoolVar(&rotateClientID, "client-id", false, "Logo API client ID")
+}
+
+// rotateTarget returns the credential key selected by --api-key or --client-id.
+func rotateTarget() (string, error) {
+	switch {
+	case rotateAPIKey && rotateClientID:
+		return "", fmt.Errorf("choose one of --api-key or --client-id")
+	case rotateAPIKey:
+		return "api_key", nil
+	case rotateClientID:
+		return "client_id", nil
+	}
+	return "", fmt.Errorf("one of --api-key or --client-id is required")
+}
+
+func runAuthRotateCmd(cmd *cobra.Command, store *secrets.ProfileStore, checker newCredentialChecker) error {
+	key, err := rotateTarget()
+	if err != nil {
+		return err
+	}
+	if rotateGrace < 0 {
+		return fmt.Errorf("--grace must not be negative")
+	}
+
+	current, _ := store.Get(key)
+	if current == "" {
+		return fmt.Errorf("no %s stored for profile %s: use 'brandfetch auth set' first", key, store.Profile())
+	}
+
+	newValue, err := readRotatedKey(cmd, key)
+	if err != nil {
+		return err
+	}
+	if newValue == "" {
+		return fmt.Errorf("new %s is empty", key)
+	}
+	if newValue == current {
+		return fmt.Errorf("new %s is the same as the stored one", key)
+	}
+
+	if !authSkipValidate {
+		fmt.Fprintln(cmd.ErrOrStderr(), "Validating new key...")
+		creds := authserver.Credentials{}
+		if key == "api_key" {
+			creds.APIKey = newValue
+		} else {
+			creds.ClientID = newValue
+		}
+		if fieldErrors := validateCredentials(cmd.Context(), checker, creds); len(fieldErrors) > 0 {
+			return fmt.Errorf("%s not rotated: %s", key, fieldErrors[key])
+		}
+	}
+
+	rotations, err := loadRotations()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	rot := config.Rotation{RotatedAt: now}
+	if rotateGrace > 0 {
+		if err := store.Set(previousKey(key), current); err != nil {
+			return fmt.Errorf("failed to keep previous %s: %w", key, err)
+		}
+		until := now.Add(rotateGrace)
+		rot.PreviousUntil = &until
+	} else {
+		_ = store.Delete(previousKey(key))
+	}
+	if err := store.Set(key, newValue); err != nil {
+		// Leave the backend as it was before the rotation.
+		_ = store.Delete(previousKey(key))
+		return fmt.Errorf("failed to store new %s: %w", key, err)
+	}
+
+	rotations.Set(store.Profile(), key, rot)
+	if err := rotations.Save(); err != nil {
+		return fmt.Errorf("failed to record rotation: %w", err)
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "Rotated %s (profile: %s).\n", key, store.Profile())
+	if rot.PreviousUntil != nil {
+		fmt.Fprintf(out, "Previous key kept until %s; undo with 'brandfetch auth rollback --%s'.\n",
+			rot.PreviousUntil.Format(time.RFC3339), strings.ReplaceAll(key, "_", "-"))
+	}
+	warnEnvOverride(cmd, key)
+	return nil
+}
+
+// readRotatedKey reads the replacement key from stdin or a hidden prompt.
+func readRotatedKey(cmd *cobra.Command, key string) (string, error) {
+	if rotateStdin {
+		line, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+		if err != nil && err != io.EOF {
+			return "", fmt.Errorf("failed to read %s: %w", key, err)
+		}
+		return strings.TrimSpace(line), nil
+	}
+	value, err := newSecretPrompter(cmd.InOrStdin(), cmd.ErrOrStderr()).Prompt("New " + key)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", key, err)
+	}
+	return value, nil
+}
+
+// warnEnvOverride notes when an environment variable hides the stored key.
+func warnEnvOverride(cmd *cobra.Command, key string) {
+	if env := credentialEnvVars[key]; os.Getenv(env) != "" {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %s is set and overrides the stored %s.\n", env, key)
+	}
+}
+
+func newAuthRollbackCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rollback",
+		Short: "Restore the key replaced by the last rotation",
+		Long: `Restore the Brand API key or Logo API client ID that 'brandfetch auth rotate'
+replaced, as long as its grace period has not ended.
+
+Examples:
+  brandfetch auth rollback --api-key
+  brandfetch auth rollback --client-id --profile staging`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := openSecretsStore()
+			if err != nil {
+				return err
+			}
+			scoped, err := profileStore(store)
+			if err != nil {
+				return err
+			}
+			return runAuthRollbackCmd(cmd, scoped)
+		},
+	}
+	addRotateKeyFlags(cmd)
+	return cmd
+}
+
+func newAuthRollbackCmdWithStore(store SecretsStore) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "rollback",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			scoped, err := profileStore(store)
+			if err != nil {
+				return err
+			}
+			return runAuthRollbackCmd(cmd, scoped)
+		},
+	}
+	addRotateKeyFlags(cmd)
+	return cmd
+}
+
+func runAuthRollbackCmd(cmd *cobra.Command, store *secrets.ProfileStore) error {
+	key, err := rotateTarget()
+	if err != nil {
+		return err
+	}
+	rotations, err := loadRotations()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	rot, ok := rotations.Get(store.Profile(), key)
+	previous, _ := store.Get(previousKey(key))
+	if !ok || previous == "" {
+		return fmt.Errorf("no previous %s to restore for profile %s", key, store.Profile())
+	}
+	if !rot.HasPrevious(now) {
+		purgeExpiredPreviousKeys(store, rotations, now)
+		return fmt.Errorf("the grace period for the previous %s ended; it has been discarded", key)
+	}
+
+	if err := store.Set(key, previous); err != nil {
+		return fmt.Errorf("failed to restore %s: %w", key, err)
+	}
+	_ = store.Delete(previousKey(key))
+
+	rot.PreviousUntil = nil
+	rot.RolledBackAt = &now
+	rotations.Set(store.Profile(), key, rot)
+	if err := rotations.Save(); err != nil {
+		return fmt.Errorf("failed to record rollback: %w", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Restored previous %s (profile: %s).\n", key, store.Profile())
+	warnEnvOverride(cmd, key)
+	return nil
+}
@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func runRotateTest(t *testing.T, store SecretsStore, stdin string, args ...string) (string, error) {
+	t.Helper()
+	server := newStubAPI(t, "bad_client_id", "bad_api_key")
+	var stdout bytes.Buffer
+	cmd := newAuthRotateCmdWithChecker(store, stubAPIChecker(server))
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetIn(strings.NewReader(stdin))
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return stdout.String(), err
+}
+
+func runRollbackTest(t *testing.T, store SecretsStore, args ...string) (string, error) {
+	t.Helper()
+	var stdout bytes.Buffer
+	cmd := newAuthRollbackCmdWithStore(store)
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return stdout.String(), err
+}
+
+func TestAuthRotateAndRollback(t *testing.T) {
+	isolateConfigDir(t)
+	t.Setenv("BRANDFETCH_API_KEY", "")
+	store := NewMockSecretsStore()
+	store.data["api_key"] = "old_api_key"
+
+	out, err := runRotateTest(t, store, "new_api_key\n", "--api-key", "--stdin")
+	if err != nil {
+		t.Fatalf("rotate error = %v", err)
+	}
+	if !containsStr(out, "Rotated api_key") || !containsStr(out, "auth rollback --api-key") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+	if store.data["api_key"] != "new_api_key" || store.data["api_key_previous"] != "old_api_key" {
+		t.Fatalf("store after rotate = %v", store.data)
+	}
+
+	rotations, err := loadRotations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rot, ok := rotations.Get("default", "api_key")
+	if !ok || rot.PreviousUntil == nil {
+		t.Fatalf("rotation not recorded: %+v", rot)
+	}
+	if d := rot.PreviousUntil.Sub(rot.RotatedAt); d != defaultRotateGrace {
+		t.Errorf("grace = %v, want %v", d, defaultRotateGrace)
+	}
+
+	var status bytes.Buffer
+	statusCmd := newAuthStatusCmdWithStore(store)
+	statusCmd.SetOut(&status)
+	if err := statusCmd.Execute(); err != nil {
+		t.Fatalf("status error = %v", err)
+	}
+	if !containsStr(status.String(), "rotated: ") || !containsStr(status.String(), "previous key kept until") {
+		t.Errorf("status should show the rotation:\n%s", status.String())
+	}
+
+	if _, err := runRollbackTest(t, store, "--api-key"); err != nil {
+		t.Fatalf("rollback error = %v", err)
+	}
+	if store.data["api_key"] != "old_api_key" {
+		t.Errorf("api_key after rollback = %q, want old_api_key", store.data["api_key"])
+	}
+	if _, ok := store.data["api_key_previous"]; ok {
+		t.Error("previous key should be removed after rollback")
+	}
+	if _, err := runRollbackTest(t, store, "--api-key"); err == nil {
+		t.Error("second rollback should fail")
+	}
+}
+
+func TestAuthRotateRejectsInvalidKey(t *testing.T) {
+	isolateConfigDir(t)
+	store := NewMockSecretsStore()
+	store.data["client_id"] = "old_client_id"
+
+	_, err := runRotateTest(t, store, "bad_client_id\n", "--client-id", "--stdin")
+	if err == nil || !containsStr(err.Error(), "client_id not rotated") {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	if store.data["client_id"] != "old_client_id" {
+		t.Errorf("client_id changed despite failed validation: %v", store.data)
+	}
+	if _, ok := store.data["client_id_previous"]; ok {
+		t.Error("no previous key should be kept")
+	}
+}
+
+func TestAuthRotateNoGrace(t *testing.T) {
+	isolateConfigDir(t)
+	store := NewMockSecretsStore()
+	store.data["api_key"] = "old_api_key"
+	store.data["api_key_previous"] = "older_api_key"
+
+	if _, err := runRotateTest(t, store, "new_api_key\n", "--api-key", "--stdin", "--grace", "0"); err != nil {
+		t.Fatalf("rotate error = %v", err)
+	}
+	if _, ok := store.data["api_key_previous"]; ok {
+		t.Error("--grace 0 should discard the previous key")
+	}
+	if _, err := runRollbackTest(t, store, "--api-key"); err == nil {
+		t.Error("rollback should fail without a previous key")
+	}
+}
+
+func TestAuthRollbackExpired(t *testing.T) {
+	isolateConfigDir(t)
+	store := NewMockSecretsStore()
+	store.data["api_key"] = "old_api_key"
+
+	if _, err := runRotateTest(t, store, "new_api_key\n", "--api-key", "--stdin", "--grace", "1ns"); err != nil {
+		t.Fatalf("rotate error = %v", err)
+	}
+	time.Sleep(time.Millisecond)
+
+	_, err := runRollbackTest(t, store, "--api-key")
+	if err == nil || !containsStr(err.Error(), "grace period") {
+		t.Fatalf("expected expiry error, got %v", err)
+	}
+	if store.data["api_key"] != "new_api_key" {
+		t.Errorf("api_key = %q, want new_api_key", store.data["api_key"])
+	}
+	if _, ok := store.data["api_key_previous"]; ok {
+		t.Error("expired previous key should be discarded")
+	}
+}
+
+func TestAuthStatusPurgesExpiredPreviousKey(t *testing.T) {
+	isolateConfigDir(t)
+	t.Setenv("BRANDFETCH_API_KEY", "")
+	store := NewMockSecretsStore()
+	store.data["api_key"] = "old_api_key"
+
+	if _, err := runRotateTest(t, store, "new_api_key\n", "--api-key", "--stdin", "--grace", "1ns"); err != nil {
+		t.Fatalf("rotate error = %v", err)
+	}
+	time.Sleep(time.Millisecond)
+
+	statusCmd := newAuthStatusCmdWithStore(store)
+	statusCmd.SetOut(&bytes.Buffer{})
+	if err := statusCmd.Execute(); err != nil {
+		t.Fatalf("status error = %v", err)
+	}
+	if _, ok := store.data["api_key_previous"]; ok {
+		t.Error("expired previous key should be purged by auth status")
+	}
+	if store.data["api_key"] != "new_api_key" {
+		t.Errorf("api_key = %q, want new_api_key", store.data["api_key"])
+	}
+	rotations, _ := loadRotations()
+	if rot, ok := rotations.Get("default", "api_key"); !ok || rot.PreviousUntil != nil {
+		t.Errorf("rotation record = %+v, want the grace period cleared", rot)
+	}
+}
+
+func TestAuthRotateFlagErrors(t *testing.T) {
+	isolateConfigDir(t)
+	store := NewMockSecretsStore()
+	store.data["api_key"] = "old_api_key"
+
+	for _, args := range [][]string{
+		{"--stdin"},
+		{"--api-key", "--client-id", "--stdin"},
+		{"--client-id", "--stdin"}, // nothing stored to rotate
+		{"--api-key", "--stdin", "--grace", "-1h"},
+	} {
+		if _, err := runRotateTest(t, store, "new_key\n", args...); err == nil {
+			t.Errorf("%v: expected error", args)
+		}
+	}
+	if _, err := runRotateTest(t, store, "old_api_key\n", "--api-key", "--stdin"); err == nil {
+		t.Error("rotating to the same key should fail")
+	}
+}
+
+func TestAuthClearRemovesPreviousKeys(t *testing.T) {
+	isolateConfigDir(t)
+	store := NewMockSecretsStore()
+	store.data["api_key"] = "old_api_key"
+
+	if _, err := runRotateTest(t, store, "new_api_key\n", "--api-key", "--stdin"); err != nil {
+		t.Fatalf("rotate error = %v", err)
+	}
+	clear := newAuthClearCmdWithStore(store)
+	clear.SetOut(&bytes.Buffer{})
+	if err := clear.Execute(); err != nil {
+		t.Fatalf("clear error = %v", err)
+	}
+	if len(store.data) != 0 {
+		t.Errorf("store after clear = %v", store.data)
+	}
+	rotations, _ := loadRotations()
+	if _, ok := rotations.Get("default", "api_key"); ok {
+		t.Error("rotation record should be removed by clear")
+	}
+}
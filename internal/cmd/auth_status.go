@@ -0,0 +1,291 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/brandfetch-cli/internal/api"
+	"github.com/salmonumbrella/brandfetch-cli/internal/config"
+	"github.com/salmonumbrella/brandfetch-cli/internal/output"
+	"github.com/salmonumbrella/brandfetch-cli/internal/secrets"
+)
+
+var (
+	authStatusCheck bool
+	authStatusQuota bool
+)
+
+// credentialChecker validates credentials against the API.
+type credentialChecker interface {
+	ValidateClientID(ctx context.Context) (*api.KeyCheck, error)
+	ValidateAPIKey(ctx context.Context) (*api.KeyCheck, error)
+	CheckAPIKeyQuota(ctx context.Context) (*api.KeyCheck, error)
+}
+
+// newCredentialChecker builds the checker used for the given credentials.
+type newCredentialChecker func(clientID, apiKey string) credentialChecker
+
+func defaultCredentialChecker(clientID, apiKey string) credentialChecker {
+	opts, err := apiClientOptions()
+	if err != nil {
+		opts = api.ClientOptions{Transport: errorTransport{err: err}}
+	}
+	return api.NewClientWithOptions(clientID, apiKey, opts)
+}
+
+type authStatus struct {
+	Profile  string           `json:"profile"`
+	Backend  string           `json:"backend,omitempty"`
+	Source   config.Source    `json:"source,omitempty"`
+	ClientID credentialStatus `json:"client_id"`
+	APIKey   credentialStatus `json:"api_key"`
+}
+
+type credentialStatus struct {
+	Configured  bool          `json:"configured"`
+	Source      config.Source `json:"source,omitempty"`
+	Masked      string        `json:"masked,omitempty"`
+	Fingerprint string        `json:"fingerprint,omitempty"`
+	Check       *api.KeyCheck `json:"check,omitempty"`
+	CheckError  string        `json:"check_error,omitempty"`
+	CheckNote   string        `json:"check_note,omitempty"`
+
+	RotatedAt     *time.Time `json:"rotated_at,omitempty"`
+	PreviousUntil *time.Time `json:"previous_until,omitempty"`
+	RolledBackAt  *time.Time `json:"rolled_back_at,omitempty"`
+}
+
+func newAuthStatusCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show credential status",
+		Long: `Show which credentials are in use and where each one comes from.
+
+Credentials are resolved in the same order as every other command:
+environment variables, then the keychain, then config.json. Keys are shown
+masked, with a short SHA-256 fingerprint to tell keys apart.
+
+--check validates each configured key with one API request: a search for the
+client ID, which reports the Logo API quota, and a GraphQL query for the API
+key, which spends no Brand API quota. --quota checks the API key with a brand
+lookup instead, which reports the remaining Brand API quota and counts as one
+request against it.
+
+Examples:
+  brandfetch auth status
+  brandfetch auth status --check
+  brandfetch auth status --quota
+  brandfetch auth status --check -o json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := openSecretsStore()
+			if err != nil {
+				return err
+			}
+			scoped, err := profileStore(store)
+			if err != nil {
+				return err
+			}
+			return runAuthStatusCmd(cmd, scoped, defaultCredentialChecker)
+		},
+	}
+	cmd.Flags().BoolVar(&authStatusCheck, "check", false, "Validate credentials against the API")
+	cmd.Flags().BoolVar(&authStatusQuota, "quota", false, "Like --check, but report the Brand API quota (spends one Brand API request)")
+	return cmd
+}
+
+func newAuthStatusCmdWithStore(store SecretsStore) *cobra.Command {
+	return newAuthStatusCmdWithChecker(store, defaultCredentialChecker)
+}
+
+func newAuthStatusCmdWithChecker(store SecretsStore, checker newCredentialChecker) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "status",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			scoped, err := profileStore(store)
+			if err != nil {
+				return err
+			}
+			return runAuthStatusCmd(cmd, scoped, checker)
+		},
+	}
+	cmd.Flags().BoolVar(&authStatusCheck, "check", false, "Validate credentials against the API")
+	cmd.Flags().BoolVar(&authStatusQuota, "quota", false, "Like --check, but report the Brand API quota (spends one Brand API request)")
+	return cmd
+}
+
+func runAuthStatusCmd(cmd *cobra.Command, store *secrets.ProfileStore, checker newCredentialChecker) error {
+	configPath, _ := config.ConfigFilePath()
+	creds, err := config.LoadCredentialsWithOptions(store.Unscoped(), configPath, config.Requirements{
+		Profile:    store.Profile(),
+		ResolveAll: true,
+	})
+	if err != nil && !errors.Is(err, config.ErrNoCredentials) {
+		return err
+	}
+	if creds == nil {
+		creds = &config.Credentials{}
+	}
+
+	status := authStatus{
+		Profile:  store.Profile(),
+		Backend:  store.Backend(),
+		Source:   creds.Source,
+		ClientID: newCredentialStatus(creds.ClientID, creds.ClientIDSource),
+		APIKey:   newCredentialStatus(creds.APIKey, creds.APIKeySource),
+	}
+
+	if rotations, err := loadRotations(); err == nil {
+		purgeExpiredPreviousKeys(store, rotations, time.Now())
+		addRotationStatus(&status.ClientID, rotations, store.Profile(), "client_id")
+		addRotationStatus(&status.APIKey, rotations, store.Profile(), "api_key")
+	}
+
+	failed := false
+	if authStatusCheck || authStatusQuota {
+		client := checker(creds.ClientID, creds.APIKey)
+		if status.ClientID.Configured {
+			failed = runCredentialCheck(cmd.Context(), &status.ClientID, client.ValidateClientID) || failed
+		}
+		if status.APIKey.Configured {
+			validate := client.ValidateAPIKey
+			status.APIKey.CheckNote = "Brand API quota not checked (--quota spends one Brand API request)"
+			if authStatusQuota {
+				validate = client.CheckAPIKeyQuota
+				status.APIKey.CheckNote = "this check spent one Brand API request"
+			}
+			failed = runCredentialCheck(cmd.Context(), &status.APIKey, validate) || failed
+		}
+	}
+
+	format, _, err := resolveOutput(cmd)
+	if err != nil {
+		return err
+	}
+	if format == output.FormatJSON {
+		if err := output.PrintJSON(cmd.OutOrStdout(), status); err != nil {
+			return err
+		}
+	} else {
+		renderAuthStatus(cmd.OutOrStdout(), status)
+	}
+
+	if failed {
+		return fmt.Errorf("credential check failed")
+	}
+	return nil
+}
+
+// runCredentialCheck records a check result and reports whether it failed.
+func runCredentialCheck(ctx context.Context, status *credentialStatus, validate func(context.Context) (*api.KeyCheck, error)) bool {
+	check, err := validate(ctx)
+	if err != nil {
+		status.CheckError = err.Error()
+		return true
+	}
+	status.Check = check
+	return !check.Valid
+}
+
+// addRotationStatus copies the rotation record for key, hiding expired grace periods.
+func addRotationStatus(status *credentialStatus, rotations *config.Rotations, profile, key string) {
+	rot, ok := rotations.Get(profile, key)
+	if !ok {
+		return
+	}
+	rotatedAt := rot.RotatedAt
+	status.RotatedAt = &rotatedAt
+	status.RolledBackAt = rot.RolledBackAt
+	if rot.HasPrevious(time.Now()) {
+		status.PreviousUntil = rot.PreviousUntil
+	}
+}
+
+func newCredentialStatus(value string, source config.Source) credentialStatus {
+	if value == "" {
+		return credentialStatus{}
+	}
+	return credentialStatus{
+		Configured:  true,
+		Source:      source,
+		Masked:      maskSecret(value),
+		Fingerprint: secretFingerprint(value),
+	}
+}
+
+// maskSecret shows only the ends of long secrets and nothing of short ones.
+func maskSecret(value string) string {
+	if len(value) < 12 {
+		return "****"
+	}
+	return value[:4] + "…" + value[len(value)-4:]
+}
+
+// secretFingerprint returns a short SHA-256 prefix that identifies a secret.
+func secretFingerprint(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return "sha256:" + hex.EncodeToString(sum[:])[:8]
+}
+
+func renderAuthStatus(w io.Writer, status authStatus) {
+	fmt.Fprintf(w, "Profile: %s\n", status.Profile)
+	if status.Backend != "" {
+		fmt.Fprintf(w, "Backend: %s\n", status.Backend)
+	}
+	if status.Source != "" {
+		fmt.Fprintf(w, "Source: %s\n", status.Source)
+	}
+	renderCredentialStatus(w, "Logo API Key (client_id)", status.ClientID)
+	renderCredentialStatus(w, "Brand API Key (api_key)", status.APIKey)
+}
+
+func renderCredentialStatus(w io.Writer, label string, status credentialStatus) {
+	if !status.Configured {
+		fmt.Fprintf(w, "%s: not configured\n", label)
+		return
+	}
+	fmt.Fprintf(w, "%s: configured (%s) %s %s\n", label, status.Source, status.Masked, status.Fingerprint)
+	if status.RotatedAt != nil {
+		line := "  rotated: " + status.RotatedAt.Format(time.RFC3339)
+		if status.PreviousUntil != nil {
+			line += ", previous key kept until " + status.PreviousUntil.Format(time.RFC3339)
+		}
+		if status.RolledBackAt != nil {
+			line += ", rolled back " + status.RolledBackAt.Format(time.RFC3339)
+		}
+		fmt.Fprintln(w, line)
+	}
+
+	switch {
+	case status.CheckError != "":
+		fmt.Fprintf(w, "  check: error: %s\n", status.CheckError)
+	case status.Check != nil:
+		fmt.Fprintf(w, "  check: %s\n", describeKeyCheck(status.Check))
+	}
+	if status.CheckNote != "" {
+		fmt.Fprintf(w, "  note: %s\n", status.CheckNote)
+	}
+}
+
+func describeKeyCheck(check *api.KeyCheck) string {
+	result := "valid"
+	if !check.Valid {
+		result = fmt.Sprintf("invalid (HTTP %d)", check.Status)
+	}
+	if check.Message != "" {
+		result += ": " + check.Message
+	}
+	switch {
+	case check.Remaining != nil && check.Quota != nil:
+		result += fmt.Sprintf(", %d of %d requests remaining", *check.Remaining, *check.Quota)
+	case check.Usage != nil:
+		result += fmt.Sprintf(", %d requests used", *check.Usage)
+	}
+	return result
+}
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/salmonumbrella/brandfetch-cli/internal/api"
+)
+
+type stubChecker struct {
+	clientID *api.KeyCheck
+	apiKey   *api.KeyCheck
+	quota    *api.KeyCheck
+	err      error
+}
+
+func (s stubChecker) ValidateClientID(ctx context.Context) (*api.KeyCheck, error) {
+	return s.clientID, s.err
+}
+
+func (s stubChecker) ValidateAPIKey(ctx context.Context) (*api.KeyCheck, error) {
+	return s.apiKey, s.err
+}
+
+func (s stubChecker) CheckAPIKeyQuota(ctx context.Context) (*api.KeyCheck, error) {
+	return s.quota, s.err
+}
+
+func runAuthStatusTest(t *testing.T, store SecretsStore, checker stubChecker, args ...string) (string, error) {
+	t.Helper()
+	t.Cleanup(func() {
+		authStatusCheck = false
+		authStatusQuota = false
+		outputFormat = "text"
+	})
+
+	var stdout bytes.Buffer
+	cmd := newAuthStatusCmdWithChecker(store, func(clientID, apiKey string) credentialChecker {
+		return checker
+	})
+	cmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "text", "")
+	cmd.SetOut(&stdout)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return stdout.String(), err
+}
+
+func int64Ptr(n int64) *int64 { return &n }
+
+func TestAuthStatusShowsSourceAndMaskedKey(t *testing.T) {
+	isolateConfigDir(t)
+	t.Setenv("BRANDFETCH_CLIENT_ID", "env-client-id-123456")
+	t.Setenv("BRANDFETCH_API_KEY", "")
+	store := NewMockSecretsStore()
+	store.data["api_key"] = "keychain-api-key-abcdef"
+
+	out, err := runAuthStatusTest(t, store, stubChecker{})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	for _, want := range []string{
+		"Source: mixed",
+		"Logo API Key (client_id): configured (env) env-…3456 sha256:",
+		"Brand API Key (api_key): configured (keychain) keyc…cdef sha256:",
+	} {
+		if !containsStr(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if containsStr(out, "keychain-api-key-abcdef") {
+		t.Errorf("output leaks the full key:\n%s", out)
+	}
+}
+
+func TestAuthStatusCheck(t *testing.T) {
+	isolateConfigDir(t)
+	t.Setenv("BRANDFETCH_CLIENT_ID", "")
+	t.Setenv("BRANDFETCH_API_KEY", "")
+	store := NewMockSecretsStore()
+	store.data["client_id"] = "client-id-123456"
+	store.data["api_key"] = "api-key-abcdefgh"
+
+	checker := stubChecker{
+		clientID: &api.KeyCheck{Valid: true, Status: 200, Quota: int64Ptr(1000), Remaining: int64Ptr(750)},
+		apiKey:   &api.KeyCheck{Valid: false, Status: 401, Message: "Key rejected by the API"},
+	}
+	out, err := runAuthStatusTest(t, store, checker, "--check")
+	if err == nil {
+		t.Fatal("expected error for a rejected key")
+	}
+	if !containsStr(out, "check: valid, 750 of 1000 requests remaining") {
+		t.Errorf("output missing client check:\n%s", out)
+	}
+	if !containsStr(out, "check: invalid (HTTP 401): Key rejected by the API") {
+		t.Errorf("output missing api key check:\n%s", out)
+	}
+}
+
+func TestAuthStatusQuota(t *testing.T) {
+	isolateConfigDir(t)
+	t.Setenv("BRANDFETCH_CLIENT_ID", "")
+	t.Setenv("BRANDFETCH_API_KEY", "")
+	store := NewMockSecretsStore()
+	store.data["api_key"] = "api-key-abcdefgh"
+
+	checker := stubChecker{
+		apiKey: &api.KeyCheck{Valid: true, Status: 200},
+		quota:  &api.KeyCheck{Valid: true, Status: 200, Quota: int64Ptr(100), Remaining: int64Ptr(60)},
+	}
+	out, err := runAuthStatusTest(t, store, checker, "--check")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !containsStr(out, "check: valid\n") || !containsStr(out, "note: Brand API quota not checked (--quota spends one Brand API request)") {
+		t.Errorf("--check output:\n%s", out)
+	}
+
+	out, err = runAuthStatusTest(t, store, checker, "--quota")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !containsStr(out, "check: valid, 60 of 100 requests remaining") || !containsStr(out, "note: this check spent one Brand API request") {
+		t.Errorf("--quota output:\n%s", out)
+	}
+}
+
+func TestAuthStatusCheckConnectionError(t *testing.T) {
+	isolateConfigDir(t)
+	t.Setenv("BRANDFETCH_CLIENT_ID", "")
+	t.Setenv("BRANDFETCH_API_KEY", "")
+	store := NewMockSecretsStore()
+	store.data["client_id"] = "client-id-123456"
+
+	out, err := runAuthStatusTest(t, store, stubChecker{err: fmt.Errorf("connection failed")}, "--check")
+	if err == nil {
+		t.Fatal("expected error when the check cannot run")
+	}
+	if !containsStr(out, "check: error: connection failed") {
+		t.Errorf("output missing check error:\n%s", out)
+	}
+}
+
+func TestAuthStatusJSON(t *testing.T) {
+	isolateConfigDir(t)
+	t.Setenv("BRANDFETCH_CLIENT_ID", "")
+	t.Setenv("BRANDFETCH_API_KEY", "")
+	store := NewMockSecretsStore()
+	store.data["client_id"] = "client-id-123456"
+
+	checker := stubChecker{clientID: &api.KeyCheck{Valid: true, Status: 200}}
+	out, err := runAuthStatusTest(t, store, checker, "--check", "-o", "json")
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	var status authStatus
+	if err := json.Unmarshal([]byte(out), &status); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if status.Profile != "default" || status.Source != "keychain" {
+		t.Errorf("status = %+v", status)
+	}
+	if !status.ClientID.Configured || status.ClientID.Check == nil || !status.ClientID.Check.Valid {
+		t.Errorf("client_id = %+v", status.ClientID)
+	}
+	if status.APIKey.Configured || status.APIKey.Check != nil {
+		t.Errorf("api_key = %+v, want unconfigured and unchecked", status.APIKey)
+	}
+}
@@ -2,8 +2,14 @@ package cmd
 
 import (
 	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
+
+	"github.com/salmonumbrella/brandfetch-cli/internal/api"
+	"github.com/salmonumbrella/brandfetch-cli/internal/authserver"
 )
 
 type MockSecretsStore struct {
@@ -32,7 +38,17 @@ func (m *MockSecretsStore) Delete(key string) error {
 	return nil
 }
 
+// isolateConfigDir points the config directory (and profile registry) at a temp dir.
+func isolateConfigDir(t *testing.T) {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("BRANDFETCH_PROFILE", "")
+	profileName = ""
+	t.Cleanup(func() { profileName = "" })
+}
+
 func TestAuthSetCmd_Stdin(t *testing.T) {
+	isolateConfigDir(t)
 	store := NewMockSecretsStore()
 
 	var stdout bytes.Buffer
@@ -41,7 +57,7 @@ func TestAuthSetCmd_Stdin(t *testing.T) {
 	cmd := newAuthSetCmdWithStore(store)
 	cmd.SetOut(&stdout)
 	cmd.SetIn(stdin)
-	cmd.SetArgs([]string{"--stdin"})
+	cmd.SetArgs([]string{"--stdin", "--skip-validate"})
 
 	err := cmd.Execute()
 	if err != nil {
@@ -56,7 +72,135 @@ func TestAuthSetCmd_Stdin(t *testing.T) {
 	}
 }
 
+// newStubAPI serves search, brand and GraphQL requests, rejecting the given
+// keys with 401.
+func newStubAPI(t *testing.T, badClientID, badAPIKey string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/v2/search/"):
+			if r.URL.Query().Get("c") == badClientID {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`[]`))
+		case strings.HasPrefix(r.URL.Path, "/v2/brands/"), r.URL.Path == "/graphql":
+			if r.Header.Get("Authorization") == "Bearer "+badAPIKey {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func stubAPIChecker(server *httptest.Server) newCredentialChecker {
+	return func(clientID, apiKey string) credentialChecker {
+		return api.NewClientWithOptions(clientID, apiKey, api.ClientOptions{
+			BaseURL:    server.URL,
+			GraphQLURL: server.URL + "/graphql",
+		})
+	}
+}
+
+func TestAuthSetCmd_StdinValidated(t *testing.T) {
+	isolateConfigDir(t)
+	store := NewMockSecretsStore()
+	server := newStubAPI(t, "bad_client_id", "bad_api_key")
+
+	cmd := newAuthSetCmdWithChecker(store, stubAPIChecker(server))
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetIn(strings.NewReader("good_client_id\ngood_api_key\n"))
+	cmd.SetArgs([]string{"--stdin"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if store.data["client_id"] != "good_client_id" || store.data["api_key"] != "good_api_key" {
+		t.Errorf("credentials not stored: %v", store.data)
+	}
+}
+
+func TestAuthSetCmd_StdinRejected(t *testing.T) {
+	isolateConfigDir(t)
+	store := NewMockSecretsStore()
+	server := newStubAPI(t, "bad_client_id", "bad_api_key")
+
+	cmd := newAuthSetCmdWithChecker(store, stubAPIChecker(server))
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetIn(strings.NewReader("good_client_id\nbad_api_key\n"))
+	cmd.SetArgs([]string{"--stdin"})
+	err := cmd.Execute()
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+	if !containsStr(err.Error(), "api_key: Brand API key was rejected (HTTP 401)") {
+		t.Errorf("error should name the rejected key: %v", err)
+	}
+	if containsStr(err.Error(), "client_id:") {
+		t.Errorf("valid client_id should not be reported: %v", err)
+	}
+	if len(store.data) != 0 {
+		t.Errorf("nothing should be stored when validation fails: %v", store.data)
+	}
+}
+
+func TestAuthSetCmd_SkipValidate(t *testing.T) {
+	isolateConfigDir(t)
+	store := NewMockSecretsStore()
+	server := newStubAPI(t, "bad_client_id", "")
+
+	cmd := newAuthSetCmdWithChecker(store, stubAPIChecker(server))
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetIn(strings.NewReader("bad_client_id\n"))
+	cmd.SetArgs([]string{"--stdin", "--skip-validate"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if store.data["client_id"] != "bad_client_id" {
+		t.Errorf("client_id not stored: %v", store.data)
+	}
+}
+
+func TestAuthSetCmd_StoresReferenceUnresolved(t *testing.T) {
+	isolateConfigDir(t)
+	t.Setenv("BF_TEST_GOOD_KEY", "good_api_key")
+	store := NewMockSecretsStore()
+	server := newStubAPI(t, "", "bad_api_key")
+
+	cmd := newAuthSetCmdWithChecker(store, stubAPIChecker(server))
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetIn(strings.NewReader("\nenv:BF_TEST_GOOD_KEY\n"))
+	cmd.SetArgs([]string{"--stdin"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if store.data["api_key"] != "env:BF_TEST_GOOD_KEY" {
+		t.Errorf("reference should be stored as given: %v", store.data)
+	}
+}
+
+func TestValidateCredentialsConnectionError(t *testing.T) {
+	server := newStubAPI(t, "", "")
+	server.Close()
+
+	fieldErrors := validateCredentials(context.Background(), stubAPIChecker(server), authserver.Credentials{ClientID: "id"})
+	if !containsStr(fieldErrors[authserver.FieldClientID], "could not be validated") {
+		t.Errorf("fieldErrors = %v", fieldErrors)
+	}
+	if _, ok := fieldErrors[authserver.FieldAPIKey]; ok {
+		t.Errorf("api_key was not provided and should not be checked: %v", fieldErrors)
+	}
+}
+
 func TestAuthStatusCmd(t *testing.T) {
+	isolateConfigDir(t)
 	store := NewMockSecretsStore()
 	store.data["client_id"] = "some_id"
 	store.data["api_key"] = "some_key"
@@ -76,7 +220,30 @@ func TestAuthStatusCmd(t *testing.T) {
 	}
 }
 
+type backendSecretsStore struct {
+	*MockSecretsStore
+	backend string
+}
+
+func (s backendSecretsStore) Backend() string { return s.backend }
+
+func TestAuthStatusCmdShowsBackend(t *testing.T) {
+	isolateConfigDir(t)
+	store := backendSecretsStore{MockSecretsStore: NewMockSecretsStore(), backend: "file"}
+
+	var stdout bytes.Buffer
+	cmd := newAuthStatusCmdWithStore(store)
+	cmd.SetOut(&stdout)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !containsStr(stdout.String(), "Backend: file") {
+		t.Errorf("output should show the backend: %s", stdout.String())
+	}
+}
+
 func TestAuthClearCmd(t *testing.T) {
+	isolateConfigDir(t)
 	store := NewMockSecretsStore()
 	store.data["client_id"] = "some_id"
 	store.data["api_key"] = "some_key"
@@ -97,3 +264,109 @@ func TestAuthClearCmd(t *testing.T) {
 		t.Errorf("api_key should be deleted")
 	}
 }
+
+func TestAuthSetCmd_Profile(t *testing.T) {
+	isolateConfigDir(t)
+	store := NewMockSecretsStore()
+	store.data["client_id"] = "default_id"
+
+	profileName = "staging"
+	var stdout bytes.Buffer
+	cmd := newAuthSetCmdWithStore(store)
+	cmd.SetOut(&stdout)
+	cmd.SetIn(strings.NewReader("staging_id\nstaging_key\n"))
+	cmd.SetArgs([]string{"--stdin", "--skip-validate"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if store.data["staging.client_id"] != "staging_id" || store.data["staging.api_key"] != "staging_key" {
+		t.Errorf("profile keys not stored: %v", store.data)
+	}
+	if store.data["client_id"] != "default_id" {
+		t.Errorf("default profile overwritten: %v", store.data)
+	}
+	profiles, err := loadProfiles()
+	if err != nil || !profiles.Has("staging") {
+		t.Errorf("staging not registered: %v %v", profiles, err)
+	}
+
+	stdout.Reset()
+	status := newAuthStatusCmdWithStore(store)
+	status.SetOut(&stdout)
+	if err := status.Execute(); err != nil {
+		t.Fatalf("status error = %v", err)
+	}
+	if !containsStr(stdout.String(), "Profile: staging") {
+		t.Errorf("status should name the profile: %s", stdout.String())
+	}
+}
+
+func TestAuthProfilesCmd(t *testing.T) {
+	isolateConfigDir(t)
+	store := NewMockSecretsStore()
+	store.data["client_id"] = "default_id"
+	store.data["staging.client_id"] = "staging_id"
+	store.data["staging.api_key"] = "staging_key"
+	if err := registerProfile("staging"); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(args ...string) (string, error) {
+		var stdout bytes.Buffer
+		cmd := newAuthProfilesCmdWithStore(store)
+		cmd.SetOut(&stdout)
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return stdout.String(), err
+	}
+
+	out, err := run("list")
+	if err != nil {
+		t.Fatalf("list error = %v", err)
+	}
+	if !containsStr(out, "* default") || !containsStr(out, "  staging") {
+		t.Errorf("unexpected list output: %s", out)
+	}
+
+	if _, err := run("use", "missing"); err == nil {
+		t.Error("expected error switching to an unknown profile")
+	}
+	if _, err := run("use", "staging"); err != nil {
+		t.Fatalf("use error = %v", err)
+	}
+	if profile, _ := currentProfile(); profile != "staging" {
+		t.Errorf("currentProfile() = %q, want staging", profile)
+	}
+
+	// --profile / BRANDFETCH_PROFILE take precedence over the active profile.
+	profileName = "default"
+	if profile, _ := currentProfile(); profile != "default" {
+		t.Errorf("currentProfile() with --profile = %q, want default", profile)
+	}
+	profileName = ""
+
+	if _, err := run("rename", "staging", "prod"); err != nil {
+		t.Fatalf("rename error = %v", err)
+	}
+	if store.data["prod.client_id"] != "staging_id" || store.data["staging.client_id"] != "" {
+		t.Errorf("keys not moved on rename: %v", store.data)
+	}
+	if profile, _ := currentProfile(); profile != "prod" {
+		t.Errorf("active profile after rename = %q, want prod", profile)
+	}
+
+	if _, err := run("delete", "default"); err == nil {
+		t.Error("expected error deleting the default profile")
+	}
+	if _, err := run("delete", "prod"); err != nil {
+		t.Fatalf("delete error = %v", err)
+	}
+	if _, ok := store.data["prod.api_key"]; ok {
+		t.Errorf("keys not deleted: %v", store.data)
+	}
+	if profile, _ := currentProfile(); profile != "default" {
+		t.Errorf("active profile after delete = %q, want default", profile)
+	}
+}
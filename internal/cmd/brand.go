@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -10,6 +11,9 @@ import (
 	"github.com/salmonumbrella/brandfetch-cli/internal/output"
 )
 
+var brandIndustry string
+var brandRaw bool
+
 // NewBrandCmd creates the brand command.
 func NewBrandCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -22,7 +26,9 @@ This command uses the Brand API which has limited quota.
 Examples:
   brandfetch brand github.com
   brandfetch brand stripe.com --output json
-  brandfetch brand id_123 --output json`,
+  brandfetch brand id_123 --output json
+  brandfetch brand stripe.com --industry financial-services
+  brandfetch brand stripe.com --raw`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client, err := createClient(clientRequirements{requireAPIKey: true})
@@ -32,17 +38,22 @@ Examples:
 			return runBrandCmd(cmd, args, client)
 		},
 	}
+	cmd.Flags().StringVar(&brandIndustry, "industry", "", "Fail unless the brand is in this industry (name or slug)")
+	cmd.Flags().BoolVar(&brandRaw, "raw", false, "Print the API response body unchanged")
 	return cmd
 }
 
 func newBrandCmdWithClient(client APIClient) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:  "brand <identifier>",
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runBrandCmd(cmd, args, client)
 		},
 	}
+	cmd.Flags().StringVar(&brandIndustry, "industry", "", "Fail unless the brand is in this industry (name or slug)")
+	cmd.Flags().BoolVar(&brandRaw, "raw", false, "Print the API response body unchanged")
+	return cmd
 }
 
 func runBrandCmd(cmd *cobra.Command, args []string, client APIClient) error {
@@ -56,6 +67,12 @@ func runBrandCmd(cmd *cobra.Command, args []string, client APIClient) error {
 	if err != nil {
 		return err
 	}
+	if brandIndustry != "" && !brand.Company.InIndustry(brandIndustry) {
+		return fmt.Errorf("%s is not in industry %q", brand.Domain, brandIndustry)
+	}
+	if brandRaw {
+		return printRawBrand(cmd, brand)
+	}
 
 	format, colorize, err := resolveOutput(cmd)
 	if err != nil {
@@ -70,6 +87,15 @@ func runBrandCmd(cmd *cobra.Command, args []string, client APIClient) error {
 	return nil
 }
 
+// printRawBrand prints the Brand API response exactly as received, so fields
+// the typed model does not know about yet are visible.
+func printRawBrand(cmd *cobra.Command, brand *api.Brand) error {
+	if len(brand.Raw) == 0 {
+		return fmt.Errorf("raw response is not available")
+	}
+	return output.PrintRaw(cmd.OutOrStdout(), brand.Raw)
+}
+
 func convertBrandToOutput(brand *api.Brand) *output.BrandResult {
 	result := &output.BrandResult{
 		ID:              brand.ID,
@@ -83,14 +109,23 @@ func convertBrandToOutput(brand *api.Brand) *output.BrandResult {
 		URN:             brand.URN,
 	}
 
+	result.Company = convertCompanyToOutput(brand.Company)
+
 	// Convert logos
 	for _, logo := range brand.Logos {
+		var tags []string
+		for _, t := range logo.Tags {
+			if t.Name != "" {
+				tags = append(tags, t.Name)
+			}
+		}
 		for _, f := range logo.Formats {
 			result.Logos = append(result.Logos, output.LogoInfo{
 				Type:   logo.Type,
 				Theme:  logo.Theme,
 				URL:    f.Src,
 				Format: f.Format,
+				Tags:   tags,
 			})
 		}
 	}
@@ -122,3 +157,31 @@ func convertBrandToOutput(brand *api.Brand) *output.BrandResult {
 
 	return result
 }
+
+func convertCompanyToOutput(company *api.Company) *output.CompanyInfo {
+	if company == nil {
+		return nil
+	}
+	info := &output.CompanyInfo{
+		Kind:        company.Kind,
+		Employees:   company.Employees,
+		FoundedYear: company.FoundedYear,
+	}
+	if loc := company.Location; loc != nil {
+		var parts []string
+		for _, p := range []string{loc.City, loc.State, loc.Country} {
+			if p != "" {
+				parts = append(parts, p)
+			}
+		}
+		info.Location = strings.Join(parts, ", ")
+	}
+	for _, ind := range company.Industries {
+		industry := output.IndustryInfo{Name: ind.Name, Slug: ind.Slug, Score: ind.Score}
+		if ind.Parent != nil {
+			industry.Parent = ind.Parent.Name
+		}
+		info.Industries = append(info.Industries, industry)
+	}
+	return info
+}
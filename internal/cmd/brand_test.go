@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/salmonumbrella/brandfetch-cli/internal/api"
@@ -78,3 +79,134 @@ func TestBrandCmd_JSON(t *testing.T) {
 		t.Errorf("JSON name = %v, want GitHub", result["name"])
 	}
 }
+
+func stripeBrand() *api.Brand {
+	var brand api.Brand
+	_ = json.Unmarshal([]byte(`{
+		"name": "Stripe",
+		"domain": "stripe.com",
+		"company": {
+			"employees": 5001,
+			"kind": "PRIVATELY_HELD",
+			"location": {"city": "South San Francisco", "state": "CA", "country": "United States"},
+			"industries": [{"name": "Financial Services", "slug": "financial-services", "score": 0.91,
+				"parent": {"name": "Finance", "slug": "finance"}}]
+		}
+	}`), &brand)
+	return &brand
+}
+
+func TestBrandCmd_TextCompany(t *testing.T) {
+	mock := &MockAPIClient{
+		GetBrandFunc: func(ctx context.Context, domain string) (*api.Brand, error) {
+			return stripeBrand(), nil
+		},
+	}
+
+	var stdout bytes.Buffer
+	outputFormat = "text"
+	cmd := newBrandCmdWithClient(mock)
+	cmd.SetOut(&stdout)
+	cmd.SetArgs([]string{"stripe.com"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	for _, want := range []string{"Employees: 5001", "Location: South San Francisco, CA, United States", "Finance > Financial Services (0.91)"} {
+		if !containsStr(stdout.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, stdout.String())
+		}
+	}
+}
+
+func TestBrandCmd_Industry(t *testing.T) {
+	mock := &MockAPIClient{
+		GetBrandFunc: func(ctx context.Context, domain string) (*api.Brand, error) {
+			return stripeBrand(), nil
+		},
+	}
+	outputFormat = "text"
+
+	var stdout bytes.Buffer
+	cmd := newBrandCmdWithClient(mock)
+	cmd.SetOut(&stdout)
+	cmd.SetArgs([]string{"stripe.com", "--industry", "finance"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !containsStr(stdout.String(), "Stripe") {
+		t.Errorf("output missing brand for matching industry")
+	}
+
+	cmd = newBrandCmdWithClient(mock)
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"stripe.com", "--industry", "software"})
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), `not in industry "software"`) {
+		t.Fatalf("Execute() error = %v, want industry mismatch", err)
+	}
+}
+
+func TestBrandCmd_JSONKeepsUnknownCompanyFields(t *testing.T) {
+	mock := &MockAPIClient{
+		GetBrandFunc: func(ctx context.Context, domain string) (*api.Brand, error) {
+			var brand api.Brand
+			_ = json.Unmarshal([]byte(`{"name":"Stripe","company":{"kind":"PRIVATELY_HELD","revenue":"1B+"}}`), &brand)
+			return &brand, nil
+		},
+	}
+
+	var stdout bytes.Buffer
+	outputFormat = "json"
+	defer func() { outputFormat = "text" }()
+
+	cmd := newBrandCmdWithClient(mock)
+	cmd.SetOut(&stdout)
+	cmd.SetArgs([]string{"stripe.com"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	var result struct {
+		Company map[string]interface{} `json:"company"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+		t.Fatalf("output not valid JSON: %v", err)
+	}
+	if result.Company["revenue"] != "1B+" || result.Company["kind"] != "PRIVATELY_HELD" {
+		t.Errorf("company = %v, want unknown fields preserved", result.Company)
+	}
+}
+
+func TestBrandCmd_Raw(t *testing.T) {
+	raw := "{\n  \"name\": \"GitHub\",\n  \"newField\": [1, 2]\n}"
+	mock := &MockAPIClient{
+		GetBrandFunc: func(ctx context.Context, domain string) (*api.Brand, error) {
+			return &api.Brand{Name: "GitHub", Raw: json.RawMessage(raw)}, nil
+		},
+	}
+	outputFormat = "text"
+
+	var stdout bytes.Buffer
+	cmd := newBrandCmdWithClient(mock)
+	cmd.SetOut(&stdout)
+	cmd.SetArgs([]string{"github.com", "--raw"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if stdout.String() != raw+"\n" {
+		t.Errorf("stdout = %q, want the body unchanged", stdout.String())
+	}
+
+	mock.GetBrandFunc = func(ctx context.Context, domain string) (*api.Brand, error) {
+		return &api.Brand{Name: "GitHub"}, nil
+	}
+	cmd = newBrandCmdWithClient(mock)
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"github.com", "--raw"})
+	if err := cmd.Execute(); err == nil {
+		t.Fatal("Execute() error = nil, want raw response unavailable")
+	}
+}
@@ -0,0 +1,386 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/brandfetch-cli/internal/api"
+	"github.com/salmonumbrella/brandfetch-cli/internal/config"
+	"github.com/salmonumbrella/brandfetch-cli/internal/output"
+)
+
+var (
+	buildFile      string
+	buildLocked    bool
+	buildSignKey   string
+	buildVerifyKey string
+)
+
+// NewBuildCmd creates the build command.
+func NewBuildCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "build",
+		Short: "Build a brand kit from brandfetch.yaml",
+		Long: `Resolve a declarative brand kit project file end-to-end.
+
+The project file lists brands, logo variants, export formats and output paths:
+
+  output: brand-assets
+  lock: brandfetch.lock
+  brands:
+    - stripe.com
+    - github.com
+  logos:                # optional, defaults to light/dark SVG logos + favicon
+    - type: logo
+      theme: dark
+      format: svg
+    - type: icon
+      format: png
+      width: 256
+  exports:
+    css: styles/brands.css
+    tailwind: tailwind.brands.js
+    tokens: tokens/brands.json
+
+Logo variants are saved as <type>[-<theme>][-<size>].<format>, where size is
+256x128, w256 or h128. Two variants may not produce the same file name.
+
+Every generated file is recorded in a SHA-256 lock file. Use --locked in CI to
+fail when the build no longer matches the committed lock; files are only
+written once they match it. Use --sign-key to write
+a detached ed25519 signature (brandfetch.lock.sig) and --verify-key with
+--locked to reject a tampered lock file.
+
+Uses the Brand API (one request per brand). Logo variants use the Logo API.
+
+Examples:
+  brandfetch build
+  brandfetch build --file ./design/brandfetch.yaml
+  brandfetch build --locked
+  brandfetch build --sign-key brandfetch.key
+  brandfetch build --locked --verify-key brandfetch.pub`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			project, err := config.LoadProject(buildFile)
+			if err != nil {
+				return err
+			}
+			client, err := createClient(clientRequirements{
+				requireAPIKey:   true,
+				requireClientID: len(project.Logos) > 0,
+			})
+			if err != nil {
+				return err
+			}
+			httpClient, err := newDownloadClient()
+			if err != nil {
+				return err
+			}
+			return runBuildCmd(cmd, project, client, httpClient)
+		},
+	}
+
+	addBuildFlags(cmd)
+
+	return cmd
+}
+
+func newBuildCmdWithClients(client APIClient, httpClient HTTPClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "build",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			project, err := config.LoadProject(buildFile)
+			if err != nil {
+				return err
+			}
+			return runBuildCmd(cmd, project, client, httpClient)
+		},
+	}
+	addBuildFlags(cmd)
+	return cmd
+}
+
+func addBuildFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&buildFile, "file", "f", config.DefaultProjectFile, "Project file")
+	cmd.Flags().BoolVar(&buildLocked, "locked", false, "Fail if the build does not match the lock file")
+	cmd.Flags().StringVar(&buildSignKey, "sign-key", "", "Sign the lock file with an ed25519 private key")
+	cmd.Flags().StringVar(&buildVerifyKey, "verify-key", "", "Verify the lock file signature with an ed25519 public key (requires --locked)")
+	addDownloadFlags(cmd)
+}
+
+func runBuildCmd(cmd *cobra.Command, project *config.Project, client APIClient, httpClient HTTPClient) error {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if buildVerifyKey != "" && !buildLocked {
+		return fmt.Errorf("--verify-key requires --locked")
+	}
+	if buildSignKey != "" && buildLocked {
+		return fmt.Errorf("--sign-key and --locked are mutually exclusive")
+	}
+	lockPath := project.Path(project.Lock)
+	if buildVerifyKey != "" {
+		if err := verifyManifestSignature(lockPath, buildVerifyKey); err != nil {
+			return err
+		}
+	}
+
+	// With --locked everything is built into a staging directory and only
+	// moved into place once it matches the lock, so a failed check leaves the
+	// tree untouched.
+	stage := buildStage{projectDir: project.Dir}
+	if buildLocked {
+		dir, err := os.MkdirTemp(project.Dir, ".brandfetch-build-")
+		if err != nil {
+			return fmt.Errorf("failed to create staging directory: %w", err)
+		}
+		defer os.RemoveAll(dir)
+		stage.dir = dir
+	}
+
+	var results []*output.QuickResult
+	var entries []checksumEntry
+	for _, id := range project.Brands {
+		brand, err := client.GetBrand(ctx, id)
+		if err != nil {
+			return fmt.Errorf("%s: %w", id, err)
+		}
+		result := convertBrandToQuickResult(brand)
+		results = append(results, result)
+
+		downloads, err := buildAssetDownloads(ctx, client, project, id, result)
+		if err != nil {
+			return fmt.Errorf("%s: %w", id, err)
+		}
+
+		targetDir := project.Path(filepath.Join(project.Output, syncBrandDir(result, id)))
+		for _, d := range downloads {
+			destPath := filepath.Join(targetDir, d.filename)
+			writePath := stage.path(destPath)
+			if err := os.MkdirAll(filepath.Dir(writePath), 0o755); err != nil {
+				return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(writePath), err)
+			}
+			updated, err := downloadFile(httpClient, d.url, writePath)
+			if err != nil {
+				return fmt.Errorf("failed to download %s: %w", destPath, err)
+			}
+			if updated {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Downloaded: %s\n", destPath)
+			} else {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Unchanged: %s\n", destPath)
+			}
+			entry, err := stage.checksumEntry(destPath)
+			if err != nil {
+				return err
+			}
+			entries = append(entries, entry)
+		}
+	}
+
+	exports := []struct {
+		path    string
+		content string
+	}{
+		{project.Exports.CSS, output.FormatQuickCSSBatch(results)},
+		{project.Exports.Tailwind, output.FormatQuickTailwindBatch(results)},
+		{project.Exports.Tokens, output.FormatQuickTokensBatch(results)},
+	}
+	var written []string
+	for _, export := range exports {
+		if export.path == "" {
+			continue
+		}
+		path := project.Path(export.path)
+		if err := writeBuildFile(stage.path(path), export.content+"\n"); err != nil {
+			return err
+		}
+		written = append(written, path)
+		entry, err := stage.checksumEntry(path)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry)
+	}
+
+	if buildLocked {
+		if err := checkBuildLock(lockPath, entries); err != nil {
+			return err
+		}
+		if err := stage.commit(); err != nil {
+			return err
+		}
+	} else if err := writeSHA256Manifest(lockPath, entries, false, buildSignKey); err != nil {
+		return fmt.Errorf("failed to write lock file: %w", err)
+	}
+	// Exports are only in place once a --locked build passed its check.
+	for _, path := range written {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Wrote: %s\n", path)
+	}
+
+	format, _, err := resolveOutput(cmd)
+	if err != nil {
+		return err
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Path < entries[j].Path })
+	if format == output.FormatJSON {
+		files := make([]map[string]string, 0, len(entries))
+		for _, e := range entries {
+			files = append(files, map[string]string{"path": e.Path, "sha256": e.Sum})
+		}
+		return output.PrintJSON(cmd.OutOrStdout(), map[string]interface{}{
+			"brands": len(results),
+			"files":  files,
+			"lock":   lockPath,
+		})
+	}
+
+	renderBuildSummary(cmd.OutOrStdout(), len(results), entries, lockPath)
+	return nil
+}
+
+// buildAssetDownloads resolves the assets to download for a brand.
+// Without explicit logo variants this matches what `quick --download` fetches.
+func buildAssetDownloads(ctx context.Context, client APIClient, project *config.Project, id string, result *output.QuickResult) ([]assetDownload, error) {
+	if len(project.Logos) == 0 {
+		return quickAssetDownloads(result), nil
+	}
+
+	var downloads []assetDownload
+	for _, variant := range project.Logos {
+		logo, err := client.GetLogo(ctx, api.LogoOptions{
+			Identifier: id,
+			Type:       variant.Type,
+			Theme:      variant.Theme,
+			Format:     variant.Format,
+			Width:      variant.Width,
+			Height:     variant.Height,
+		})
+		if err != nil {
+			return nil, err
+		}
+		downloads = append(downloads, assetDownload{logo.URL, variant.FileName()})
+	}
+	return downloads, nil
+}
+
+// buildStage redirects build output into a staging directory when dir is
+// set, and moves the staged files onto their targets in commit.
+type buildStage struct {
+	projectDir string
+	dir        string
+	staged     map[string]string // target path -> staged path
+	order      []string
+}
+
+// path returns where the file for target is written.
+func (s *buildStage) path(target string) string {
+	if s.dir == "" {
+		return target
+	}
+	if staged, ok := s.staged[target]; ok {
+		return staged
+	}
+	if s.staged == nil {
+		s.staged = make(map[string]string)
+	}
+	staged := filepath.Join(s.dir, strconv.Itoa(len(s.order)), filepath.Base(target))
+	s.staged[target] = staged
+	s.order = append(s.order, target)
+	return staged
+}
+
+// checksumEntry hashes the file written for target, recorded under target's
+// project-relative path.
+func (s *buildStage) checksumEntry(target string) (checksumEntry, error) {
+	entry, err := buildChecksumEntry(s.path(target), "")
+	if err != nil {
+		return checksumEntry{}, err
+	}
+	if rel, err := filepath.Rel(s.projectDir, target); err == nil && rel != "." {
+		entry.Path = rel
+	}
+	return entry, nil
+}
+
+// commit moves staged files, and the ETags they were downloaded with, onto
+// their targets.
+func (s *buildStage) commit() error {
+	for _, target := range s.order {
+		staged := s.staged[target]
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(target), err)
+		}
+		if err := os.Rename(staged, target); err != nil {
+			return fmt.Errorf("failed to write %s: %w", target, err)
+		}
+		stagedETag, targetETag := newDownloadPaths(staged).etag, newDownloadPaths(target).etag
+		if err := os.Rename(stagedETag, targetETag); os.IsNotExist(err) {
+			_ = os.Remove(targetETag)
+		}
+	}
+	return nil
+}
+
+func writeBuildFile(path, content string) error {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", dir, err)
+		}
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// checkBuildLock compares built files against an existing lock file.
+func checkBuildLock(lockPath string, entries []checksumEntry) error {
+	locked, err := parseSHA256Manifest(lockPath)
+	if err != nil {
+		return err
+	}
+
+	built := make(map[string]string, len(entries))
+	for _, e := range entries {
+		built[e.Path] = e.Sum
+	}
+
+	var problems []string
+	for path, sum := range built {
+		expected, ok := locked[path]
+		switch {
+		case !ok:
+			problems = append(problems, "not in lock: "+path)
+		case !strings.EqualFold(expected, sum):
+			problems = append(problems, "changed: "+path)
+		}
+	}
+	for path := range locked {
+		if _, ok := built[path]; !ok {
+			problems = append(problems, "no longer built: "+path)
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("build does not match %s:\n  %s", lockPath, strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+func renderBuildSummary(w io.Writer, brands int, entries []checksumEntry, lockPath string) {
+	for _, e := range entries {
+		fmt.Fprintf(w, "%s  %s\n", e.Sum, e.Path)
+	}
+	fmt.Fprintf(w, "\nBuilt %d brand(s), %d file(s). Lock: %s\n", brands, len(entries), lockPath)
+}
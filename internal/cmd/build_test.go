@@ -0,0 +1,221 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/brandfetch-cli/internal/api"
+)
+
+func resetBuildFlags() {
+	buildFile = "brandfetch.yaml"
+	buildLocked = false
+	buildSignKey = ""
+	buildVerifyKey = ""
+}
+
+func writeTestProject(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "brandfetch.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write project: %v", err)
+	}
+	return path
+}
+
+func buildTestClients() (*MockAPIClient, *MockHTTPClient) {
+	mock := &MockAPIClient{
+		GetBrandFunc: func(ctx context.Context, domain string) (*api.Brand, error) {
+			return &api.Brand{
+				Name:   "Stripe",
+				Domain: domain,
+				Logos: []api.Logo{{
+					Type:    "logo",
+					Theme:   "light",
+					Formats: []api.LogoFormat{{Src: "https://asset.brandfetch.io/" + domain + "/logo-light.svg", Format: "svg"}},
+				}},
+				Colors: []api.Color{{Hex: "#635BFF", Type: "accent"}},
+				Fonts:  []api.Font{{Name: "Sohne", Type: "title"}},
+			}, nil
+		},
+		GetLogoFunc: func(ctx context.Context, opts api.LogoOptions) (*api.LogoResult, error) {
+			return &api.LogoResult{URL: "https://cdn.brandfetch.io/" + opts.Identifier + "/type/" + opts.Type + "." + opts.Format}, nil
+		},
+	}
+	mockHTTP := &MockHTTPClient{
+		GetFunc: func(url string) (*http.Response, error) {
+			return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader("asset:" + url))}, nil
+		},
+	}
+	return mock, mockHTTP
+}
+
+func TestBuildCmd_WritesAssetsExportsAndLock(t *testing.T) {
+	resetBuildFlags()
+	defer resetBuildFlags()
+	outputFormat = "text"
+
+	path := writeTestProject(t, `brands:
+  - stripe.com
+exports:
+  css: styles/brands.css
+  tokens: tokens.json
+`)
+	dir := filepath.Dir(path)
+	mock, mockHTTP := buildTestClients()
+
+	var stdout, stderr bytes.Buffer
+	cmd := newBuildCmdWithClients(mock, mockHTTP)
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetArgs([]string{"--file", path})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	for _, rel := range []string{"brand-assets/stripe/logo-light.svg", "styles/brands.css", "tokens.json"} {
+		if _, err := os.Stat(filepath.Join(dir, rel)); err != nil {
+			t.Errorf("expected %s to exist: %v", rel, err)
+		}
+	}
+
+	lock, err := parseSHA256Manifest(filepath.Join(dir, "brandfetch.lock"))
+	if err != nil {
+		t.Fatalf("failed to read lock: %v", err)
+	}
+	if len(lock) != 3 {
+		t.Errorf("lock entries = %d, want 3: %v", len(lock), lock)
+	}
+	if _, ok := lock["brand-assets/stripe/logo-light.svg"]; !ok {
+		t.Errorf("lock missing logo entry: %v", lock)
+	}
+	css, _ := os.ReadFile(filepath.Join(dir, "styles/brands.css"))
+	if !containsStr(string(css), "--color-accent: #635BFF") {
+		t.Errorf("css export missing color: %s", string(css))
+	}
+	if !containsStr(stderr.String(), "Wrote: "+filepath.Join(dir, "styles/brands.css")) {
+		t.Errorf("stderr missing written export: %s", stderr.String())
+	}
+}
+
+func TestBuildCmd_LogoVariants(t *testing.T) {
+	resetBuildFlags()
+	defer resetBuildFlags()
+	outputFormat = "text"
+
+	path := writeTestProject(t, `brands: [stripe.com]
+logos:
+  - type: icon
+    theme: dark
+    format: png
+    width: 256
+    height: 256
+`)
+	mock, mockHTTP := buildTestClients()
+
+	cmd := newBuildCmdWithClients(mock, mockHTTP)
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--file", path})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	asset := filepath.Join(filepath.Dir(path), "brand-assets", "stripe", "icon-dark-256x256.png")
+	data, err := os.ReadFile(asset)
+	if err != nil {
+		t.Fatalf("expected variant %s: %v", asset, err)
+	}
+	if !containsStr(string(data), "type/icon.png") {
+		t.Errorf("variant downloaded from unexpected URL: %s", string(data))
+	}
+}
+
+func TestBuildCmd_LockedFailureReportsNoWrites(t *testing.T) {
+	resetBuildFlags()
+	defer resetBuildFlags()
+	outputFormat = "text"
+
+	path := writeTestProject(t, "brands: [stripe.com]\nexports:\n  css: brands.css\n")
+	mock, mockHTTP := buildTestClients()
+
+	run := func(args ...string) (string, error) {
+		var stderr bytes.Buffer
+		cmd := newBuildCmdWithClients(mock, mockHTTP)
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&stderr)
+		cmd.SetArgs(append([]string{"--file", path}, args...))
+		err := cmd.Execute()
+		return stderr.String(), err
+	}
+
+	if _, err := run(); err != nil {
+		t.Fatalf("initial build error = %v", err)
+	}
+	mockHTTP.GetFunc = func(url string) (*http.Response, error) {
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader("rebranded"))}, nil
+	}
+	stderr, err := run("--locked")
+	if err == nil {
+		t.Fatal("locked build with a changed logo succeeded")
+	}
+	if containsStr(stderr, "Wrote:") {
+		t.Errorf("failed --locked build reported writes:\n%s", stderr)
+	}
+}
+
+func TestBuildCmd_Locked(t *testing.T) {
+	resetBuildFlags()
+	defer resetBuildFlags()
+	outputFormat = "text"
+
+	path := writeTestProject(t, "brands: [stripe.com]\n")
+	mock, mockHTTP := buildTestClients()
+
+	run := func(args ...string) error {
+		cmd := newBuildCmdWithClients(mock, mockHTTP)
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs(append([]string{"--file", path}, args...))
+		return cmd.Execute()
+	}
+
+	if err := run(); err != nil {
+		t.Fatalf("initial build error = %v", err)
+	}
+	logo := filepath.Join(filepath.Dir(path), "brand-assets", "stripe", "logo-light.svg")
+	if err := os.Remove(logo); err != nil {
+		t.Fatal(err)
+	}
+	if err := run("--locked"); err != nil {
+		t.Fatalf("locked build error = %v", err)
+	}
+	if _, err := os.Stat(logo); err != nil {
+		t.Errorf("matching --locked build did not write %s: %v", logo, err)
+	}
+
+	mockHTTP.GetFunc = func(url string) (*http.Response, error) {
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader("rebranded"))}, nil
+	}
+	before, err := os.ReadFile(logo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = run("--locked")
+	if err == nil || !containsStr(err.Error(), "changed: brand-assets/stripe/logo-light.svg") {
+		t.Errorf("locked build error = %v, want changed logo", err)
+	}
+	// A failed check leaves the tree as it was.
+	if after, _ := os.ReadFile(logo); string(after) != string(before) {
+		t.Errorf("failed --locked build overwrote %s with %q", logo, after)
+	}
+	if staging, _ := filepath.Glob(filepath.Join(filepath.Dir(path), ".brandfetch-build-*")); len(staging) != 0 {
+		t.Errorf("staging directories left behind: %v", staging)
+	}
+}
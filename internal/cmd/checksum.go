@@ -2,23 +2,92 @@ package cmd
 
 import (
 	"crypto/sha256"
+	"crypto/sha512"
 	"encoding/hex"
 	"fmt"
+	"hash"
 	"io"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
+
+	"golang.org/x/crypto/blake2b"
+	"lukechampine.com/blake3"
 )
 
-func computeSHA256(path string) (string, error) {
+// Supported checksum algorithms.
+const (
+	algoSHA256  = "sha256"
+	algoSHA512  = "sha512"
+	algoBLAKE2b = "blake2b" // BLAKE2b-512, as produced by b2sum
+	algoBLAKE3  = "blake3"  // 256-bit output, as produced by b3sum
+)
+
+// parseChecksumAlgorithm normalizes an algorithm name.
+func parseChecksumAlgorithm(name string) (string, error) {
+	switch strings.ToLower(strings.ReplaceAll(strings.TrimSpace(name), "-", "")) {
+	case "sha256":
+		return algoSHA256, nil
+	case "sha512":
+		return algoSHA512, nil
+	case "blake2b", "blake2", "b2":
+		return algoBLAKE2b, nil
+	case "blake3", "b3":
+		return algoBLAKE3, nil
+	default:
+		return "", fmt.Errorf("invalid checksum algorithm: %s (valid: sha256, sha512, blake2b, blake3)", name)
+	}
+}
+
+// checksumAlgorithmForManifest infers the algorithm from a manifest's extension,
+// falling back to the digest length of its entries.
+func checksumAlgorithmForManifest(path string, manifest map[string]string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".sha512":
+		return algoSHA512
+	case ".b2", ".blake2b":
+		return algoBLAKE2b
+	case ".b3", ".blake3":
+		return algoBLAKE3
+	case ".sha256":
+		return algoSHA256
+	}
+	for _, sum := range manifest {
+		if len(sum) == sha512.Size*2 {
+			return algoSHA512
+		}
+	}
+	return algoSHA256
+}
+
+func newChecksumHasher(algo string) (hash.Hash, error) {
+	switch algo {
+	case algoSHA256:
+		return sha256.New(), nil
+	case algoSHA512:
+		return sha512.New(), nil
+	case algoBLAKE2b:
+		return blake2b.New512(nil)
+	case algoBLAKE3:
+		return blake3.New(32, nil), nil
+	default:
+		return nil, fmt.Errorf("unsupported checksum algorithm: %s", algo)
+	}
+}
+
+func computeChecksum(path, algo string) (string, error) {
+	hasher, err := newChecksumHasher(algo)
+	if err != nil {
+		return "", err
+	}
+
 	file, err := os.Open(path)
 	if err != nil {
 		return "", fmt.Errorf("failed to open file for checksum: %w", err)
 	}
 	defer file.Close()
 
-	hasher := sha256.New()
 	if _, err := io.Copy(hasher, file); err != nil {
 		return "", fmt.Errorf("failed to read file for checksum: %w", err)
 	}
@@ -26,6 +95,10 @@ func computeSHA256(path string) (string, error) {
 	return hex.EncodeToString(hasher.Sum(nil)), nil
 }
 
+func computeSHA256(path string) (string, error) {
+	return computeChecksum(path, algoSHA256)
+}
+
 func verifySHA256(path, expected string) (bool, error) {
 	sum, err := computeSHA256(path)
 	if err != nil {
@@ -40,6 +113,8 @@ type checksumEntry struct {
 	Sum  string
 }
 
+// parseSHA256Manifest reads a "<hex digest>  <path>" manifest.
+// The format is shared by every supported algorithm.
 func parseSHA256Manifest(path string) (map[string]string, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -112,7 +187,9 @@ func buildChecksumEntry(path, root string) (checksumEntry, error) {
 	return checksumEntry{Path: entryPath, Sum: sum}, nil
 }
 
-func writeSHA256Manifest(path string, entries []checksumEntry, appendExisting bool) error {
+// writeSHA256Manifest writes entries to a manifest, optionally merging an existing one.
+// When signKey is set, a detached ed25519 signature is written next to it.
+func writeSHA256Manifest(path string, entries []checksumEntry, appendExisting bool, signKey string) error {
 	if len(entries) == 0 {
 		return fmt.Errorf("no downloaded files to write")
 	}
@@ -144,5 +221,15 @@ func writeSHA256Manifest(path string, entries []checksumEntry, appendExisting bo
 		sb.WriteString(fmt.Sprintf("%s  %s\n", merged[name], name))
 	}
 
-	return os.WriteFile(path, []byte(sb.String()), 0o644)
+	if err := os.WriteFile(path, []byte(sb.String()), 0o644); err != nil {
+		return err
+	}
+	if signKey != "" {
+		return signManifest(path, signKey)
+	}
+	// A signature left from an earlier signed write no longer matches.
+	if err := os.Remove(manifestSignaturePath(path)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
 }
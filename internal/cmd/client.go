@@ -3,9 +3,15 @@ package cmd
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
 
 	"github.com/salmonumbrella/brandfetch-cli/internal/api"
+	"github.com/salmonumbrella/brandfetch-cli/internal/cassette"
 	"github.com/salmonumbrella/brandfetch-cli/internal/config"
 	"github.com/salmonumbrella/brandfetch-cli/internal/secrets"
 )
@@ -13,6 +19,8 @@ import (
 // APIClient interface for dependency injection in tests.
 type APIClient interface {
 	GetLogo(ctx context.Context, opts api.LogoOptions) (*api.LogoResult, error)
+	ResolveLogo(ctx context.Context, opts api.LogoOptions) (*api.ResolvedLogo, error)
+	ResolvePreferredLogo(ctx context.Context, opts api.LogoOptions, variants []api.LogoVariant) (*api.ResolvedLogo, api.LogoVariant, error)
 	GetBrand(ctx context.Context, identifier string) (*api.Brand, error)
 	Search(ctx context.Context, query string, limit int) ([]api.SearchResult, error)
 	CreateTransaction(ctx context.Context, label, countryCode string) (*api.Brand, error)
@@ -28,19 +36,132 @@ type clientRequirements struct {
 func createClient(req clientRequirements) (*api.Client, error) {
 	// Try to get credentials
 	var keychain config.KeychainGetter
-	store, err := secrets.NewStore()
+	store, err := openSecretsStore()
 	if err == nil {
 		keychain = store
 	}
 
+	profile, err := currentProfile()
+	if err != nil {
+		return nil, err
+	}
+
 	configPath, _ := config.ConfigFilePath()
 	creds, err := config.LoadCredentialsWithOptions(keychain, configPath, config.Requirements{
 		RequireClientID: req.requireClientID,
 		RequireAPIKey:   req.requireAPIKey,
+		Profile:         profile,
 	})
 	if err != nil {
 		return nil, err
 	}
+	if store != nil {
+		if rotations, err := loadRotations(); err == nil {
+			purgeExpiredPreviousKeys(secrets.NewProfileStore(store, profile), rotations, time.Now())
+		}
+	}
+
+	opts, err := apiClientOptions()
+	if err != nil {
+		return nil, err
+	}
+	return api.NewClientWithOptions(creds.ClientID, creds.APIKey, opts), nil
+}
+
+// apiClientOptions builds client options from the endpoint, timeout, proxy
+// and TLS flags.
+func apiClientOptions() (api.ClientOptions, error) {
+	if v := os.Getenv("BRANDFETCH_TIMEOUT"); v != "" {
+		if _, err := time.ParseDuration(v); err != nil {
+			return api.ClientOptions{}, fmt.Errorf("invalid BRANDFETCH_TIMEOUT %q: %w", v, err)
+		}
+	}
+	if requestTimeout < 0 {
+		return api.ClientOptions{}, fmt.Errorf("--timeout must not be negative")
+	}
+	transport, err := api.NewTransport(api.TransportOptions{
+		Proxy:          proxyURL,
+		CACertFile:     caCertFile,
+		ClientCertFile: clientCertFile,
+		ClientKeyFile:  clientKeyFile,
+	})
+	if err != nil {
+		return api.ClientOptions{}, err
+	}
+	rt, err := cassetteTransport(transport)
+	if err != nil {
+		return api.ClientOptions{}, err
+	}
+	// --timeout 0 disables the timeout rather than falling back to the default.
+	timeout := requestTimeout
+	if timeout == 0 {
+		timeout = api.NoTimeout
+	}
+	return api.ClientOptions{
+		BaseURL:     apiURL,
+		LogoBaseURL: cdnURL,
+		GraphQLURL:  graphQLURL,
+		Timeout:     timeout,
+		Transport:   rt,
+	}, nil
+}
+
+// cassettes holds one recorder or player per directory, so the API and
+// download clients share sequence numbers and replay state.
+var cassettes = struct {
+	sync.Mutex
+	transports map[string]http.RoundTripper
+}{transports: make(map[string]http.RoundTripper)}
+
+// cassetteTransport wraps next with the --record or --replay cassette, if any.
+func cassetteTransport(next http.RoundTripper) (http.RoundTripper, error) {
+	if recordDir != "" && replayDir != "" {
+		return nil, fmt.Errorf("--record and --replay are mutually exclusive")
+	}
+	if recordDir == "" && replayDir == "" {
+		return next, nil
+	}
+
+	key := "record:" + recordDir
+	if replayDir != "" {
+		key = "replay:" + replayDir
+	}
+	cassettes.Lock()
+	defer cassettes.Unlock()
+	if rt, ok := cassettes.transports[key]; ok {
+		return rt, nil
+	}
+
+	var (
+		rt  http.RoundTripper
+		err error
+	)
+	if replayDir != "" {
+		rt, err = cassette.NewPlayer(replayDir)
+	} else {
+		rt, err = cassette.NewRecorder(recordDir, next)
+	}
+	if err != nil {
+		return nil, err
+	}
+	cassettes.transports[key] = rt
+	return rt, nil
+}
+
+// newDownloadClient returns the HTTP client for CDN downloads, which shares the
+// API client's timeout, proxy and TLS settings.
+func newDownloadClient() (*http.Client, error) {
+	opts, err := apiClientOptions()
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{Timeout: max(opts.Timeout, 0), Transport: opts.Transport}, nil
+}
+
+// errorTransport fails every request, surfacing a configuration error where
+// the caller cannot return one.
+type errorTransport struct{ err error }
 
-	return api.NewClient(creds.ClientID, creds.APIKey), nil
+func (t errorTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, t.err
 }
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/salmonumbrella/brandfetch-cli/internal/api"
+	"github.com/salmonumbrella/brandfetch-cli/internal/mockserver"
+	"github.com/salmonumbrella/brandfetch-cli/pkg/brandfetch/brandfetchtest"
+)
+
+// setNetworkFlags sets the endpoint and transport flag vars for one test.
+func setNetworkFlags(t *testing.T, base, timeout, proxy string) {
+	t.Helper()
+	apiURL, proxyURL = base, proxy
+	requestTimeout = 0
+	if timeout != "" {
+		d, err := time.ParseDuration(timeout)
+		if err != nil {
+			t.Fatal(err)
+		}
+		requestTimeout = d
+	}
+	t.Cleanup(func() {
+		apiURL, proxyURL, requestTimeout = "", "", 0
+	})
+}
+
+func TestCreateClientUsesAPIURL(t *testing.T) {
+	isolateConfigDir(t)
+	t.Setenv("BRANDFETCH_CLIENT_ID", "cid")
+	t.Setenv("BRANDFETCH_API_KEY", "key")
+
+	var gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+	setNetworkFlags(t, server.URL, "5s", "")
+
+	client, err := createClient(clientRequirements{requireClientID: true})
+	if err != nil {
+		t.Fatalf("createClient() error = %v", err)
+	}
+	if _, err := client.Search(context.Background(), "github", 0); err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if gotPath != "/v2/search/github" {
+		t.Errorf("request path = %q, want the --api-url server", gotPath)
+	}
+}
+
+func TestAPIClientOptionsErrors(t *testing.T) {
+	setNetworkFlags(t, "", "", "://bad")
+	if _, err := apiClientOptions(); err == nil {
+		t.Error("expected error for an invalid --proxy")
+	}
+
+	setNetworkFlags(t, "", "-1s", "")
+	if _, err := apiClientOptions(); err == nil {
+		t.Error("expected error for a negative --timeout")
+	}
+
+	setNetworkFlags(t, "", "", "")
+	t.Setenv("BRANDFETCH_TIMEOUT", "soon")
+	if _, err := apiClientOptions(); err == nil || !containsStr(err.Error(), "BRANDFETCH_TIMEOUT") {
+		t.Errorf("expected error naming BRANDFETCH_TIMEOUT, got %v", err)
+	}
+}
+
+func TestTimeoutAppliesToDownloads(t *testing.T) {
+	setNetworkFlags(t, "", "5s", "")
+	hc, err := newDownloadClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hc.Timeout != 5*time.Second {
+		t.Errorf("download Timeout = %v, want 5s", hc.Timeout)
+	}
+
+	// --timeout 0 disables the timeout instead of using the default.
+	setNetworkFlags(t, "", "0s", "")
+	opts, err := apiClientOptions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.Timeout != api.NoTimeout {
+		t.Errorf("API Timeout = %v, want api.NoTimeout", opts.Timeout)
+	}
+	if hc, err = newDownloadClient(); err != nil {
+		t.Fatal(err)
+	}
+	if hc.Timeout != 0 {
+		t.Errorf("download Timeout = %v, want none", hc.Timeout)
+	}
+}
+
+func TestCreateClientRecordReplay(t *testing.T) {
+	isolateConfigDir(t)
+	t.Setenv("BRANDFETCH_CLIENT_ID", "cid")
+	t.Setenv("BRANDFETCH_API_KEY", "key")
+	t.Cleanup(func() { recordDir, replayDir = "", "" })
+
+	server := brandfetchtest.NewServer(t, mockserver.Options{})
+	setNetworkFlags(t, server.URL, "", "")
+	dir := t.TempDir()
+
+	recordDir = dir
+	client, err := createClient(clientRequirements{requireAPIKey: true})
+	if err != nil {
+		t.Fatalf("createClient() error = %v", err)
+	}
+	if _, err := client.GetBrand(context.Background(), "github.com"); err != nil {
+		t.Fatalf("recorded GetBrand() error = %v", err)
+	}
+	server.Close()
+
+	recordDir, replayDir = "", dir
+	client, err = createClient(clientRequirements{requireAPIKey: true})
+	if err != nil {
+		t.Fatalf("createClient() error = %v", err)
+	}
+	brand, err := client.GetBrand(context.Background(), "github.com")
+	if err != nil || brand.Name != "GitHub" {
+		t.Errorf("replayed GetBrand() = %v, %v", brand, err)
+	}
+
+	recordDir = dir
+	if _, err := apiClientOptions(); err == nil {
+		t.Error("expected error when --record and --replay are combined")
+	}
+}
@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/brandfetch-cli/internal/config"
+	"github.com/salmonumbrella/brandfetch-cli/internal/output"
+)
+
+const settingsFileHeader = `# brandfetch settings
+# Top-level keys set global flags; nested keys follow the command path.
+# Precedence: command-line flag > environment variable > this file > built-in default.
+#
+# output: json
+# logo:
+#   format: png
+#   theme: dark
+`
+
+// NewConfigCmd creates the config command group.
+func NewConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage default settings",
+		Long: `Manage default flag values in settings.yaml under the config directory.
+
+Keys are flag names. Global flags use the bare name; per-command defaults are
+prefixed with the command path and also apply to subcommands:
+
+  output                 --output for every command
+  logo.format            --format for logo and logo download
+  logo.download.dir      --dir for logo download only
+  quick.max-size         --max-size for quick
+
+Precedence: command-line flag > environment variable > settings > built-in default.
+
+Examples:
+  brandfetch config set output json
+  brandfetch config set logo.format png
+  brandfetch config get logo.format
+  brandfetch config unset logo.format
+  brandfetch config list
+  brandfetch config edit`,
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "get <key>",
+		Short: "Print a setting",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigGetCmd(cmd, args[0])
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Set a default flag value",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigSetCmd(cmd, args[0], args[1])
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "unset <key>",
+		Short: "Remove a setting",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigUnsetCmd(cmd, args[0])
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "List all settings",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigListCmd(cmd)
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "edit",
+		Short: "Open settings.yaml in $VISUAL or $EDITOR",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigEditCmd(cmd)
+		},
+	})
+
+	return cmd
+}
+
+func loadSettings() (*config.Settings, error) {
+	path, err := config.SettingsFilePath()
+	if err != nil {
+		return nil, err
+	}
+	return config.LoadSettings(path)
+}
+
+func runConfigGetCmd(cmd *cobra.Command, key string) error {
+	settings, err := loadSettings()
+	if err != nil {
+		return err
+	}
+	value, ok := settings.Get(key)
+	if !ok {
+		return fmt.Errorf("%s is not set", key)
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), value)
+	return nil
+}
+
+func runConfigSetCmd(cmd *cobra.Command, key, value string) error {
+	f, err := resolveSettingKey(cmd.Root(), key)
+	if err != nil {
+		return err
+	}
+	if err := validateSettingValue(f, value); err != nil {
+		return err
+	}
+
+	settings, err := loadSettings()
+	if err != nil {
+		return err
+	}
+	if err := settings.Set(key, value); err != nil {
+		return err
+	}
+	if err := settings.Save(); err != nil {
+		return fmt.Errorf("failed to save settings: %w", err)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "%s = %s\n", key, value)
+	return nil
+}
+
+func runConfigUnsetCmd(cmd *cobra.Command, key string) error {
+	settings, err := loadSettings()
+	if err != nil {
+		return err
+	}
+	if !settings.Unset(key) {
+		return fmt.Errorf("%s is not set", key)
+	}
+	if err := settings.Save(); err != nil {
+		return fmt.Errorf("failed to save settings: %w", err)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Unset %s\n", key)
+	return nil
+}
+
+func runConfigListCmd(cmd *cobra.Command) error {
+	settings, err := loadSettings()
+	if err != nil {
+		return err
+	}
+
+	format, _, err := resolveOutput(cmd)
+	if err != nil {
+		return err
+	}
+	if format == output.FormatJSON {
+		values := make(map[string]string)
+		for _, key := range settings.Keys() {
+			values[key], _ = settings.Get(key)
+		}
+		return output.PrintJSON(cmd.OutOrStdout(), values)
+	}
+
+	for _, key := range settings.Keys() {
+		value, _ := settings.Get(key)
+		fmt.Fprintf(cmd.OutOrStdout(), "%s = %s\n", key, value)
+	}
+	return nil
+}
+
+func runConfigEditCmd(cmd *cobra.Command) error {
+	path, err := config.SettingsFilePath()
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		if err := config.EnsureDir(filepath.Dir(path)); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, []byte(settingsFileHeader), 0o600); err != nil {
+			return fmt.Errorf("failed to create %s: %w", path, err)
+		}
+	}
+
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+		if runtime.GOOS == "windows" {
+			editor = "notepad"
+		}
+	}
+	parts := strings.Fields(editor)
+	c := exec.Command(parts[0], append(parts[1:], path)...)
+	c.Stdin = cmd.InOrStdin()
+	c.Stdout = cmd.OutOrStdout()
+	c.Stderr = cmd.ErrOrStderr()
+	if err := c.Run(); err != nil {
+		return fmt.Errorf("editor %q failed: %w", editor, err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if _, err := config.ParseSettings(data); err != nil {
+		return fmt.Errorf("%s is not valid YAML, run 'brandfetch config edit' again to fix it: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// newSettingsTestRoot builds a root command with a probe subcommand that
+// records flag values after settings are applied.
+func newSettingsTestRoot(got *map[string]string) *cobra.Command {
+	root := NewRootCmd()
+	var format, dir string
+	var width int
+	probe := &cobra.Command{
+		Use: "logo",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			*got = map[string]string{"output": outputFormat, "format": format}
+			return nil
+		},
+	}
+	probe.Flags().StringVar(&format, "format", "svg", "")
+	probe.Flags().IntVar(&width, "width", 0, "")
+	download := &cobra.Command{
+		Use: "download",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			*got = map[string]string{"format": format, "dir": dir}
+			return nil
+		},
+	}
+	download.Flags().StringVar(&format, "format", "svg", "")
+	download.Flags().StringVar(&dir, "dir", "", "")
+	probe.AddCommand(download)
+	root.AddCommand(probe, NewConfigCmd())
+	return root
+}
+
+func runSettingsTestRoot(t *testing.T, root *cobra.Command, args ...string) (string, error) {
+	t.Helper()
+	var stdout bytes.Buffer
+	root.SetOut(&stdout)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs(args)
+	err := root.Execute()
+	return stdout.String(), err
+}
+
+func TestConfigCmd_SetGetListUnset(t *testing.T) {
+	isolateConfigDir(t)
+	defer func() { outputFormat = "text" }()
+	var got map[string]string
+
+	for _, args := range [][]string{
+		{"config", "set", "logo.format", "png"},
+		{"config", "set", "logo.download.dir", "./assets"},
+		{"config", "set", "color", "never"},
+	} {
+		if _, err := runSettingsTestRoot(t, newSettingsTestRoot(&got), args...); err != nil {
+			t.Fatalf("%v error = %v", args, err)
+		}
+	}
+
+	out, err := runSettingsTestRoot(t, newSettingsTestRoot(&got), "config", "get", "logo.format")
+	if err != nil || out != "png\n" {
+		t.Errorf("config get = %q, %v; want png", out, err)
+	}
+	out, err = runSettingsTestRoot(t, newSettingsTestRoot(&got), "config", "list")
+	if err != nil || !containsStr(out, "color = never\nlogo.download.dir = ./assets\nlogo.format = png\n") {
+		t.Errorf("config list = %q, %v", out, err)
+	}
+
+	for _, args := range [][]string{
+		{"config", "set", "nope", "x"},
+		{"config", "set", "missing.format", "x"},
+		{"config", "set", "logo.nope", "x"},
+		{"config", "set", "logo.width", "wide"},
+		{"config", "unset", "never.set"},
+	} {
+		if _, err := runSettingsTestRoot(t, newSettingsTestRoot(&got), args...); err == nil {
+			t.Errorf("%v: expected error", args)
+		}
+	}
+
+	if _, err := runSettingsTestRoot(t, newSettingsTestRoot(&got), "config", "unset", "logo.format"); err != nil {
+		t.Fatalf("unset error = %v", err)
+	}
+	if _, err := runSettingsTestRoot(t, newSettingsTestRoot(&got), "config", "get", "logo.format"); err == nil {
+		t.Error("expected error getting an unset key")
+	}
+}
+
+func TestApplySettings_Precedence(t *testing.T) {
+	isolateConfigDir(t)
+	defer func() { outputFormat = "text" }()
+	path := filepath.Join(os.Getenv("XDG_CONFIG_HOME"), "brandfetch", "settings.yaml")
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	content := "output: json\nlogo:\n  format: png\n  download:\n    dir: ./assets\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var got map[string]string
+	if _, err := runSettingsTestRoot(t, newSettingsTestRoot(&got), "logo"); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got["output"] != "json" || got["format"] != "png" {
+		t.Errorf("settings not applied: %v", got)
+	}
+
+	// Parent command settings apply to subcommands.
+	if _, err := runSettingsTestRoot(t, newSettingsTestRoot(&got), "logo", "download"); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got["format"] != "png" || got["dir"] != "./assets" {
+		t.Errorf("subcommand settings not applied: %v", got)
+	}
+
+	// Flags beat settings.
+	if _, err := runSettingsTestRoot(t, newSettingsTestRoot(&got), "logo", "--format", "webp", "--output", "text"); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got["output"] != "text" || got["format"] != "webp" {
+		t.Errorf("flags should override settings: %v", got)
+	}
+
+	// Environment variables beat settings.
+	t.Setenv("BRANDFETCH_OUTPUT", "text")
+	if _, err := runSettingsTestRoot(t, newSettingsTestRoot(&got), "logo"); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got["output"] != "text" {
+		t.Errorf("env should override settings: %v", got)
+	}
+
+	// A broken value is reported.
+	if err := os.WriteFile(path, []byte("logo:\n  width: wide\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runSettingsTestRoot(t, newSettingsTestRoot(&got), "logo"); err == nil {
+		t.Error("expected error for invalid setting value")
+	}
+}
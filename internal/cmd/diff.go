@@ -0,0 +1,233 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/brandfetch-cli/internal/api"
+	"github.com/salmonumbrella/brandfetch-cli/internal/branddiff"
+	"github.com/salmonumbrella/brandfetch-cli/internal/output"
+)
+
+// exitDiffChanged is the exit code for --exit-code when the brands differ.
+const exitDiffChanged = 2
+
+// Diff output formats.
+const (
+	diffFormatText      = "text"
+	diffFormatMarkdown  = "markdown"
+	diffFormatJSONPatch = "json-patch"
+)
+
+var (
+	diffAgainst  string
+	diffFormat   string
+	diffHash     bool
+	diffExitCode bool
+)
+
+// NewDiffCmd creates the diff command.
+func NewDiffCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff <identifier> --against <snapshot.json> | diff <old.json> <new.json>",
+		Short: "Compare brand snapshots",
+		Long: `Compare two brand snapshots field by field.
+
+With one identifier, the live brand is fetched and compared against a snapshot
+saved earlier with "brandfetch brand <identifier> --output json" (or --raw).
+With two files, the snapshots are compared without calling the API.
+
+Colors, fonts, logos, links, name, domain and descriptions are compared.
+Changed colors include their CIE76 distance (ΔE); below about 2.3 the change
+is hard to see. Logos are matched by type, theme and format and compared by
+URL, or with --hash by the SHA-256 of the downloaded file, so assets that only
+moved are not reported.
+
+Output formats (--format):
+  text        one line per change (default)
+  markdown    a table, for pull requests and chat
+  json-patch  an RFC 6902 patch from the old snapshot to the new one
+              (default with --output json)
+
+The live fetch uses the Brand API which has limited quota.
+
+Examples:
+  brandfetch brand stripe.com --output json > stripe.json
+  brandfetch diff stripe.com --against stripe.json
+  brandfetch diff old/stripe.json new/stripe.json --format markdown
+  brandfetch diff stripe.com --against stripe.json --hash --exit-code`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var client APIClient
+			if len(args) == 1 {
+				c, err := createClient(clientRequirements{requireAPIKey: true})
+				if err != nil {
+					return err
+				}
+				client = c
+			}
+			httpClient, err := newDownloadClient()
+			if err != nil {
+				return err
+			}
+			return runDiffCmd(cmd, args, client, httpClient)
+		},
+	}
+
+	addDiffFlags(cmd)
+
+	return cmd
+}
+
+func newDiffCmdWithClients(client APIClient, httpClient HTTPClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "diff",
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDiffCmd(cmd, args, client, httpClient)
+		},
+	}
+
+	addDiffFlags(cmd)
+
+	return cmd
+}
+
+func addDiffFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&diffAgainst, "against", "", "Snapshot to compare the live brand against")
+	cmd.Flags().StringVar(&diffFormat, "format", "", "Output format: text, markdown, json-patch")
+	cmd.Flags().BoolVar(&diffHash, "hash", false, "Download logos and compare their SHA-256 instead of their URLs")
+	cmd.Flags().BoolVar(&diffExitCode, "exit-code", false, fmt.Sprintf("Exit with status %d when the brands differ", exitDiffChanged))
+}
+
+func runDiffCmd(cmd *cobra.Command, args []string, client APIClient, httpClient HTTPClient) error {
+	format, err := diffOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	var oldBrand, newBrand *api.Brand
+	switch {
+	case len(args) == 2:
+		if diffAgainst != "" {
+			return fmt.Errorf("--against cannot be used when comparing two snapshots")
+		}
+		if oldBrand, err = loadBrandSnapshot(args[0]); err != nil {
+			return err
+		}
+		if newBrand, err = loadBrandSnapshot(args[1]); err != nil {
+			return err
+		}
+	case diffAgainst == "":
+		return fmt.Errorf("--against is required when comparing a live brand")
+	default:
+		if oldBrand, err = loadBrandSnapshot(diffAgainst); err != nil {
+			return err
+		}
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		if newBrand, err = client.GetBrand(ctx, args[0]); err != nil {
+			return err
+		}
+	}
+
+	var opts branddiff.Options
+	if diffHash {
+		opts.Hash = logoHasher(httpClient)
+	}
+	d := branddiff.Compare(oldBrand, newBrand, opts)
+
+	out := cmd.OutOrStdout()
+	switch format {
+	case diffFormatJSONPatch:
+		if err := output.PrintJSON(out, d.Patch()); err != nil {
+			return err
+		}
+	case diffFormatMarkdown:
+		fmt.Fprint(out, d.Markdown())
+	default:
+		fmt.Fprint(out, d.Text())
+	}
+
+	if diffExitCode && len(d.Changes) > 0 {
+		return &ExitError{Code: exitDiffChanged, Err: fmt.Errorf("brand changed: %d difference(s)", len(d.Changes))}
+	}
+	return nil
+}
+
+// diffOutputFormat resolves --format, defaulting to a JSON patch when
+// --output json is set.
+func diffOutputFormat(cmd *cobra.Command) (string, error) {
+	switch diffFormat {
+	case diffFormatText, diffFormatMarkdown, diffFormatJSONPatch:
+		return diffFormat, nil
+	case "":
+	default:
+		return "", fmt.Errorf("invalid format: %s (valid: text, markdown, json-patch)", diffFormat)
+	}
+	format, _, err := resolveOutput(cmd)
+	if err != nil {
+		return "", err
+	}
+	if format == output.FormatJSON {
+		return diffFormatJSONPatch, nil
+	}
+	return diffFormatText, nil
+}
+
+// loadBrandSnapshot reads a brand saved with brand --output json or --raw.
+func loadBrandSnapshot(path string) (*api.Brand, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	var brand api.Brand
+	if err := json.Unmarshal(data, &brand); err != nil {
+		return nil, fmt.Errorf("invalid snapshot %s: %w", path, err)
+	}
+	return &brand, nil
+}
+
+// logoHasher returns a branddiff hash function that downloads each URL once.
+func logoHasher(httpClient HTTPClient) func(string) (string, error) {
+	cache := make(map[string]string)
+	return func(url string) (string, error) {
+		if sum, ok := cache[url]; ok {
+			return sum, nil
+		}
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		if err != nil {
+			return "", err
+		}
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return "", fmt.Errorf("HTTP %d", resp.StatusCode)
+		}
+		// Hashing stops at the download size cap, like downloadFile.
+		h := sha256.New()
+		n, err := io.Copy(h, io.LimitReader(resp.Body, defaultDownloadMaxSize+1))
+		if err != nil {
+			return "", err
+		}
+		if n > defaultDownloadMaxSize {
+			return "", fmt.Errorf("logo exceeds %d bytes", defaultDownloadMaxSize)
+		}
+		sum := hex.EncodeToString(h.Sum(nil))
+		cache[url] = sum
+		return sum, nil
+	}
+}
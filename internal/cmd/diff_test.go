@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/brandfetch-cli/internal/api"
+)
+
+func resetDiffFlags() {
+	diffAgainst = ""
+	diffFormat = ""
+	diffHash = false
+	diffExitCode = false
+}
+
+const diffOldSnapshot = `{
+	"name": "Stripe",
+	"domain": "stripe.com",
+	"colors": [{"hex": "#635bff", "type": "accent"}],
+	"logos": [{"type": "logo", "theme": "light", "formats": [{"src": "https://asset/v1.svg", "format": "svg"}]}]
+}`
+
+const diffNewSnapshot = `{
+	"name": "Stripe",
+	"domain": "stripe.com",
+	"colors": [{"hex": "#533afd", "type": "accent"}],
+	"logos": [{"type": "logo", "theme": "light", "formats": [{"src": "https://asset/v2.svg", "format": "svg"}]}]
+}`
+
+func writeDiffSnapshots(t *testing.T) (string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	oldPath := filepath.Join(dir, "old.json")
+	newPath := filepath.Join(dir, "new.json")
+	if err := os.WriteFile(oldPath, []byte(diffOldSnapshot), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(newPath, []byte(diffNewSnapshot), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return oldPath, newPath
+}
+
+func runDiffTest(t *testing.T, client APIClient, httpClient HTTPClient, args ...string) (string, error) {
+	t.Helper()
+	var stdout bytes.Buffer
+	cmd := newDiffCmdWithClients(client, httpClient)
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return stdout.String(), err
+}
+
+func TestDiffCmd_TwoFiles(t *testing.T) {
+	resetDiffFlags()
+	outputFormat = "text"
+	oldPath, newPath := writeDiffSnapshots(t)
+
+	out, err := runDiffTest(t, &MockAPIClient{}, &MockHTTPClient{}, oldPath, newPath)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	for _, want := range []string{
+		"Stripe (stripe.com): 2 changes",
+		"~ colors[accent]  #635bff -> #533afd (ΔE ",
+		"~ logos[logo/light/svg]  https://asset/v1.svg -> https://asset/v2.svg",
+	} {
+		if !containsStr(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestDiffCmd_AgainstLiveBrand(t *testing.T) {
+	resetDiffFlags()
+	outputFormat = "text"
+	oldPath, _ := writeDiffSnapshots(t)
+
+	mock := &MockAPIClient{
+		GetBrandFunc: func(ctx context.Context, domain string) (*api.Brand, error) {
+			var brand api.Brand
+			if err := json.Unmarshal([]byte(diffNewSnapshot), &brand); err != nil {
+				return nil, err
+			}
+			return &brand, nil
+		},
+	}
+	out, err := runDiffTest(t, mock, &MockHTTPClient{}, "stripe.com", "--against", oldPath, "--format", "markdown")
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !containsStr(out, "| colors[accent] | changed | `#635bff` | `#533afd` (ΔE ") {
+		t.Errorf("output = %s", out)
+	}
+}
+
+func TestDiffCmd_RequiresAgainst(t *testing.T) {
+	resetDiffFlags()
+	_, err := runDiffTest(t, &MockAPIClient{}, &MockHTTPClient{}, "stripe.com")
+	if err == nil || !strings.Contains(err.Error(), "--against") {
+		t.Errorf("error = %v, want --against required", err)
+	}
+}
+
+func TestDiffCmd_JSONPatch(t *testing.T) {
+	resetDiffFlags()
+	outputFormat = "json"
+	defer func() { outputFormat = "text" }()
+	oldPath, newPath := writeDiffSnapshots(t)
+
+	out, err := runDiffTest(t, &MockAPIClient{}, &MockHTTPClient{}, oldPath, newPath)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	var ops []struct {
+		Op    string          `json:"op"`
+		Path  string          `json:"path"`
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal([]byte(out), &ops); err != nil {
+		t.Fatalf("output is not a JSON patch: %v\n%s", err, out)
+	}
+	if len(ops) != 2 || ops[0].Op != "replace" || ops[0].Path != "/colors/0" ||
+		ops[1].Path != "/logos/0/formats/0/src" || string(ops[1].Value) != `"https://asset/v2.svg"` {
+		t.Errorf("ops = %+v", ops)
+	}
+}
+
+func TestDiffCmd_HashAndExitCode(t *testing.T) {
+	resetDiffFlags()
+	outputFormat = "text"
+	oldPath, newPath := writeDiffSnapshots(t)
+
+	requests := 0
+	httpClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			requests++
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("<svg/>"))}, nil
+		},
+	}
+	out, err := runDiffTest(t, &MockAPIClient{}, httpClient, oldPath, newPath, "--hash", "--exit-code")
+	if requests != 2 {
+		t.Errorf("downloaded %d logos, want 2", requests)
+	}
+	if containsStr(out, "logos[") {
+		t.Errorf("logo with identical content reported:\n%s", out)
+	}
+	var exitErr *ExitError
+	if !errors.As(err, &exitErr) || exitErr.Code != exitDiffChanged {
+		t.Fatalf("error = %v, want exit code %d", err, exitDiffChanged)
+	}
+
+	resetDiffFlags()
+	if _, err := runDiffTest(t, &MockAPIClient{}, &MockHTTPClient{}, oldPath, oldPath, "--exit-code"); err != nil {
+		t.Errorf("identical snapshots: error = %v", err)
+	}
+}
+
+// endlessReader is a response body that never ends.
+type endlessReader struct{}
+
+func (endlessReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+	return len(p), nil
+}
+
+func TestLogoHasher_CapsBody(t *testing.T) {
+	hash := logoHasher(&MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(endlessReader{})}, nil
+		},
+	})
+	if _, err := hash("https://asset/huge.svg"); err == nil || !containsStr(err.Error(), "exceeds") {
+		t.Errorf("error = %v, want the size cap", err)
+	}
+}
@@ -0,0 +1,389 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// defaultDownloadMaxSize caps a single downloaded asset; brand images are far smaller.
+const defaultDownloadMaxSize = 50 << 20
+
+// downloadMaxSize is the --max-size flag shared by commands that download assets.
+var downloadMaxSize = byteSize(defaultDownloadMaxSize)
+
+func addDownloadFlags(cmd *cobra.Command) {
+	downloadMaxSize = byteSize(defaultDownloadMaxSize)
+	cmd.Flags().Var(&downloadMaxSize, "max-size", "Maximum size per downloaded file, e.g. 512KB or 10MB (0 for no limit)")
+}
+
+// byteSize is a flag value accepting sizes like 512KB, 10MB or 1GB (1024-based).
+type byteSize int64
+
+var byteSizeUnits = []struct {
+	suffix string
+	size   int64
+}{
+	{"GIB", 1 << 30}, {"GB", 1 << 30}, {"G", 1 << 30},
+	{"MIB", 1 << 20}, {"MB", 1 << 20}, {"M", 1 << 20},
+	{"KIB", 1 << 10}, {"KB", 1 << 10}, {"K", 1 << 10},
+	{"B", 1},
+}
+
+func (b *byteSize) String() string {
+	n := int64(*b)
+	for _, u := range []struct {
+		suffix string
+		size   int64
+	}{{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}} {
+		if n >= u.size && n%u.size == 0 {
+			return strconv.FormatInt(n/u.size, 10) + u.suffix
+		}
+	}
+	return strconv.FormatInt(n, 10)
+}
+
+func (b *byteSize) Set(value string) error {
+	n, err := parseByteSize(value)
+	if err != nil {
+		return err
+	}
+	*b = byteSize(n)
+	return nil
+}
+
+func (b *byteSize) Type() string {
+	return "size"
+}
+
+func parseByteSize(value string) (int64, error) {
+	s := strings.ToUpper(strings.TrimSpace(value))
+	multiplier := int64(1)
+	for _, u := range byteSizeUnits {
+		if strings.HasSuffix(s, u.suffix) {
+			multiplier = u.size
+			s = strings.TrimSpace(strings.TrimSuffix(s, u.suffix))
+			break
+		}
+	}
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size %q (use e.g. 512KB, 10MB)", value)
+	}
+	return int64(n * float64(multiplier)), nil
+}
+
+// downloadOptions controls how downloadFileWithOptions fetches a file.
+type downloadOptions struct {
+	maxSize int64 // 0 disables the limit
+	resume  bool  // resume an interrupted download from its .part file with HTTP Range
+	etag    bool  // send If-None-Match with the stored ETag and skip unchanged files
+}
+
+// downloadPaths are the hidden working files kept next to a download target.
+type downloadPaths struct {
+	part     string // partial content, renamed onto the target once complete
+	partETag string // ETag and source URL of the response being written to part
+	etag     string // ETag and source URL of the completed target file
+}
+
+func newDownloadPaths(destPath string) downloadPaths {
+	dir, base := filepath.Dir(destPath), filepath.Base(destPath)
+	return downloadPaths{
+		part:     filepath.Join(dir, "."+base+".part"),
+		partETag: filepath.Join(dir, "."+base+".part.etag"),
+		etag:     filepath.Join(dir, "."+base+".etag"),
+	}
+}
+
+// downloadFile downloads fileURL to destPath, honoring --max-size.
+// It reports whether destPath was written; false means the server confirmed
+// the stored ETag is unchanged.
+func downloadFile(httpClient HTTPClient, fileURL, destPath string) (bool, error) {
+	return downloadFileWithOptions(httpClient, fileURL, destPath, downloadOptions{
+		maxSize: int64(downloadMaxSize),
+		resume:  true,
+		etag:    true,
+	})
+}
+
+// downloadFileWithOptions downloads into a hidden .part file and renames it onto
+// destPath once complete, so destPath is never left truncated.
+// It sets browser headers to avoid CDN blocks (e.g., CloudFront 403 errors).
+func downloadFileWithOptions(httpClient HTTPClient, fileURL, destPath string, opts downloadOptions) (bool, error) {
+	paths := newDownloadPaths(destPath)
+
+	req, err := http.NewRequest(http.MethodGet, fileURL, nil)
+	if err != nil {
+		return false, err
+	}
+
+	// Set browser headers to avoid CDN blocks
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
+	req.Header.Set("Accept", "image/svg+xml,image/webp,image/apng,image/*,*/*;q=0.8")
+	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
+
+	// Resume only a .part whose sidecar holds a validator for this URL; without
+	// one, a changed asset could be spliced onto the old bytes.
+	var offset int64
+	if opts.resume {
+		if info, err := os.Stat(paths.part); err == nil && info.Size() > 0 {
+			if etag := readETag(paths.partETag, fileURL); etag != "" {
+				offset = info.Size()
+				req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+				req.Header.Set("If-Range", etag)
+			} else {
+				discardPartial(paths)
+			}
+		}
+	}
+	if opts.etag && offset == 0 {
+		if etag := readETag(paths.etag, fileURL); etag != "" && fileExists(destPath) {
+			req.Header.Set("If-None-Match", etag)
+		}
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// Full content, including when the server ignored Range or If-Range no longer matched.
+		offset = 0
+	case http.StatusPartialContent:
+		if offset == 0 || !contentRangeStartsAt(resp.Header.Get("Content-Range"), offset) {
+			discardPartial(paths)
+			return false, fmt.Errorf("unexpected partial response (Content-Range %q)", resp.Header.Get("Content-Range"))
+		}
+	case http.StatusNotModified:
+		if req.Header.Get("If-None-Match") != "" {
+			return false, nil
+		}
+		return false, fmt.Errorf("HTTP %d", resp.StatusCode)
+	case http.StatusRequestedRangeNotSatisfiable:
+		discardPartial(paths)
+		return false, fmt.Errorf("HTTP %d: discarded partial download, retry to start over", resp.StatusCode)
+	default:
+		return false, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	// The start of the file is sniffed when the server sent a generic type.
+	br := bufio.NewReaderSize(resp.Body, sniffLen)
+	var head []byte
+	if offset > 0 {
+		head = readFileHead(paths.part)
+	} else {
+		head, _ = br.Peek(sniffLen)
+	}
+	if err := checkContentType(resp.Header.Get("Content-Type"), destPath, head); err != nil {
+		return false, err
+	}
+	if opts.maxSize > 0 && resp.ContentLength > 0 && offset+resp.ContentLength > opts.maxSize {
+		return false, fmt.Errorf("file is %d bytes, exceeds --max-size %d", offset+resp.ContentLength, opts.maxSize)
+	}
+
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if offset > 0 {
+		flags = os.O_WRONLY | os.O_APPEND
+	} else {
+		// Remember which version the partial content belongs to for If-Range.
+		// Weak ETags cannot validate a range, so such downloads restart.
+		if etag := resp.Header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") && opts.resume {
+			if err := writeETag(paths.partETag, etag, fileURL); err != nil {
+				return false, err
+			}
+		} else {
+			_ = os.Remove(paths.partETag)
+		}
+	}
+	out, err := os.OpenFile(paths.part, flags, 0o644)
+	if err != nil {
+		return false, err
+	}
+
+	var body io.Reader = br
+	if opts.maxSize > 0 {
+		body = io.LimitReader(br, opts.maxSize-offset+1)
+	}
+	n, copyErr := io.Copy(out, body)
+	if err := out.Close(); err != nil && copyErr == nil {
+		copyErr = err
+	}
+	if copyErr != nil {
+		// Keep the partial file so the next attempt can resume.
+		if !opts.resume || !fileExists(paths.partETag) {
+			discardPartial(paths)
+		}
+		return false, copyErr
+	}
+	if opts.maxSize > 0 && offset+n > opts.maxSize {
+		discardPartial(paths)
+		return false, fmt.Errorf("file exceeds --max-size %d", opts.maxSize)
+	}
+
+	if err := os.Rename(paths.part, destPath); err != nil {
+		discardPartial(paths)
+		return false, err
+	}
+	if opts.etag {
+		etag := resp.Header.Get("ETag")
+		if etag == "" && offset > 0 {
+			etag = readETag(paths.partETag, fileURL)
+		}
+		if etag != "" {
+			_ = writeETag(paths.etag, etag, fileURL)
+		} else {
+			_ = os.Remove(paths.etag)
+		}
+	}
+	_ = os.Remove(paths.partETag)
+	return true, nil
+}
+
+// writeDownloadedFile writes content that was already fetched, e.g. while
+// resolving a logo, with the same checks and atomic rename as downloadFile.
+func writeDownloadedFile(destPath, contentType string, content []byte) error {
+	if limit := int64(downloadMaxSize); limit > 0 && int64(len(content)) > limit {
+		return fmt.Errorf("file is %d bytes, exceeds --max-size %d", len(content), limit)
+	}
+	if err := checkContentType(contentType, destPath, content); err != nil {
+		return err
+	}
+	paths := newDownloadPaths(destPath)
+	if err := os.WriteFile(paths.part, content, 0o644); err != nil {
+		discardPartial(paths)
+		return err
+	}
+	if err := os.Rename(paths.part, destPath); err != nil {
+		discardPartial(paths)
+		return err
+	}
+	// Any stored ETag described the previous content.
+	_ = os.Remove(paths.etag)
+	_ = os.Remove(paths.partETag)
+	return nil
+}
+
+func discardPartial(paths downloadPaths) {
+	_ = os.Remove(paths.part)
+	_ = os.Remove(paths.partETag)
+}
+
+// writeETag stores an ETag together with the URL it was served for.
+func writeETag(path, etag, fileURL string) error {
+	return os.WriteFile(path, []byte(etag+"\n"+fileURL+"\n"), 0o644)
+}
+
+// readETag returns the ETag stored by writeETag, or "" when the sidecar is
+// missing or was written for a different URL.
+func readETag(path, fileURL string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	etag, source, _ := strings.Cut(strings.TrimSpace(string(data)), "\n")
+	if strings.TrimSpace(source) != fileURL {
+		return ""
+	}
+	return strings.TrimSpace(etag)
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular()
+}
+
+// contentRangeStartsAt reports whether a "bytes start-end/total" header starts at offset.
+func contentRangeStartsAt(header string, offset int64) bool {
+	spec, ok := strings.CutPrefix(strings.TrimSpace(header), "bytes ")
+	if !ok {
+		return false
+	}
+	start, _, ok := strings.Cut(spec, "-")
+	if !ok {
+		return false
+	}
+	n, err := strconv.ParseInt(strings.TrimSpace(start), 10, 64)
+	return err == nil && n == offset
+}
+
+// imageContentTypes maps file extensions to the content types accepted for them.
+var imageContentTypes = map[string][]string{
+	".svg":  {"image/svg+xml", "text/xml", "application/xml"},
+	".png":  {"image/png"},
+	".jpg":  {"image/jpeg"},
+	".jpeg": {"image/jpeg"},
+	".webp": {"image/webp"},
+	".gif":  {"image/gif"},
+	".ico":  {"image/x-icon", "image/vnd.microsoft.icon"},
+}
+
+// sniffLen is how much of a body sniffsAsImage looks at.
+const sniffLen = 512
+
+// checkContentType rejects responses whose Content-Type does not match the
+// image format implied by destPath, such as an HTML error page saved as a logo.
+// Missing and octet-stream types are accepted; text/plain, which misconfigured
+// servers send for SVGs, only when head, the start of the body, is an image.
+func checkContentType(header, destPath string, head []byte) error {
+	if header == "" {
+		return nil
+	}
+	mediaType, _, err := mime.ParseMediaType(header)
+	if err != nil {
+		return fmt.Errorf("invalid Content-Type %q", header)
+	}
+	switch mediaType {
+	case "application/octet-stream", "binary/octet-stream":
+		// Generic types returned by misconfigured buckets and servers.
+		return nil
+	case "text/plain":
+		if sniffsAsImage(head) {
+			return nil
+		}
+		return fmt.Errorf("unexpected Content-Type %s for %s (content is not an image)", mediaType, filepath.Base(destPath))
+	}
+	expected, ok := imageContentTypes[strings.ToLower(filepath.Ext(destPath))]
+	if !ok {
+		return nil
+	}
+	for _, want := range expected {
+		if mediaType == want {
+			return nil
+		}
+	}
+	return fmt.Errorf("unexpected Content-Type %s for %s (want %s)", mediaType, filepath.Base(destPath), strings.Join(expected, " or "))
+}
+
+// sniffsAsImage reports whether head is the start of an SVG document or a
+// raster image format.
+func sniffsAsImage(head []byte) bool {
+	if strings.HasPrefix(http.DetectContentType(head), "image/") {
+		return true
+	}
+	return bytes.Contains(bytes.ToLower(head), []byte("<svg"))
+}
+
+// readFileHead returns up to sniffLen bytes from the start of path.
+func readFileHead(path string) []byte {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	head := make([]byte, sniffLen)
+	n, _ := io.ReadFull(f, head)
+	return head[:n]
+}
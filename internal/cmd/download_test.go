@@ -0,0 +1,246 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDownloadFile_ETagSkipsUnchanged(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Type", "image/svg+xml")
+		_, _ = io.WriteString(w, "<svg></svg>")
+	}))
+	defer server.Close()
+
+	dest := filepath.Join(t.TempDir(), "logo.svg")
+	updated, err := downloadFile(server.Client(), server.URL, dest)
+	if err != nil || !updated {
+		t.Fatalf("first download = %v, %v; want true, nil", updated, err)
+	}
+	updated, err = downloadFile(server.Client(), server.URL, dest)
+	if err != nil || updated {
+		t.Fatalf("second download = %v, %v; want false, nil", updated, err)
+	}
+	if data, _ := os.ReadFile(dest); string(data) != "<svg></svg>" {
+		t.Errorf("content = %q", string(data))
+	}
+
+	// Without the target file the ETag is not sent.
+	if err := os.Remove(dest); err != nil {
+		t.Fatal(err)
+	}
+	if updated, err = downloadFile(server.Client(), server.URL, dest); err != nil || !updated {
+		t.Fatalf("download after delete = %v, %v; want true, nil", updated, err)
+	}
+	if requests != 3 {
+		t.Errorf("requests = %d, want 3", requests)
+	}
+}
+
+func TestDownloadFile_ResumesPartialDownload(t *testing.T) {
+	const content = "0123456789"
+	interrupted := false
+	var rangeHeader, ifRange string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"abc"`)
+		w.Header().Set("Content-Type", "image/png")
+		if !interrupted {
+			interrupted = true
+			w.Header().Set("Content-Length", "10")
+			_, _ = io.WriteString(w, content[:5])
+			return // connection closes short of Content-Length
+		}
+		rangeHeader = r.Header.Get("Range")
+		ifRange = r.Header.Get("If-Range")
+		w.Header().Set("Content-Range", "bytes 5-9/10")
+		w.WriteHeader(http.StatusPartialContent)
+		_, _ = io.WriteString(w, content[5:])
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	dest := filepath.Join(dir, "icon.png")
+	if _, err := downloadFile(server.Client(), server.URL, dest); err == nil {
+		t.Fatal("expected error for interrupted download")
+	}
+	if _, err := os.Stat(dest); !os.IsNotExist(err) {
+		t.Fatal("interrupted download must not create the target file")
+	}
+
+	if _, err := downloadFile(server.Client(), server.URL, dest); err != nil {
+		t.Fatalf("resumed download error = %v", err)
+	}
+	if rangeHeader != "bytes=5-" || ifRange != `"abc"` {
+		t.Errorf("Range = %q, If-Range = %q", rangeHeader, ifRange)
+	}
+	if data, _ := os.ReadFile(dest); string(data) != content {
+		t.Errorf("content = %q, want %q", string(data), content)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".icon.png.part")); !os.IsNotExist(err) {
+		t.Error("partial file not cleaned up")
+	}
+}
+
+func TestDownloadFile_SidecarsBoundToURL(t *testing.T) {
+	var ifNoneMatch, rangeHeader []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ifNoneMatch = append(ifNoneMatch, r.Header.Get("If-None-Match"))
+		rangeHeader = append(rangeHeader, r.Header.Get("Range"))
+		w.Header().Set("Content-Type", "image/png")
+		switch r.URL.Path {
+		case "/old":
+			w.Header().Set("ETag", `"old"`)
+			_, _ = io.WriteString(w, "old")
+		case "/no-etag":
+			w.Header().Set("Content-Length", "10")
+			_, _ = io.WriteString(w, "stale") // connection closes short of Content-Length
+		default:
+			_, _ = io.WriteString(w, "new asset!")
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	dest := filepath.Join(dir, "icon.png")
+	if _, err := downloadFile(server.Client(), server.URL+"/old", dest); err != nil {
+		t.Fatal(err)
+	}
+	// A different source URL must not reuse the old ETag.
+	if updated, err := downloadFile(server.Client(), server.URL+"/new", dest); err != nil || !updated {
+		t.Fatalf("download from new URL = %v, %v; want true, nil", updated, err)
+	}
+	if ifNoneMatch[1] != "" {
+		t.Errorf("If-None-Match = %q sent for a different URL", ifNoneMatch[1])
+	}
+
+	// An interrupted download without an ETag cannot be resumed safely.
+	if _, err := downloadFile(server.Client(), server.URL+"/no-etag", dest); err == nil {
+		t.Fatal("expected error for interrupted download")
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".icon.png.part")); !os.IsNotExist(err) {
+		t.Error("partial file without a validator was kept")
+	}
+	if _, err := downloadFile(server.Client(), server.URL+"/new", dest); err != nil {
+		t.Fatal(err)
+	}
+	if got := rangeHeader[len(rangeHeader)-1]; got != "" {
+		t.Errorf("Range = %q, want a full download", got)
+	}
+	if data, _ := os.ReadFile(dest); string(data) != "new asset!" {
+		t.Errorf("content = %q", string(data))
+	}
+}
+
+func TestDownloadFile_Rejections(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		maxSize     byteSize
+		wantErr     string
+	}{
+		{"html error page", "text/html; charset=utf-8", "<html>Access denied</html>", 0, "unexpected Content-Type text/html"},
+		{"wrong image format", "image/png", "png", 0, "want image/svg+xml"},
+		{"too large", "image/svg+xml", strings.Repeat("x", 2048), 1024, "exceeds --max-size"},
+		{"text page as text/plain", "text/plain; charset=utf-8", "Access denied", 0, "content is not an image"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				_, _ = io.WriteString(w, tt.body)
+			}))
+			defer server.Close()
+
+			old := downloadMaxSize
+			downloadMaxSize = tt.maxSize
+			defer func() { downloadMaxSize = old }()
+
+			dir := t.TempDir()
+			dest := filepath.Join(dir, "logo.svg")
+			if err := os.WriteFile(dest, []byte("previous"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := downloadFile(server.Client(), server.URL, dest)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want %q", err, tt.wantErr)
+			}
+			if data, _ := os.ReadFile(dest); string(data) != "previous" {
+				t.Errorf("existing file overwritten: %q", string(data))
+			}
+			if _, err := os.Stat(filepath.Join(dir, ".logo.svg.part")); !os.IsNotExist(err) {
+				t.Error("partial file left behind")
+			}
+		})
+	}
+}
+
+func TestDownloadFile_TextPlainImage(t *testing.T) {
+	tests := []struct {
+		name string
+		dest string
+		body string
+	}{
+		{"svg", "logo.svg", `<?xml version="1.0"?><svg xmlns="http://www.w3.org/2000/svg"></svg>`},
+		{"png", "logo.png", "\x89PNG\r\n\x1a\n" + strings.Repeat("\x00", 16)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/plain")
+				_, _ = io.WriteString(w, tt.body)
+			}))
+			defer server.Close()
+
+			dest := filepath.Join(t.TempDir(), tt.dest)
+			if _, err := downloadFile(server.Client(), server.URL, dest); err != nil {
+				t.Fatalf("downloadFile() error = %v", err)
+			}
+			if data, _ := os.ReadFile(dest); string(data) != tt.body {
+				t.Errorf("content = %q, want %q", data, tt.body)
+			}
+		})
+	}
+}
+
+func TestParseByteSize(t *testing.T) {
+	tests := map[string]int64{
+		"0":      0,
+		"1024":   1024,
+		"512KB":  512 << 10,
+		"10mb":   10 << 20,
+		"1.5MiB": 3 << 19,
+		"2G":     2 << 30,
+	}
+	for in, want := range tests {
+		got, err := parseByteSize(in)
+		if err != nil || got != want {
+			t.Errorf("parseByteSize(%q) = %d, %v; want %d", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "abc", "-1MB"} {
+		if _, err := parseByteSize(in); err == nil {
+			t.Errorf("parseByteSize(%q) expected error", in)
+		}
+	}
+
+	size := byteSize(defaultDownloadMaxSize)
+	if got := size.String(); got != "50MB" {
+		t.Errorf("String() = %q, want 50MB", got)
+	}
+}
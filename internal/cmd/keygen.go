@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	keygenOut   string
+	keygenForce bool
+)
+
+// NewKeygenCmd creates the keygen command.
+func NewKeygenCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "keygen",
+		Short: "Generate an ed25519 key pair for signing manifests",
+		Long: `Generate an ed25519 key pair for signing checksum manifests and lock files.
+
+Writes <out>.key (PKCS#8 private key, mode 0600) and <out>.pub (PKIX public key),
+both PEM encoded. Keep the private key secret (e.g. as a CI secret) and commit
+the public key so CI can verify signatures.
+
+Examples:
+  brandfetch keygen
+  brandfetch keygen --out ./keys/brand-assets
+  brandfetch build --sign-key brandfetch.key
+  brandfetch verify --verify-key brandfetch.pub`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runKeygenCmd(cmd)
+		},
+	}
+
+	cmd.Flags().StringVar(&keygenOut, "out", "brandfetch", "Output path prefix for the .key and .pub files")
+	cmd.Flags().BoolVar(&keygenForce, "force", false, "Overwrite existing key files")
+
+	return cmd
+}
+
+func runKeygenCmd(cmd *cobra.Command) error {
+	privPath := keygenOut + ".key"
+	pubPath := keygenOut + ".pub"
+	if !keygenForce {
+		for _, path := range []string{privPath, pubPath} {
+			if _, err := os.Stat(path); err == nil {
+				return fmt.Errorf("%s already exists (use --force to overwrite)", path)
+			}
+		}
+	}
+
+	privPEM, pubPEM, err := generateSigningKey()
+	if err != nil {
+		return err
+	}
+	if err := writeKeyFile(privPath, privPEM, 0o600); err != nil {
+		return fmt.Errorf("failed to write private key: %w", err)
+	}
+	if err := writeKeyFile(pubPath, pubPEM, 0o644); err != nil {
+		return fmt.Errorf("failed to write public key: %w", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Private key: %s\nPublic key:  %s\n", privPath, pubPath)
+	return nil
+}
+
+// writeKeyFile replaces path with data. The old file is removed first because
+// os.WriteFile keeps an existing file's mode, which could leave a private key
+// readable by others.
+func writeKeyFile(path string, data []byte, perm os.FileMode) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return os.WriteFile(path, data, perm)
+}
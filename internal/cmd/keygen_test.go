@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func resetKeygenFlags() {
+	keygenOut = "brandfetch"
+	keygenForce = false
+}
+
+// generateTestKeys runs keygen into a temp dir and returns the key paths.
+func generateTestKeys(t *testing.T) (string, string) {
+	t.Helper()
+	resetKeygenFlags()
+	defer resetKeygenFlags()
+
+	prefix := filepath.Join(t.TempDir(), "test")
+	cmd := NewKeygenCmd()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--out", prefix})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("keygen error = %v", err)
+	}
+	return prefix + ".key", prefix + ".pub"
+}
+
+func TestKeygenCmd(t *testing.T) {
+	priv, pub := generateTestKeys(t)
+
+	info, err := os.Stat(priv)
+	if err != nil {
+		t.Fatalf("private key not written: %v", err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("private key mode = %v, want 0600", info.Mode().Perm())
+	}
+	if _, err := loadSigningKey(priv); err != nil {
+		t.Errorf("loadSigningKey() error = %v", err)
+	}
+	if _, err := loadVerifyKey(pub); err != nil {
+		t.Errorf("loadVerifyKey() error = %v", err)
+	}
+	if _, err := loadVerifyKey(priv); err == nil {
+		t.Error("expected error loading a private key as public key")
+	}
+
+	// Refuses to overwrite without --force.
+	resetKeygenFlags()
+	defer resetKeygenFlags()
+	cmd := NewKeygenCmd()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--out", priv[:len(priv)-len(".key")]})
+	if err := cmd.Execute(); err == nil {
+		t.Error("expected error when key files exist")
+	}
+
+	// --force replaces a world-readable file with a private one.
+	if err := os.Chmod(priv, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	resetKeygenFlags()
+	cmd = NewKeygenCmd()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--out", priv[:len(priv)-len(".key")], "--force"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("keygen --force error = %v", err)
+	}
+	if info, err := os.Stat(priv); err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("private key after --force = %v, %v; want mode 0600", info.Mode().Perm(), err)
+	}
+}
+
+func TestManifestSignature(t *testing.T) {
+	priv, pub := generateTestKeys(t)
+	dir := t.TempDir()
+	asset := filepath.Join(dir, "logo.svg")
+	if err := os.WriteFile(asset, []byte("<svg></svg>"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	entry, err := buildChecksumEntry(asset, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	manifest := filepath.Join(dir, "checksums.sha256")
+	if err := writeSHA256Manifest(manifest, []checksumEntry{entry}, false, priv); err != nil {
+		t.Fatalf("writeSHA256Manifest() error = %v", err)
+	}
+	if err := verifyManifestSignature(manifest, pub); err != nil {
+		t.Fatalf("verifyManifestSignature() error = %v", err)
+	}
+
+	// Rewriting the manifest unsigned drops the stale signature.
+	if err := writeSHA256Manifest(manifest, []checksumEntry{entry}, false, ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(manifest + ".sig"); !os.IsNotExist(err) {
+		t.Errorf("stale signature kept after an unsigned rewrite: %v", err)
+	}
+	if err := verifyManifestSignature(manifest, pub); err == nil || !containsStr(err.Error(), "is not signed") {
+		t.Errorf("verifyManifestSignature() unsigned = %v", err)
+	}
+	if err := writeSHA256Manifest(manifest, []checksumEntry{entry}, false, priv); err != nil {
+		t.Fatal(err)
+	}
+
+	// A manifest signed by another key is rejected.
+	_, otherPub := generateTestKeys(t)
+	if err := verifyManifestSignature(manifest, otherPub); err == nil {
+		t.Error("expected error for signature from a different key")
+	}
+
+	// verify skips the .sig file and reports a tampered manifest with exit code 5.
+	resetVerifyFlags()
+	defer resetVerifyFlags()
+	outputFormat = "text"
+	if out, err := runVerifyTest(t, dir, "--manifest", manifest, "--verify-key", pub); err != nil {
+		t.Fatalf("verify error = %v (output: %s)", err, out)
+	}
+	f, err := os.OpenFile(manifest, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString("0000  evil.svg\n"); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	resetVerifyFlags()
+	_, err = runVerifyTest(t, dir, "--manifest", manifest, "--verify-key", pub)
+	if got := ExitCode(err); got != exitVerifySig {
+		t.Errorf("ExitCode() = %d, want %d (err: %v)", got, exitVerifySig, err)
+	}
+}
+
+func TestBuildCmd_SignedLock(t *testing.T) {
+	resetBuildFlags()
+	defer resetBuildFlags()
+	outputFormat = "text"
+
+	priv, pub := generateTestKeys(t)
+	path := writeTestProject(t, "brands:\n  - stripe.com\n")
+	mock, mockHTTP := buildTestClients()
+
+	run := func(args ...string) error {
+		resetBuildFlags()
+		cmd := newBuildCmdWithClients(mock, mockHTTP)
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs(append([]string{"--file", path}, args...))
+		return cmd.Execute()
+	}
+
+	if err := run("--sign-key", priv); err != nil {
+		t.Fatalf("signed build error = %v", err)
+	}
+	lock := filepath.Join(filepath.Dir(path), "brandfetch.lock")
+	if _, err := os.Stat(lock + ".sig"); err != nil {
+		t.Fatalf("lock signature not written: %v", err)
+	}
+	if err := run("--locked", "--verify-key", pub); err != nil {
+		t.Fatalf("locked build with valid signature error = %v", err)
+	}
+
+	if err := os.WriteFile(lock+".sig", []byte("AAAA\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := run("--locked", "--verify-key", pub); err == nil {
+		t.Error("expected error for invalid lock signature")
+	}
+	if err := run("--verify-key", pub); err == nil {
+		t.Error("expected error for --verify-key without --locked")
+	}
+}
@@ -17,6 +17,8 @@ var (
 	logoFallback string
 	logoWidth    int
 	logoHeight   int
+	logoResolve  bool
+	logoPrefer   string
 )
 
 // NewLogoCmd creates the logo command.
@@ -32,7 +34,9 @@ Examples:
   brandfetch logo github.com
   brandfetch logo github.com --format png
   brandfetch logo github.com --theme dark
-  brandfetch logo id_123 --type icon --format png`,
+  brandfetch logo id_123 --type icon --format png
+  brandfetch logo github.com --format png --resolve
+  brandfetch logo github.com --prefer svg:dark,png:dark,svg:light,icon`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client, err := createClient(clientRequirements{requireClientID: true})
@@ -44,6 +48,7 @@ Examples:
 	}
 
 	addLogoFlags(cmd)
+	addLogoResolveFlag(cmd)
 	cmd.AddCommand(newLogoDownloadCmd())
 
 	return cmd
@@ -59,6 +64,7 @@ func newLogoCmdWithClient(client APIClient) *cobra.Command {
 		},
 	}
 	addLogoFlags(cmd)
+	addLogoResolveFlag(cmd)
 	return cmd
 }
 
@@ -69,24 +75,54 @@ func runLogoCmd(cmd *cobra.Command, args []string, client APIClient) error {
 		ctx = context.Background()
 	}
 
-	result, err := client.GetLogo(ctx, api.LogoOptions{
-		Identifier: identifier,
-		Format:     logoFormat,
-		Theme:      logoTheme,
-		Type:       logoType,
-		Fallback:   logoFallback,
-		Width:      logoWidth,
-		Height:     logoHeight,
-	})
+	opts := logoOptionsFromFlags(identifier)
+
+	format, _, err := resolveOutput(cmd)
 	if err != nil {
 		return err
 	}
 
-	format, _, err := resolveOutput(cmd)
+	if logoPrefer != "" {
+		resolved, variant, err := resolvePreferredLogo(cmd, client, opts)
+		if err != nil {
+			return err
+		}
+		if logoResolve {
+			printResolvedLogo(cmd, resolved, variant, format)
+			return nil
+		}
+		opts = variant.Apply(opts)
+		printLogoResult(cmd, &output.LogoResult{
+			URL:        resolved.URL,
+			Identifier: opts.Identifier,
+			Format:     opts.Format,
+			Theme:      opts.Theme,
+			Type:       opts.Type,
+			Fallback:   opts.Fallback,
+			Width:      opts.Width,
+			Height:     opts.Height,
+			Variant:    variant.String(),
+		}, format)
+		return nil
+	}
+
+	if logoResolve {
+		resolved, err := client.ResolveLogo(ctx, opts)
+		if err != nil {
+			return err
+		}
+		printResolvedLogo(cmd, resolved, api.LogoVariant{}, format)
+		if resolved.StatusCode < 200 || resolved.StatusCode > 299 {
+			return fmt.Errorf("logo CDN returned HTTP %d for %s", resolved.StatusCode, identifier)
+		}
+		return nil
+	}
+
+	result, err := client.GetLogo(ctx, opts)
 	if err != nil {
 		return err
 	}
-	logoResult := &output.LogoResult{
+	printLogoResult(cmd, &output.LogoResult{
 		URL:        result.URL,
 		Identifier: result.Identifier,
 		Format:     result.Format,
@@ -95,13 +131,64 @@ func runLogoCmd(cmd *cobra.Command, args []string, client APIClient) error {
 		Fallback:   result.Fallback,
 		Width:      result.Width,
 		Height:     result.Height,
+	}, format)
+	return nil
+}
+
+func logoOptionsFromFlags(identifier string) api.LogoOptions {
+	return api.LogoOptions{
+		Identifier: identifier,
+		Format:     logoFormat,
+		Theme:      logoTheme,
+		Type:       logoType,
+		Fallback:   logoFallback,
+		Width:      logoWidth,
+		Height:     logoHeight,
+	}
+}
+
+// resolvePreferredLogo probes the --prefer variants in order and returns the
+// first one the CDN serves as a real logo. The choice is noted on stderr.
+func resolvePreferredLogo(cmd *cobra.Command, client APIClient, opts api.LogoOptions) (*api.ResolvedLogo, api.LogoVariant, error) {
+	variants, err := api.ParseLogoVariants(logoPrefer)
+	if err != nil {
+		return nil, api.LogoVariant{}, err
+	}
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
 	}
+	resolved, variant, err := client.ResolvePreferredLogo(ctx, opts, variants)
+	if err != nil {
+		return nil, api.LogoVariant{}, err
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "Using %s for %s\n", variant, opts.Identifier)
+	return resolved, variant, nil
+}
 
-	fmt.Fprint(cmd.OutOrStdout(), output.FormatLogo(logoResult, format))
+func printLogoResult(cmd *cobra.Command, result *output.LogoResult, format output.Format) {
+	fmt.Fprint(cmd.OutOrStdout(), output.FormatLogo(result, format))
 	if format == output.FormatText {
 		fmt.Fprintln(cmd.OutOrStdout())
 	}
-	return nil
+}
+
+func printResolvedLogo(cmd *cobra.Command, resolved *api.ResolvedLogo, variant api.LogoVariant, format output.Format) {
+	fmt.Fprintln(cmd.OutOrStdout(), output.FormatResolvedLogo(&output.ResolvedLogo{
+		URL:         resolved.URL,
+		FinalURL:    resolved.FinalURL,
+		Status:      resolved.StatusCode,
+		ContentType: resolved.ContentType,
+		Size:        resolved.Size,
+		Width:       resolved.Width,
+		Height:      resolved.Height,
+		Fallback:    resolved.Fallback,
+		Variant:     variant.String(),
+	}, format))
+}
+
+func addLogoResolveFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&logoResolve, "resolve", false, "Request the logo and report status, content type, size, dimensions and fallback use")
 }
 
 func addLogoFlags(cmd *cobra.Command) {
@@ -111,4 +198,5 @@ func addLogoFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&logoFallback, "fallback", "", "Fallback: lettermark, icon, symbol, brandfetch, 404")
 	cmd.Flags().IntVar(&logoWidth, "width", 0, "Logo width (px)")
 	cmd.Flags().IntVar(&logoHeight, "height", 0, "Logo height (px)")
+	cmd.Flags().StringVar(&logoPrefer, "prefer", "", "Try variants in order until the CDN serves a real logo (e.g. svg:dark,png:dark,icon)")
 }
@@ -3,7 +3,6 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
@@ -34,7 +33,8 @@ func newLogoDownloadCmdWithClients(client APIClient, httpClient HTTPClient) *cob
 Examples:
   brandfetch logo download github.com
   brandfetch logo download github.com --format png --path ./logo.png
-  brandfetch logo download id_123 --type icon --format png --dir ./assets`,
+  brandfetch logo download id_123 --type icon --format png --dir ./assets
+  brandfetch logo download github.com --prefer svg:dark,png:dark --dir ./assets`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			apiClient := client
@@ -45,10 +45,15 @@ Examples:
 					return err
 				}
 			}
-			if httpClient == nil {
-				httpClient = http.DefaultClient
+			downloader := httpClient
+			if downloader == nil {
+				var err error
+				downloader, err = newDownloadClient()
+				if err != nil {
+					return err
+				}
 			}
-			return runLogoDownloadCmd(cmd, args, apiClient, httpClient)
+			return runLogoDownloadCmd(cmd, args, apiClient, downloader)
 		},
 	}
 
@@ -56,6 +61,7 @@ Examples:
 	cmd.Flags().StringVar(&logoDownloadPath, "path", "", "Output file path")
 	cmd.Flags().StringVar(&logoDownloadDir, "dir", "", "Output directory (defaults to current directory)")
 	cmd.Flags().StringVar(&logoDownloadSHA256, "sha256", "", "Verify SHA-256 checksum after download")
+	addDownloadFlags(cmd)
 
 	return cmd
 }
@@ -71,23 +77,30 @@ func runLogoDownloadCmd(cmd *cobra.Command, args []string, client APIClient, htt
 		return fmt.Errorf("--path and --dir are mutually exclusive")
 	}
 
-	result, err := client.GetLogo(ctx, api.LogoOptions{
-		Identifier: identifier,
-		Format:     logoFormat,
-		Theme:      logoTheme,
-		Type:       logoType,
-		Fallback:   logoFallback,
-		Width:      logoWidth,
-		Height:     logoHeight,
-	})
-	if err != nil {
-		return err
+	opts := logoOptionsFromFlags(identifier)
+	var logoURL, variant string
+	var resolved *api.ResolvedLogo
+	if logoPrefer != "" {
+		var chosen api.LogoVariant
+		var err error
+		resolved, chosen, err = resolvePreferredLogo(cmd, client, opts)
+		if err != nil {
+			return err
+		}
+		opts = chosen.Apply(opts)
+		logoURL, variant = resolved.URL, chosen.String()
+	} else {
+		result, err := client.GetLogo(ctx, opts)
+		if err != nil {
+			return err
+		}
+		logoURL = result.URL
 	}
 
 	path := logoDownloadPath
 	if path == "" {
-		ext := logoFormat
-		if extFromURL := strings.TrimPrefix(getExtensionFromURL(result.URL), "."); extFromURL != "" {
+		ext := opts.Format
+		if extFromURL := strings.TrimPrefix(getExtensionFromURL(logoURL), "."); extFromURL != "" {
 			ext = extFromURL
 		}
 		if ext == "" {
@@ -102,20 +115,22 @@ func runLogoDownloadCmd(cmd *cobra.Command, args []string, client APIClient, htt
 	}
 
 	if dir := filepath.Dir(path); dir != "." {
-		err = os.MkdirAll(dir, 0o755)
-		if err != nil {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
 			return fmt.Errorf("failed to create directory %s: %w", dir, err)
 		}
 	}
 
-	err = downloadFile(httpClient, result.URL, path)
-	if err != nil {
+	// --prefer already fetched the chosen logo; write it instead of downloading it again.
+	if resolved != nil && resolved.Body != nil {
+		if err := writeDownloadedFile(path, resolved.ContentType, resolved.Body); err != nil {
+			return fmt.Errorf("failed to save logo: %w", err)
+		}
+	} else if _, err := downloadFile(httpClient, logoURL, path); err != nil {
 		return fmt.Errorf("failed to download logo: %w", err)
 	}
 
 	if logoDownloadSHA256 != "" {
-		var ok bool
-		ok, err = verifySHA256(path, logoDownloadSHA256)
+		ok, err := verifySHA256(path, logoDownloadSHA256)
 		if err != nil {
 			return err
 		}
@@ -131,9 +146,12 @@ func runLogoDownloadCmd(cmd *cobra.Command, args []string, client APIClient, htt
 
 	if format == output.FormatJSON {
 		payload := map[string]string{
-			"url":  result.URL,
+			"url":  logoURL,
 			"path": path,
 		}
+		if variant != "" {
+			payload["variant"] = variant
+		}
 		return output.PrintJSON(cmd.OutOrStdout(), payload)
 	}
 
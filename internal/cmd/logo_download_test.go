@@ -15,6 +15,7 @@ import (
 
 func TestLogoDownloadCmd_Text(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/svg+xml")
 		_, _ = io.WriteString(w, "logo-bytes")
 	}))
 	defer server.Close()
@@ -50,6 +51,74 @@ func TestLogoDownloadCmd_Text(t *testing.T) {
 	}
 }
 
+func TestLogoDownloadCmd_Prefer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = io.WriteString(w, "png-bytes")
+	}))
+	defer server.Close()
+
+	mock := &MockAPIClient{
+		ResolvePreferredFunc: func(ctx context.Context, opts api.LogoOptions, variants []api.LogoVariant) (*api.ResolvedLogo, api.LogoVariant, error) {
+			return &api.ResolvedLogo{URL: server.URL + "/github.com/theme/dark/type/icon.png", StatusCode: 200}, variants[1], nil
+		},
+	}
+
+	tempDir := t.TempDir()
+	var stdout, stderr bytes.Buffer
+	cmd := newLogoDownloadCmdWithClients(mock, server.Client())
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetArgs([]string{"github.com", "--prefer", "svg:dark,icon:png:dark", "--dir", tempDir})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	outPath := filepath.Join(tempDir, "github.com.png")
+	data, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("failed to read output file: %v", err)
+	}
+	if string(data) != "png-bytes" {
+		t.Errorf("unexpected file contents: %s", string(data))
+	}
+	if !containsStr(stderr.String(), "Using png:dark:icon for github.com") {
+		t.Errorf("stderr = %q, want the chosen variant", stderr.String())
+	}
+}
+
+func TestLogoDownloadCmd_PreferReusesResolvedContent(t *testing.T) {
+	mock := &MockAPIClient{
+		ResolvePreferredFunc: func(ctx context.Context, opts api.LogoOptions, variants []api.LogoVariant) (*api.ResolvedLogo, api.LogoVariant, error) {
+			return &api.ResolvedLogo{
+				URL:         "https://cdn.brandfetch.io/github.com/theme/dark/type/icon.png",
+				StatusCode:  200,
+				ContentType: "image/png",
+				Body:        []byte("resolved-png"),
+			}, variants[0], nil
+		},
+	}
+	httpClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			t.Errorf("unexpected download of %s", req.URL)
+			return nil, io.EOF
+		},
+	}
+
+	tempDir := t.TempDir()
+	cmd := newLogoDownloadCmdWithClients(mock, httpClient)
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"github.com", "--prefer", "icon:png:dark", "--dir", tempDir})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(tempDir, "github.com.png"))
+	if err != nil || string(data) != "resolved-png" {
+		t.Errorf("saved %q, %v; want the resolved content", data, err)
+	}
+}
+
 func TestSanitizeFileName(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -85,6 +154,7 @@ func TestLogoDownloadCmd_BrowserHeaders(t *testing.T) {
 	var receivedHeaders http.Header
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		receivedHeaders = r.Header
+		w.Header().Set("Content-Type", "image/svg+xml")
 		_, _ = io.WriteString(w, "logo-bytes")
 	}))
 	defer server.Close()
@@ -132,6 +202,7 @@ func TestLogoDownloadCmd_BrowserHeaders(t *testing.T) {
 
 func TestLogoDownloadCmd_SHA256(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/svg+xml")
 		_, _ = io.WriteString(w, "logo-bytes")
 	}))
 	defer server.Close()
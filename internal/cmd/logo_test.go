@@ -14,6 +14,8 @@ import (
 // MockAPIClient for testing commands
 type MockAPIClient struct {
 	GetLogoFunc           func(ctx context.Context, opts api.LogoOptions) (*api.LogoResult, error)
+	ResolveLogoFunc       func(ctx context.Context, opts api.LogoOptions) (*api.ResolvedLogo, error)
+	ResolvePreferredFunc  func(ctx context.Context, opts api.LogoOptions, variants []api.LogoVariant) (*api.ResolvedLogo, api.LogoVariant, error)
 	GetBrandFunc          func(ctx context.Context, domain string) (*api.Brand, error)
 	SearchFunc            func(ctx context.Context, query string, limit int) ([]api.SearchResult, error)
 	CreateTransactionFunc func(ctx context.Context, label, countryCode string) (*api.Brand, error)
@@ -25,6 +27,20 @@ func (m *MockAPIClient) GetLogo(ctx context.Context, opts api.LogoOptions) (*api
 	return m.GetLogoFunc(ctx, opts)
 }
 
+func (m *MockAPIClient) ResolveLogo(ctx context.Context, opts api.LogoOptions) (*api.ResolvedLogo, error) {
+	if m.ResolveLogoFunc == nil {
+		return nil, fmt.Errorf("ResolveLogo not implemented")
+	}
+	return m.ResolveLogoFunc(ctx, opts)
+}
+
+func (m *MockAPIClient) ResolvePreferredLogo(ctx context.Context, opts api.LogoOptions, variants []api.LogoVariant) (*api.ResolvedLogo, api.LogoVariant, error) {
+	if m.ResolvePreferredFunc == nil {
+		return nil, api.LogoVariant{}, fmt.Errorf("ResolvePreferredLogo not implemented")
+	}
+	return m.ResolvePreferredFunc(ctx, opts, variants)
+}
+
 func (m *MockAPIClient) GetBrand(ctx context.Context, domain string) (*api.Brand, error) {
 	return m.GetBrandFunc(ctx, domain)
 }
@@ -118,3 +134,138 @@ func TestLogoCmd_JSON(t *testing.T) {
 		t.Errorf("JSON url = %v, want expected URL", result["url"])
 	}
 }
+
+func TestLogoCmd_Resolve(t *testing.T) {
+	mock := &MockAPIClient{
+		ResolveLogoFunc: func(ctx context.Context, opts api.LogoOptions) (*api.ResolvedLogo, error) {
+			if opts.Identifier != "github.com" || opts.Format != "png" {
+				return nil, fmt.Errorf("unexpected options: %+v", opts)
+			}
+			return &api.ResolvedLogo{
+				URL:         "https://cdn.brandfetch.io/github.com/type/icon.png?c=id",
+				FinalURL:    "https://asset.brandfetch.io/github/icon.png",
+				StatusCode:  200,
+				ContentType: "image/png",
+				Size:        2048,
+				Width:       400,
+				Height:      400,
+				Fallback:    true,
+			}, nil
+		},
+	}
+
+	var stdout bytes.Buffer
+	outputFormat = "text"
+	cmd := newLogoCmdWithClient(mock)
+	cmd.SetOut(&stdout)
+	cmd.SetArgs([]string{"github.com", "--format", "png", "--resolve"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	for _, want := range []string{
+		"Final URL:    https://asset.brandfetch.io/github/icon.png",
+		"Status:       200 OK",
+		"Content type: image/png",
+		"Size:         2048 bytes",
+		"Dimensions:   400x400",
+		"Fallback:     yes",
+	} {
+		if !containsStr(stdout.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, stdout.String())
+		}
+	}
+
+	stdout.Reset()
+	outputFormat = "json"
+	defer func() { outputFormat = "text" }()
+	cmd = newLogoCmdWithClient(mock)
+	cmd.SetOut(&stdout)
+	cmd.SetArgs([]string{"github.com", "--format", "png", "--resolve"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+		t.Fatalf("output not valid JSON: %v", err)
+	}
+	if result["status"] != float64(200) || result["fallback"] != true || result["width"] != float64(400) {
+		t.Errorf("JSON = %v", result)
+	}
+}
+
+func TestLogoCmd_ResolveNotFound(t *testing.T) {
+	mock := &MockAPIClient{
+		ResolveLogoFunc: func(ctx context.Context, opts api.LogoOptions) (*api.ResolvedLogo, error) {
+			return &api.ResolvedLogo{URL: "https://cdn.brandfetch.io/nope.example", FinalURL: "https://cdn.brandfetch.io/nope.example", StatusCode: 404}, nil
+		},
+	}
+
+	var stdout bytes.Buffer
+	outputFormat = "text"
+	cmd := newLogoCmdWithClient(mock)
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"nope.example", "--fallback", "404", "--resolve"})
+	err := cmd.Execute()
+	if err == nil || !containsStr(err.Error(), "HTTP 404") {
+		t.Fatalf("Execute() error = %v, want HTTP 404", err)
+	}
+	if !containsStr(stdout.String(), "Status:       404 Not Found") {
+		t.Errorf("output missing status:\n%s", stdout.String())
+	}
+}
+
+func TestLogoCmd_Prefer(t *testing.T) {
+	mock := &MockAPIClient{
+		ResolvePreferredFunc: func(ctx context.Context, opts api.LogoOptions, variants []api.LogoVariant) (*api.ResolvedLogo, api.LogoVariant, error) {
+			if len(variants) != 3 || variants[0].String() != "svg:dark" || opts.Type != "logo" {
+				return nil, api.LogoVariant{}, fmt.Errorf("unexpected variants %v for %+v", variants, opts)
+			}
+			return &api.ResolvedLogo{URL: "https://cdn.brandfetch.io/github.com/theme/dark/type/logo.png?c=id", StatusCode: 200}, variants[1], nil
+		},
+	}
+
+	var stdout, stderr bytes.Buffer
+	outputFormat = "text"
+	cmd := newLogoCmdWithClient(mock)
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetArgs([]string{"github.com", "--prefer", "svg:dark,png:dark,icon"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if stdout.String() != "https://cdn.brandfetch.io/github.com/theme/dark/type/logo.png?c=id\n" {
+		t.Errorf("stdout = %q, want the chosen URL", stdout.String())
+	}
+	if !containsStr(stderr.String(), "Using png:dark for github.com") {
+		t.Errorf("stderr = %q, want the chosen variant", stderr.String())
+	}
+
+	stdout.Reset()
+	outputFormat = "json"
+	defer func() { outputFormat = "text" }()
+	cmd = newLogoCmdWithClient(mock)
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"github.com", "--prefer", "svg:dark,png:dark,icon"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+		t.Fatalf("output not valid JSON: %v", err)
+	}
+	if result["variant"] != "png:dark" || result["format"] != "png" || result["theme"] != "dark" {
+		t.Errorf("JSON = %v, want the chosen variant", result)
+	}
+}
+
+func TestLogoCmd_PreferInvalid(t *testing.T) {
+	cmd := newLogoCmdWithClient(&MockAPIClient{})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"github.com", "--prefer", "svg:gif"})
+	if err := cmd.Execute(); err == nil || !containsStr(err.Error(), "invalid logo preference") {
+		t.Fatalf("Execute() error = %v, want invalid preference", err)
+	}
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/brandfetch-cli/internal/mockserver"
+	"github.com/salmonumbrella/brandfetch-cli/internal/output"
+)
+
+var (
+	mockAddr        string
+	mockFixtures    string
+	mockLatency     time.Duration
+	mockErrorRate   float64
+	mockErrorStatus []int
+	mockSeed        int64
+	mockClientID    string
+	mockAPIKey      string
+)
+
+// NewMockCmd creates the mock command group.
+func NewMockCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mock",
+		Short: "Run an offline mock of the Brandfetch APIs",
+	}
+	cmd.AddCommand(newMockServeCmd())
+	return cmd
+}
+
+func newMockServeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve the Brand, Search, Transaction, GraphQL and Logo CDN APIs from fixtures",
+		Long: `Serve an offline mock of the Brandfetch APIs for scripts and tests, without
+spending quota. Brands come from built-in fixtures or from --fixtures DIR,
+which holds one brands/<domain>.json file per brand in Brand API format.
+
+Implemented endpoints:
+  GET  /v2/brands/{domain|id}    Brand API
+  GET  /v2/search/{query}        Search API
+  POST /v2/brands/transaction    Transaction API
+  POST /graphql                  Webhook create, list, subscribe and unsubscribe
+  GET  /cdn/{identifier}/...     Logo API CDN (generated images)
+
+Any non-empty key is accepted unless --client-id or --api-key is given.
+
+Examples:
+  brandfetch mock serve
+  brandfetch mock serve --addr 127.0.0.1:9000 --fixtures ./fixtures
+  brandfetch mock serve --latency 300ms --error-rate 0.2 --error-status 429,503`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMockServeCmd(cmd)
+		},
+	}
+
+	cmd.Flags().StringVar(&mockAddr, "addr", "127.0.0.1:8080", "Address to listen on")
+	cmd.Flags().StringVar(&mockFixtures, "fixtures", "", "Fixture directory (default: built-in fixtures)")
+	cmd.Flags().DurationVar(&mockLatency, "latency", 0, "Delay every response")
+	cmd.Flags().Float64Var(&mockErrorRate, "error-rate", 0, "Fraction of requests (0-1) answered with an injected error")
+	cmd.Flags().IntSliceVar(&mockErrorStatus, "error-status", nil, "Status codes for injected errors (default: 429,500,503)")
+	cmd.Flags().Int64Var(&mockSeed, "seed", 0, "Seed for reproducible error injection")
+	cmd.Flags().StringVar(&mockClientID, "client-id", "", "Only accept this Logo API key")
+	cmd.Flags().StringVar(&mockAPIKey, "api-key", "", "Only accept this Brand API key")
+
+	return cmd
+}
+
+func runMockServeCmd(cmd *cobra.Command) error {
+	opts := mockserver.Options{
+		Latency:       mockLatency,
+		ErrorRate:     mockErrorRate,
+		ErrorStatuses: mockErrorStatus,
+		Seed:          mockSeed,
+		ClientID:      mockClientID,
+		APIKey:        mockAPIKey,
+	}
+	if mockFixtures != "" {
+		if info, err := os.Stat(mockFixtures); err != nil || !info.IsDir() {
+			return fmt.Errorf("fixtures directory %s not found", mockFixtures)
+		}
+		opts.Fixtures = os.DirFS(mockFixtures)
+	}
+
+	server, err := mockserver.Listen(mockAddr, opts)
+	if err != nil {
+		return err
+	}
+
+	format, _, err := resolveOutput(cmd)
+	if err != nil {
+		return err
+	}
+	endpoints := server.Endpoints()
+	if format == output.FormatJSON {
+		if err := output.PrintJSON(cmd.OutOrStdout(), map[string]string{
+			"api_url":     endpoints.API,
+			"cdn_url":     endpoints.CDN,
+			"graphql_url": endpoints.GraphQL,
+		}); err != nil {
+			return err
+		}
+	} else {
+		out := cmd.OutOrStdout()
+		fmt.Fprintf(out, "Mock Brandfetch API listening on %s\n\n", server.URL())
+		fmt.Fprintln(out, "Point the CLI at it with:")
+		fmt.Fprintf(out, "  export BRANDFETCH_API_URL=%s\n", endpoints.API)
+		fmt.Fprintf(out, "  export BRANDFETCH_CDN_URL=%s\n", endpoints.CDN)
+		fmt.Fprintf(out, "  export BRANDFETCH_GRAPHQL_URL=%s\n\n", endpoints.GraphQL)
+		fmt.Fprintln(out, "Press Ctrl+C to stop.")
+	}
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- server.Serve() }()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return server.Shutdown(shutdownCtx)
+	}
+}
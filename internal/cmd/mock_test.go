@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/salmonumbrella/brandfetch-cli/internal/mockserver"
+)
+
+func TestMockServeCmd(t *testing.T) {
+	isolateConfigDir(t)
+	t.Setenv("BRANDFETCH_CLIENT_ID", "cid")
+	t.Setenv("BRANDFETCH_API_KEY", "key")
+
+	stdout := &syncBuffer{}
+	cmd := NewMockCmd()
+	cmd.SetOut(stdout)
+	cmd.SetArgs([]string{"serve", "--addr", "127.0.0.1:0"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- cmd.ExecuteContext(ctx) }()
+
+	listening := regexp.MustCompile(`listening on (http://\S+)`)
+	var baseURL string
+	for deadline := time.Now().Add(5 * time.Second); baseURL == "" && time.Now().Before(deadline); {
+		if m := listening.FindStringSubmatch(stdout.String()); m != nil {
+			baseURL = m[1]
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if baseURL == "" {
+		t.Fatalf("server did not start: %s", stdout.String())
+	}
+
+	endpoints := mockserver.EndpointsFor(baseURL)
+	apiURL, cdnURL, graphQLURL = endpoints.API, endpoints.CDN, endpoints.GraphQL
+	t.Cleanup(func() { apiURL, cdnURL, graphQLURL = "", "", "" })
+
+	client, err := createClient(clientRequirements{requireAPIKey: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	brand, err := client.GetBrand(ctx, "github.com")
+	if err != nil || brand.Name != "GitHub" {
+		t.Errorf("GetBrand() = %v, %v", brand, err)
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("serve returned %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("serve did not stop after cancellation")
+	}
+}
+
+func TestMockServeCmdMissingFixtures(t *testing.T) {
+	cmd := NewMockCmd()
+	cmd.SetOut(&syncBuffer{})
+	cmd.SetErr(&syncBuffer{})
+	cmd.SetArgs([]string{"serve", "--addr", "127.0.0.1:0", "--fixtures", t.TempDir() + "/missing"})
+	if err := cmd.Execute(); err == nil {
+		t.Error("expected error for a missing fixtures directory")
+	}
+}
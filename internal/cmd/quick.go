@@ -3,7 +3,6 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
 	"os"
@@ -24,6 +23,9 @@ var quickSHA256Manifest string
 var quickSHA256ManifestOut string
 var quickSHA256ManifestAppend bool
 var quickSHA256ManifestVerify bool
+var quickSignKey string
+var quickVerifyKey string
+var quickIndustry string
 
 // HTTPClient interface for downloading files (allows mocking in tests).
 type HTTPClient interface {
@@ -57,14 +59,21 @@ Examples:
   brandfetch quick stripe.com github.com airbnb.com
   brandfetch quick stripe.com github.com --output json
   brandfetch quick stripe.com github.com --css
-  brandfetch quick stripe.com github.com --download ./assets/`,
+  brandfetch quick stripe.com github.com --download ./assets/
+  brandfetch quick stripe.com github.com shopify.com --industry finance
+  brandfetch quick stripe.com -d ./assets --sha256-manifest-out assets.sha256 --sign-key brandfetch.key
+  brandfetch quick stripe.com -d ./assets --sha256-manifest assets.sha256 --verify-key brandfetch.pub`,
 		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client, err := createClient(clientRequirements{requireAPIKey: true})
 			if err != nil {
 				return err
 			}
-			return runQuickCmd(cmd, args, client, http.DefaultClient)
+			httpClient, err := newDownloadClient()
+			if err != nil {
+				return err
+			}
+			return runQuickCmd(cmd, args, client, httpClient)
 		},
 	}
 
@@ -76,6 +85,10 @@ Examples:
 	cmd.Flags().StringVar(&quickSHA256ManifestOut, "sha256-manifest-out", "", "Write a SHA-256 manifest file for downloads")
 	cmd.Flags().BoolVar(&quickSHA256ManifestAppend, "sha256-manifest-append", false, "Merge checksums into existing manifest")
 	cmd.Flags().BoolVar(&quickSHA256ManifestVerify, "sha256-manifest-verify", false, "Fail when checksum verification mismatches")
+	cmd.Flags().StringVar(&quickSignKey, "sign-key", "", "Sign the --sha256-manifest-out manifest with an ed25519 private key")
+	cmd.Flags().StringVar(&quickVerifyKey, "verify-key", "", "Require a valid signature on --sha256-manifest from an ed25519 public key")
+	cmd.Flags().StringVar(&quickIndustry, "industry", "", "Only include brands in this industry (name or slug)")
+	addDownloadFlags(cmd)
 
 	return cmd
 }
@@ -100,6 +113,10 @@ func newQuickCmdWithClients(client APIClient, httpClient HTTPClient) *cobra.Comm
 	cmd.Flags().StringVar(&quickSHA256ManifestOut, "sha256-manifest-out", "", "Write a SHA-256 manifest file for downloads")
 	cmd.Flags().BoolVar(&quickSHA256ManifestAppend, "sha256-manifest-append", false, "Merge checksums into existing manifest")
 	cmd.Flags().BoolVar(&quickSHA256ManifestVerify, "sha256-manifest-verify", false, "Fail when checksum verification mismatches")
+	cmd.Flags().StringVar(&quickSignKey, "sign-key", "", "Sign the --sha256-manifest-out manifest with an ed25519 private key")
+	cmd.Flags().StringVar(&quickVerifyKey, "verify-key", "", "Require a valid signature on --sha256-manifest from an ed25519 public key")
+	cmd.Flags().StringVar(&quickIndustry, "industry", "", "Only include brands in this industry (name or slug)")
+	addDownloadFlags(cmd)
 	return cmd
 }
 
@@ -119,10 +136,17 @@ func runQuickCmd(cmd *cobra.Command, args []string, client APIClient, httpClient
 	if tailwindOutput && cssOutput {
 		return fmt.Errorf("--tailwind and --css are mutually exclusive")
 	}
+	if quickSignKey != "" && quickSHA256ManifestOut == "" {
+		return fmt.Errorf("--sign-key requires --sha256-manifest-out")
+	}
+	if quickVerifyKey != "" && quickSHA256Manifest == "" {
+		return fmt.Errorf("--verify-key requires --sha256-manifest")
+	}
 
 	// Fetch all brands, continuing on error
 	var results []*output.QuickResult
 	var fetchErrors []string
+	var skipped []string
 
 	for _, domain := range args {
 		brand, err := client.GetBrand(ctx, domain)
@@ -131,9 +155,18 @@ func runQuickCmd(cmd *cobra.Command, args []string, client APIClient, httpClient
 			fmt.Fprintf(cmd.ErrOrStderr(), "Error fetching %s: %v\n", domain, err)
 			continue
 		}
+		if quickIndustry != "" && !brand.Company.InIndustry(quickIndustry) {
+			skipped = append(skipped, domain)
+			fmt.Fprintf(cmd.ErrOrStderr(), "Skipping %s: not in industry %q\n", domain, quickIndustry)
+			continue
+		}
 		results = append(results, convertBrandToQuickResult(brand))
 	}
 
+	if len(results) == 0 && len(skipped) > 0 && len(fetchErrors) == 0 {
+		return fmt.Errorf("no brands in industry %q: %s", quickIndustry, strings.Join(skipped, ", "))
+	}
+
 	// If no results, return error summary
 	if len(results) == 0 {
 		return fmt.Errorf("failed to fetch all domains: %s", strings.Join(fetchErrors, "; "))
@@ -158,6 +191,11 @@ func runQuickCmd(cmd *cobra.Command, args []string, client APIClient, httpClient
 		var manifest map[string]string
 		var manifestEntries []checksumEntry
 		if quickSHA256Manifest != "" {
+			if quickVerifyKey != "" {
+				if err := verifyManifestSignature(quickSHA256Manifest, quickVerifyKey); err != nil {
+					return err
+				}
+			}
 			var err error
 			manifest, err = parseSHA256Manifest(quickSHA256Manifest)
 			if err != nil {
@@ -168,7 +206,7 @@ func runQuickCmd(cmd *cobra.Command, args []string, client APIClient, httpClient
 			return err
 		}
 		if quickSHA256ManifestOut != "" {
-			if err := writeSHA256Manifest(quickSHA256ManifestOut, manifestEntries, quickSHA256ManifestAppend); err != nil {
+			if err := writeSHA256Manifest(quickSHA256ManifestOut, manifestEntries, quickSHA256ManifestAppend, quickSignKey); err != nil {
 				return err
 			}
 		}
@@ -222,39 +260,18 @@ func downloadAssetsToDir(cmd *cobra.Command, result *output.QuickResult, httpCli
 		return err
 	}
 
-	var downloads []struct {
-		url      string
-		filename string
-	}
-
-	if result.LogoLight != "" {
-		downloads = append(downloads, struct {
-			url      string
-			filename string
-		}{result.LogoLight, "logo-light.svg"})
-	}
-
-	if result.LogoDark != "" {
-		downloads = append(downloads, struct {
-			url      string
-			filename string
-		}{result.LogoDark, "logo-dark.svg"})
-	}
-
-	if result.Favicon != "" {
-		ext := getExtensionFromURL(result.Favicon)
-		downloads = append(downloads, struct {
-			url      string
-			filename string
-		}{result.Favicon, "favicon" + ext})
-	}
+	downloads := quickAssetDownloads(result)
 
 	for _, d := range downloads {
 		destPath := filepath.Join(targetDir, d.filename)
-		if err := downloadFile(httpClient, d.url, destPath); err != nil {
+		if updated, err := downloadFile(httpClient, d.url, destPath); err != nil {
 			fmt.Fprintf(cmd.ErrOrStderr(), "Error: failed to download %s: %v\n", d.filename, err)
 		} else {
-			fmt.Fprintf(cmd.ErrOrStderr(), "Downloaded: %s\n", destPath)
+			if updated {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Downloaded: %s\n", destPath)
+			} else {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Unchanged: %s\n", destPath)
+			}
 			if quickSHA256 {
 				if err := writeSHA256File(destPath); err != nil {
 					fmt.Fprintf(cmd.ErrOrStderr(), "Error: failed to write checksum for %s: %v\n", d.filename, err)
@@ -280,46 +297,35 @@ func downloadAssetsToDir(cmd *cobra.Command, result *output.QuickResult, httpCli
 	return nil
 }
 
-func writeSHA256File(path string) error {
-	sum, err := computeSHA256(path)
-	if err != nil {
-		return err
-	}
-	content := fmt.Sprintf("%s  %s\n", sum, filepath.Base(path))
-	return os.WriteFile(path+".sha256", []byte(content), 0o644)
+// assetDownload pairs a remote asset URL with its local file name.
+type assetDownload struct {
+	url      string
+	filename string
 }
 
-// downloadFile downloads a file from url and saves it to destPath.
-// It sets browser headers to avoid CDN blocks (e.g., CloudFront 403 errors).
-func downloadFile(httpClient HTTPClient, fileURL, destPath string) error {
-	req, err := http.NewRequest(http.MethodGet, fileURL, nil)
-	if err != nil {
-		return err
+// quickAssetDownloads returns the logos and favicon that quick downloads for a brand.
+func quickAssetDownloads(result *output.QuickResult) []assetDownload {
+	var downloads []assetDownload
+	if result.LogoLight != "" {
+		downloads = append(downloads, assetDownload{result.LogoLight, "logo-light.svg"})
 	}
-
-	// Set browser headers to avoid CDN blocks
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
-	req.Header.Set("Accept", "image/svg+xml,image/webp,image/apng,image/*,*/*;q=0.8")
-	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
-
-	resp, err := httpClient.Do(req)
-	if err != nil {
-		return err
+	if result.LogoDark != "" {
+		downloads = append(downloads, assetDownload{result.LogoDark, "logo-dark.svg"})
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	if result.Favicon != "" {
+		ext := getExtensionFromURL(result.Favicon)
+		downloads = append(downloads, assetDownload{result.Favicon, "favicon" + ext})
 	}
+	return downloads
+}
 
-	out, err := os.Create(destPath)
+func writeSHA256File(path string) error {
+	sum, err := computeSHA256(path)
 	if err != nil {
 		return err
 	}
-	defer out.Close()
-
-	_, err = io.Copy(out, resp.Body)
-	return err
+	content := fmt.Sprintf("%s  %s\n", sum, filepath.Base(path))
+	return os.WriteFile(path+".sha256", []byte(content), 0o644)
 }
 
 // getExtensionFromURL extracts file extension from a URL.
@@ -1768,3 +1768,38 @@ func TestSanitizeDirName(t *testing.T) {
 		})
 	}
 }
+
+func TestQuickCmd_Industry(t *testing.T) {
+	mock := &MockAPIClient{
+		GetBrandFunc: func(ctx context.Context, domain string) (*api.Brand, error) {
+			if domain == "stripe.com" {
+				return stripeBrand(), nil
+			}
+			return &api.Brand{Name: "GitHub", Domain: "github.com"}, nil
+		},
+	}
+	outputFormat = "text"
+
+	var stdout, stderr bytes.Buffer
+	cmd := newQuickCmdWithClient(mock)
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetArgs([]string{"stripe.com", "github.com", "--industry", "financial-services"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !strings.Contains(stdout.String(), "Stripe") || strings.Contains(stdout.String(), "GitHub") {
+		t.Errorf("stdout = %q, want only Stripe", stdout.String())
+	}
+	if !strings.Contains(stderr.String(), `Skipping github.com: not in industry "financial-services"`) {
+		t.Errorf("stderr = %q, want skip notice", stderr.String())
+	}
+
+	cmd = newQuickCmdWithClient(mock)
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"github.com", "--industry", "finance"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), `no brands in industry "finance"`) {
+		t.Fatalf("Execute() error = %v, want no brands in industry", err)
+	}
+}
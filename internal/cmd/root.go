@@ -1,14 +1,32 @@
 package cmd
 
 import (
+	"errors"
+	"fmt"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/brandfetch-cli/internal/api"
 )
 
 var (
-	outputFormat string
-	colorMode    string
+	outputFormat   string
+	colorMode      string
+	profileName    string
+	keyringBackend string
+
+	apiURL         string
+	cdnURL         string
+	graphQLURL     string
+	requestTimeout time.Duration
+	proxyURL       string
+	caCertFile     string
+	clientCertFile string
+	clientKeyFile  string
+	recordDir      string
+	replayDir      string
 )
 
 // NewRootCmd creates the root command.
@@ -21,6 +39,13 @@ func NewRootCmd() *cobra.Command {
 Get your API keys at https://brandfetch.com/developers`,
 		SilenceUsage:  true,
 		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			// A broken settings file must not block fixing it.
+			if path := commandPath(cmd); len(path) > 0 && path[0] == "config" {
+				return nil
+			}
+			return applySettings(cmd)
+		},
 	}
 
 	// Global flags
@@ -28,6 +53,30 @@ Get your API keys at https://brandfetch.com/developers`,
 		"Output format: text, json")
 	cmd.PersistentFlags().StringVar(&colorMode, "color", getEnvDefault("BRANDFETCH_COLOR", "auto"),
 		"Color mode: auto, always, never")
+	cmd.PersistentFlags().StringVar(&profileName, "profile", os.Getenv("BRANDFETCH_PROFILE"),
+		"Credential profile (default: active profile)")
+	cmd.PersistentFlags().StringVar(&keyringBackend, "keyring-backend", getEnvDefault("BRANDFETCH_KEYRING_BACKEND", "auto"),
+		"Secrets backend: auto, keychain, wincred, secret-service, kwallet, pass, file, env")
+	cmd.PersistentFlags().StringVar(&apiURL, "api-url", os.Getenv("BRANDFETCH_API_URL"),
+		"Brand, Search and Transaction API base URL (default: https://api.brandfetch.io)")
+	cmd.PersistentFlags().StringVar(&cdnURL, "cdn-url", os.Getenv("BRANDFETCH_CDN_URL"),
+		"Logo API CDN base URL (default: https://cdn.brandfetch.io)")
+	cmd.PersistentFlags().StringVar(&graphQLURL, "graphql-url", os.Getenv("BRANDFETCH_GRAPHQL_URL"),
+		"GraphQL endpoint (default: https://graphql.brandfetch.io/)")
+	cmd.PersistentFlags().DurationVar(&requestTimeout, "timeout", getEnvDuration("BRANDFETCH_TIMEOUT", api.DefaultTimeout),
+		"Per-request API and download timeout (0 disables it)")
+	cmd.PersistentFlags().StringVar(&proxyURL, "proxy", os.Getenv("BRANDFETCH_PROXY"),
+		"Proxy URL (default: HTTPS_PROXY/HTTP_PROXY)")
+	cmd.PersistentFlags().StringVar(&caCertFile, "ca-cert", os.Getenv("BRANDFETCH_CA_CERT"),
+		"PEM CA bundle to trust in addition to the system roots")
+	cmd.PersistentFlags().StringVar(&clientCertFile, "client-cert", os.Getenv("BRANDFETCH_CLIENT_CERT"),
+		"PEM client certificate for mutual TLS")
+	cmd.PersistentFlags().StringVar(&clientKeyFile, "client-key", os.Getenv("BRANDFETCH_CLIENT_KEY"),
+		"PEM private key for --client-cert")
+	cmd.PersistentFlags().StringVar(&recordDir, "record", os.Getenv("BRANDFETCH_RECORD"),
+		"Record API requests and responses to fixture files in this directory")
+	cmd.PersistentFlags().StringVar(&replayDir, "replay", os.Getenv("BRANDFETCH_REPLAY"),
+		"Answer API requests from fixture files recorded with --record")
 
 	return cmd
 }
@@ -41,6 +90,12 @@ func Execute(args []string) error {
 	rootCmd.AddCommand(NewLogoCmd())
 	rootCmd.AddCommand(NewBrandCmd())
 	rootCmd.AddCommand(NewQuickCmd())
+	rootCmd.AddCommand(NewSyncCmd())
+	rootCmd.AddCommand(NewBuildCmd())
+	rootCmd.AddCommand(NewVerifyCmd())
+	rootCmd.AddCommand(NewDiffCmd())
+	rootCmd.AddCommand(NewWatchCmd())
+	rootCmd.AddCommand(NewKeygenCmd())
 	rootCmd.AddCommand(NewSearchCmd())
 	rootCmd.AddCommand(NewColorsCmd())
 	rootCmd.AddCommand(NewFontsCmd())
@@ -48,9 +103,20 @@ func Execute(args []string) error {
 	rootCmd.AddCommand(NewWebhooksCmd())
 	rootCmd.AddCommand(NewGraphQLCmd())
 	rootCmd.AddCommand(NewAuthCmd())
+	rootCmd.AddCommand(NewConfigCmd())
+	rootCmd.AddCommand(NewMockCmd())
 
 	rootCmd.SetArgs(args)
-	return rootCmd.Execute()
+	return withAuthHint(rootCmd.Execute())
+}
+
+// withAuthHint points rejected credentials at 'brandfetch auth set'; the API
+// client's own errors stay free of CLI wording.
+func withAuthHint(err error) error {
+	if errors.Is(err, api.ErrUnauthorized) {
+		return fmt.Errorf("%w\nRun `brandfetch auth set` to configure credentials.", err)
+	}
+	return err
 }
 
 func getEnvDefault(key, defaultVal string) string {
@@ -60,6 +126,15 @@ func getEnvDefault(key, defaultVal string) string {
 	return defaultVal
 }
 
+// getEnvDuration parses key as a duration. Invalid values are reported when a
+// client is created.
+func getEnvDuration(key string, defaultVal time.Duration) time.Duration {
+	if v, err := time.ParseDuration(os.Getenv(key)); err == nil {
+		return v
+	}
+	return defaultVal
+}
+
 // GetOutputFormat returns the current output format.
 func GetOutputFormat() string {
 	return outputFormat
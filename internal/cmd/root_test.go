@@ -2,8 +2,12 @@ package cmd
 
 import (
 	"bytes"
+	"errors"
 	"strings"
 	"testing"
+
+	"github.com/salmonumbrella/brandfetch-cli/internal/api"
+	"github.com/salmonumbrella/brandfetch-cli/pkg/brandfetch"
 )
 
 func TestRootCmd_Help(t *testing.T) {
@@ -41,6 +45,24 @@ func TestRootCmd_OutputFlag(t *testing.T) {
 	}
 }
 
+func TestWithAuthHint(t *testing.T) {
+	err := withAuthHint(&ExitError{Code: 3, Err: brandfetch.WrapAPIError(401, "")})
+	if !containsStr(err.Error(), "brandfetch auth set") {
+		t.Errorf("401 error = %q, want the auth set hint", err)
+	}
+	if !errors.Is(err, api.ErrUnauthorized) || ExitCode(err) != 3 {
+		t.Errorf("hint lost the wrapped error: %v (exit %d)", err, ExitCode(err))
+	}
+
+	other := errors.New("boom")
+	if got := withAuthHint(other); got != other {
+		t.Errorf("withAuthHint(%v) = %v, want it unchanged", other, got)
+	}
+	if withAuthHint(nil) != nil {
+		t.Error("withAuthHint(nil) should be nil")
+	}
+}
+
 func containsStr(s, substr string) bool {
 	return len(s) >= len(substr) && strings.Contains(s, substr)
 }
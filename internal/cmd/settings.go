@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/salmonumbrella/brandfetch-cli/internal/config"
+)
+
+// envFlags maps global flags to the environment variables that override settings.
+var envFlags = map[string]string{
+	"output":          "BRANDFETCH_OUTPUT",
+	"color":           "BRANDFETCH_COLOR",
+	"profile":         "BRANDFETCH_PROFILE",
+	"keyring-backend": "BRANDFETCH_KEYRING_BACKEND",
+	"api-url":         "BRANDFETCH_API_URL",
+	"cdn-url":         "BRANDFETCH_CDN_URL",
+	"graphql-url":     "BRANDFETCH_GRAPHQL_URL",
+	"timeout":         "BRANDFETCH_TIMEOUT",
+	"proxy":           "BRANDFETCH_PROXY",
+	"ca-cert":         "BRANDFETCH_CA_CERT",
+	"client-cert":     "BRANDFETCH_CLIENT_CERT",
+	"client-key":      "BRANDFETCH_CLIENT_KEY",
+	"record":          "BRANDFETCH_RECORD",
+	"replay":          "BRANDFETCH_REPLAY",
+}
+
+// applySettings fills flags that were not set on the command line from
+// settings.yaml, giving the precedence flag > env > config > built-in default.
+func applySettings(cmd *cobra.Command) error {
+	path, err := config.SettingsFilePath()
+	if err != nil {
+		return nil
+	}
+	settings, err := config.LoadSettings(path)
+	if err != nil {
+		return err
+	}
+
+	var applyErr error
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		if applyErr != nil || f.Changed {
+			return
+		}
+		if env, ok := envFlags[f.Name]; ok && os.Getenv(env) != "" && isGlobalFlag(cmd, f.Name) {
+			return
+		}
+		for _, key := range settingKeys(cmd, f.Name) {
+			value, ok := settings.Get(key)
+			if !ok {
+				continue
+			}
+			// Set the value without marking the flag as changed: it acts as a default.
+			if err := f.Value.Set(value); err != nil {
+				applyErr = fmt.Errorf("invalid value for %s in %s: %w", key, path, err)
+			}
+			return
+		}
+	})
+	return applyErr
+}
+
+// settingKeys returns the settings keys that configure flag name on cmd, most
+// specific first: "logo.download.format", "logo.format", then "format" for global flags.
+func settingKeys(cmd *cobra.Command, name string) []string {
+	path := commandPath(cmd)
+	var keys []string
+	for i := len(path); i > 0; i-- {
+		keys = append(keys, strings.Join(path[:i], ".")+"."+name)
+	}
+	if isGlobalFlag(cmd, name) {
+		keys = append(keys, name)
+	}
+	return keys
+}
+
+// commandPath returns the command names below the root, e.g. ["logo", "download"].
+func commandPath(cmd *cobra.Command) []string {
+	var path []string
+	for c := cmd; c.HasParent(); c = c.Parent() {
+		path = append([]string{c.Name()}, path...)
+	}
+	return path
+}
+
+func isGlobalFlag(cmd *cobra.Command, name string) bool {
+	return cmd.Root().PersistentFlags().Lookup(name) != nil
+}
+
+// resolveSettingKey finds the flag a settings key configures.
+func resolveSettingKey(root *cobra.Command, key string) (*pflag.Flag, error) {
+	parts := strings.Split(key, ".")
+	name := parts[len(parts)-1]
+	if len(parts) == 1 {
+		if f := root.PersistentFlags().Lookup(name); f != nil {
+			return f, nil
+		}
+		return nil, fmt.Errorf("unknown global setting %q (per-command settings look like logo.format)", key)
+	}
+
+	c := root
+	for _, part := range parts[:len(parts)-1] {
+		var next *cobra.Command
+		for _, sub := range c.Commands() {
+			if sub.Name() == part {
+				next = sub
+				break
+			}
+		}
+		if next == nil {
+			return nil, fmt.Errorf("unknown command %q in setting %q", part, key)
+		}
+		c = next
+	}
+	if f := c.Flags().Lookup(name); f != nil {
+		return f, nil
+	}
+	if f := c.InheritedFlags().Lookup(name); f != nil {
+		return f, nil
+	}
+	return nil, fmt.Errorf("unknown flag --%s for %q", name, strings.Join(parts[:len(parts)-1], " "))
+}
+
+// validateSettingValue checks value against the flag's type.
+func validateSettingValue(f *pflag.Flag, value string) error {
+	var err error
+	switch f.Value.Type() {
+	case "bool":
+		_, err = strconv.ParseBool(value)
+	case "int", "int64":
+		_, err = strconv.ParseInt(value, 10, 64)
+	case "float64":
+		_, err = strconv.ParseFloat(value, 64)
+	case "duration":
+		_, err = time.ParseDuration(value)
+	case "size":
+		_, err = parseByteSize(value)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid %s value %q for --%s", f.Value.Type(), value, f.Name)
+	}
+	return nil
+}
@@ -0,0 +1,455 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/brandfetch-cli/internal/api"
+	"github.com/salmonumbrella/brandfetch-cli/internal/output"
+)
+
+// syncStateFile is the manifest sync keeps in the target directory.
+const syncStateFile = ".brandfetch-sync.json"
+
+var (
+	syncPrune     bool
+	syncBrandFile string
+)
+
+// Sync change statuses.
+const (
+	syncAdded     = "added"
+	syncUpdated   = "updated"
+	syncUnchanged = "unchanged"
+	syncRemoved   = "removed"
+	syncStale     = "stale"
+)
+
+type syncState struct {
+	Brands map[string]*syncBrandState `json:"brands"`
+}
+
+type syncBrandState struct {
+	Name  string                    `json:"name,omitempty"`
+	Dir   string                    `json:"dir"`
+	Files map[string]*syncFileState `json:"files"`
+}
+
+type syncFileState struct {
+	URL    string `json:"url"`
+	SHA256 string `json:"sha256"`
+}
+
+type syncChange struct {
+	File   string `json:"file"`
+	Status string `json:"status"`
+}
+
+type syncBrandReport struct {
+	Identifier string       `json:"identifier"`
+	Dir        string       `json:"dir,omitempty"`
+	Error      string       `json:"error,omitempty"`
+	Changes    []syncChange `json:"changes,omitempty"`
+}
+
+type syncReport struct {
+	Brands    []syncBrandReport `json:"brands"`
+	Added     int               `json:"added"`
+	Updated   int               `json:"updated"`
+	Unchanged int               `json:"unchanged"`
+	Removed   int               `json:"removed"`
+	Stale     int               `json:"stale"`
+}
+
+// NewSyncCmd creates the sync command.
+func NewSyncCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sync <dir> [identifier...]",
+		Short: "Incrementally sync brand assets into a directory",
+		Long: `Fetch current brand data and update a directory of brand assets.
+
+Only assets whose URL or content changed are rewritten. The list of brands
+comes from the arguments, --file, or the sync manifest (` + syncStateFile + `)
+left in the directory by a previous run. Each brand gets its own subdirectory.
+--prune removes files that a synced brand no longer has; tracked brands that
+are not being synced are left alone.
+
+Uses the Brand API which has limited quota (one request per brand).
+
+Examples:
+  brandfetch sync ./brand-assets stripe.com github.com
+  brandfetch sync ./brand-assets
+  brandfetch sync ./brand-assets --file brands.txt --prune
+  brandfetch sync ./brand-assets --output json`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := createClient(clientRequirements{requireAPIKey: true})
+			if err != nil {
+				return err
+			}
+			httpClient, err := newDownloadClient()
+			if err != nil {
+				return err
+			}
+			return runSyncCmd(cmd, args, client, httpClient)
+		},
+	}
+
+	addSyncFlags(cmd)
+
+	return cmd
+}
+
+func newSyncCmdWithClients(client APIClient, httpClient HTTPClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "sync <dir> [identifier...]",
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSyncCmd(cmd, args, client, httpClient)
+		},
+	}
+	addSyncFlags(cmd)
+	return cmd
+}
+
+func addSyncFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&syncPrune, "prune", false, "Remove files that are no longer part of a brand")
+	cmd.Flags().StringVar(&syncBrandFile, "file", "", "Read identifiers from a file (one per line)")
+	addDownloadFlags(cmd)
+}
+
+func runSyncCmd(cmd *cobra.Command, args []string, client APIClient, httpClient HTTPClient) error {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	dir := args[0]
+	statePath := filepath.Join(dir, syncStateFile)
+	state, err := loadSyncState(statePath)
+	if err != nil {
+		return err
+	}
+
+	identifiers := args[1:]
+	if syncBrandFile != "" {
+		fromFile, err := readIdentifierFile(syncBrandFile)
+		if err != nil {
+			return err
+		}
+		identifiers = append(identifiers, fromFile...)
+	}
+	explicit := len(identifiers) > 0
+	if !explicit {
+		for id := range state.Brands {
+			identifiers = append(identifiers, id)
+		}
+		sort.Strings(identifiers)
+	}
+	identifiers = dedupeStrings(identifiers)
+	if len(identifiers) == 0 {
+		return fmt.Errorf("no brands to sync: pass identifiers, --file, or run in a directory with %s", syncStateFile)
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	// Brands tracked in the manifest but not requested are left untouched;
+	// --prune only removes stale files of the brands being synced.
+	report := &syncReport{}
+	var failed []string
+	for _, id := range identifiers {
+		brandReport := syncBrand(ctx, cmd, client, httpClient, dir, id, state)
+		if brandReport.Error != "" {
+			failed = append(failed, id)
+		}
+		report.Brands = append(report.Brands, brandReport)
+	}
+
+	for _, b := range report.Brands {
+		for _, c := range b.Changes {
+			switch c.Status {
+			case syncAdded:
+				report.Added++
+			case syncUpdated:
+				report.Updated++
+			case syncUnchanged:
+				report.Unchanged++
+			case syncRemoved:
+				report.Removed++
+			case syncStale:
+				report.Stale++
+			}
+		}
+	}
+
+	if err := saveSyncState(statePath, state); err != nil {
+		return err
+	}
+
+	format, _, err := resolveOutput(cmd)
+	if err != nil {
+		return err
+	}
+	if format == output.FormatJSON {
+		if err := output.PrintJSON(cmd.OutOrStdout(), report); err != nil {
+			return err
+		}
+	} else {
+		renderSyncReport(cmd.OutOrStdout(), report)
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to sync %d brand(s): %s", len(failed), strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+func syncBrand(ctx context.Context, cmd *cobra.Command, client APIClient, httpClient HTTPClient, dir, id string, state *syncState) syncBrandReport {
+	report := syncBrandReport{Identifier: id}
+
+	brand, err := client.GetBrand(ctx, id)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error fetching %s: %v\n", id, err)
+		report.Error = err.Error()
+		return report
+	}
+	result := convertBrandToQuickResult(brand)
+
+	prev := state.Brands[id]
+	brandDir := syncBrandDir(result, id)
+	if prev != nil && prev.Dir != "" {
+		// The manifest is a plain file in the target directory; never follow
+		// it outside of that directory.
+		if !filepath.IsLocal(prev.Dir) {
+			report.Error = fmt.Sprintf("invalid directory %q in %s", prev.Dir, syncStateFile)
+			fmt.Fprintf(cmd.ErrOrStderr(), "Error: %s: %s\n", id, report.Error)
+			return report
+		}
+		brandDir = prev.Dir
+	}
+	report.Dir = brandDir
+
+	next := &syncBrandState{Name: result.Name, Dir: brandDir, Files: make(map[string]*syncFileState)}
+	targetDir := filepath.Join(dir, brandDir)
+	if err := os.MkdirAll(targetDir, 0o755); err != nil {
+		report.Error = fmt.Sprintf("failed to create directory %s: %v", targetDir, err)
+		return report
+	}
+
+	for _, d := range quickAssetDownloads(result) {
+		var prevFile *syncFileState
+		if prev != nil {
+			prevFile = prev.Files[d.filename]
+		}
+		destPath := filepath.Join(targetDir, d.filename)
+		status, sum, err := syncAsset(httpClient, d.url, destPath, prevFile)
+		if err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Error: failed to sync %s: %v\n", destPath, err)
+			if prevFile != nil {
+				next.Files[d.filename] = prevFile
+			}
+			if report.Error == "" {
+				report.Error = err.Error()
+			}
+			continue
+		}
+		next.Files[d.filename] = &syncFileState{URL: d.url, SHA256: sum}
+		report.Changes = append(report.Changes, syncChange{File: d.filename, Status: status})
+	}
+
+	if prev != nil {
+		var stale []string
+		for name := range prev.Files {
+			// Assets are stored flat in the brand directory.
+			if _, ok := next.Files[name]; !ok && filepath.IsLocal(name) && filepath.Base(name) == name {
+				stale = append(stale, name)
+			}
+		}
+		sort.Strings(stale)
+		for _, name := range stale {
+			if syncPrune {
+				if err := removeIfExists(filepath.Join(targetDir, name)); err != nil {
+					fmt.Fprintf(cmd.ErrOrStderr(), "Error: failed to remove %s: %v\n", name, err)
+					next.Files[name] = prev.Files[name]
+					continue
+				}
+				report.Changes = append(report.Changes, syncChange{File: name, Status: syncRemoved})
+				continue
+			}
+			next.Files[name] = prev.Files[name]
+			report.Changes = append(report.Changes, syncChange{File: name, Status: syncStale})
+		}
+	}
+
+	state.Brands[id] = next
+	return report
+}
+
+// syncAsset brings destPath up to date with fileURL and returns the change status and content hash.
+func syncAsset(httpClient HTTPClient, fileURL, destPath string, prev *syncFileState) (string, string, error) {
+	localSum, err := computeSHA256(destPath)
+	exists := err == nil
+
+	// Same URL and untouched content: nothing to fetch.
+	if exists && prev != nil && prev.URL == fileURL && strings.EqualFold(prev.SHA256, localSum) {
+		return syncUnchanged, localSum, nil
+	}
+
+	// The temp name keeps the extension so the Content-Type check applies.
+	tmp, err := os.CreateTemp(filepath.Dir(destPath), ".*-"+filepath.Base(destPath))
+	if err != nil {
+		return "", "", err
+	}
+	tmpPath := tmp.Name()
+	tmp.Close()
+	defer os.Remove(tmpPath)
+
+	if _, err := downloadFileWithOptions(httpClient, fileURL, tmpPath, downloadOptions{maxSize: int64(downloadMaxSize)}); err != nil {
+		return "", "", err
+	}
+	sum, err := computeSHA256(tmpPath)
+	if err != nil {
+		return "", "", err
+	}
+
+	// URL moved but the bytes are identical.
+	if exists && strings.EqualFold(sum, localSum) {
+		return syncUnchanged, sum, nil
+	}
+	if err := os.Rename(tmpPath, destPath); err != nil {
+		return "", "", err
+	}
+	if exists {
+		return syncUpdated, sum, nil
+	}
+	return syncAdded, sum, nil
+}
+
+func syncBrandDir(result *output.QuickResult, id string) string {
+	if result.Domain != "" {
+		return sanitizeDirName(result.Domain)
+	}
+	return sanitizeFileName(api.NormalizeIdentifier(id))
+}
+
+func loadSyncState(path string) (*syncState, error) {
+	state := &syncState{Brands: make(map[string]*syncBrandState)}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return state, nil
+		}
+		return nil, fmt.Errorf("failed to read sync manifest: %w", err)
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse sync manifest %s: %w", path, err)
+	}
+	if state.Brands == nil {
+		state.Brands = make(map[string]*syncBrandState)
+	}
+	for _, b := range state.Brands {
+		if b.Files == nil {
+			b.Files = make(map[string]*syncFileState)
+		}
+	}
+	return state, nil
+}
+
+func saveSyncState(path string, state *syncState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+func renderSyncReport(w io.Writer, report *syncReport) {
+	for _, b := range report.Brands {
+		if b.Dir != "" {
+			fmt.Fprintf(w, "%s (%s)\n", b.Identifier, b.Dir)
+		} else {
+			fmt.Fprintln(w, b.Identifier)
+		}
+		if b.Error != "" {
+			fmt.Fprintf(w, "  ! %s\n", b.Error)
+		}
+		for _, c := range b.Changes {
+			fmt.Fprintf(w, "  %s %s\n", syncStatusSymbol(c.Status), c.File)
+		}
+	}
+	fmt.Fprintf(w, "\n%d added, %d updated, %d unchanged, %d removed", report.Added, report.Updated, report.Unchanged, report.Removed)
+	if report.Stale > 0 {
+		fmt.Fprintf(w, ", %d stale (use --prune to remove)", report.Stale)
+	}
+	fmt.Fprintln(w)
+}
+
+func syncStatusSymbol(status string) string {
+	switch status {
+	case syncAdded:
+		return "+"
+	case syncUpdated:
+		return "~"
+	case syncRemoved:
+		return "-"
+	case syncStale:
+		return "?"
+	default:
+		return "="
+	}
+}
+
+// readIdentifierFile reads one identifier per line, skipping blanks and # comments.
+func readIdentifierFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	defer file.Close()
+
+	var ids []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		ids = append(ids, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return ids, nil
+}
+
+func dedupeStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	var out []string
+	for _, v := range values {
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		out = append(out, v)
+	}
+	return out
+}
+
+func removeIfExists(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,273 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/brandfetch-cli/internal/api"
+)
+
+func resetSyncFlags() {
+	syncPrune = false
+	syncBrandFile = ""
+}
+
+func syncTestBrand(lightURL string) *api.Brand {
+	return &api.Brand{
+		Name:   "Stripe",
+		Domain: "stripe.com",
+		Logos: []api.Logo{
+			{
+				Type:    "logo",
+				Theme:   "light",
+				Formats: []api.LogoFormat{{Src: lightURL, Format: "svg"}},
+			},
+		},
+	}
+}
+
+func TestSyncCmd_DownloadsOnlyChanges(t *testing.T) {
+	resetSyncFlags()
+	defer resetSyncFlags()
+	outputFormat = "json"
+	defer func() { outputFormat = "text" }()
+
+	dir := t.TempDir()
+	lightURL := "https://asset.brandfetch.io/stripe/logo-light.svg"
+	content := "<svg>v1</svg>"
+	requests := 0
+
+	mock := &MockAPIClient{
+		GetBrandFunc: func(ctx context.Context, domain string) (*api.Brand, error) {
+			return syncTestBrand(lightURL), nil
+		},
+	}
+	mockHTTP := &MockHTTPClient{
+		GetFunc: func(url string) (*http.Response, error) {
+			requests++
+			return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(content))}, nil
+		},
+	}
+
+	run := func(args ...string) syncReport {
+		t.Helper()
+		var stdout, stderr bytes.Buffer
+		cmd := newSyncCmdWithClients(mock, mockHTTP)
+		cmd.SetOut(&stdout)
+		cmd.SetErr(&stderr)
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("Execute() error = %v (stderr: %s)", err, stderr.String())
+		}
+		var report syncReport
+		if err := json.Unmarshal(stdout.Bytes(), &report); err != nil {
+			t.Fatalf("output not valid JSON: %v", err)
+		}
+		return report
+	}
+
+	report := run(dir, "stripe.com")
+	if report.Added != 1 {
+		t.Fatalf("first sync added = %d, want 1", report.Added)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "stripe", "logo-light.svg"))
+	if err != nil || string(data) != content {
+		t.Fatalf("logo not written: %v %q", err, string(data))
+	}
+
+	// Second run reads brands from the manifest and skips the unchanged asset.
+	report = run(dir)
+	if report.Unchanged != 1 || requests != 1 {
+		t.Errorf("second sync unchanged = %d, requests = %d; want 1, 1", report.Unchanged, requests)
+	}
+
+	// New URL with identical bytes is still reported as unchanged.
+	lightURL = "https://asset.brandfetch.io/stripe/logo-light-v2.svg"
+	report = run(dir)
+	if report.Unchanged != 1 || requests != 2 {
+		t.Errorf("moved URL sync unchanged = %d, requests = %d; want 1, 2", report.Unchanged, requests)
+	}
+
+	content = "<svg>v2</svg>"
+	lightURL = "https://asset.brandfetch.io/stripe/logo-light-v3.svg"
+	report = run(dir)
+	if report.Updated != 1 {
+		t.Errorf("changed content sync updated = %d, want 1", report.Updated)
+	}
+	data, _ = os.ReadFile(filepath.Join(dir, "stripe", "logo-light.svg"))
+	if string(data) != content {
+		t.Errorf("logo content = %q, want %q", string(data), content)
+	}
+}
+
+func TestSyncCmd_Prune(t *testing.T) {
+	resetSyncFlags()
+	defer resetSyncFlags()
+	outputFormat = "text"
+
+	dir := t.TempDir()
+	withFavicon := true
+	mock := &MockAPIClient{
+		GetBrandFunc: func(ctx context.Context, domain string) (*api.Brand, error) {
+			brand := syncTestBrand("https://asset.brandfetch.io/stripe/logo-light.svg")
+			if withFavicon {
+				brand.Logos = append(brand.Logos, api.Logo{
+					Type:    "icon",
+					Formats: []api.LogoFormat{{Src: "https://asset.brandfetch.io/stripe/favicon.png", Format: "png"}},
+				})
+			}
+			return brand, nil
+		},
+	}
+	mockHTTP := &MockHTTPClient{
+		GetFunc: func(url string) (*http.Response, error) {
+			return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(url))}, nil
+		},
+	}
+
+	var stdout bytes.Buffer
+	cmd := newSyncCmdWithClients(mock, mockHTTP)
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{dir, "stripe.com"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	favicon := filepath.Join(dir, "stripe", "favicon.png")
+	if _, err := os.Stat(favicon); err != nil {
+		t.Fatalf("favicon not downloaded: %v", err)
+	}
+
+	withFavicon = false
+	stdout.Reset()
+	cmd = newSyncCmdWithClients(mock, mockHTTP)
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{dir})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !containsStr(stdout.String(), "? favicon.png") {
+		t.Errorf("expected stale favicon without --prune: %s", stdout.String())
+	}
+	if _, err := os.Stat(favicon); err != nil {
+		t.Errorf("favicon removed without --prune")
+	}
+
+	stdout.Reset()
+	cmd = newSyncCmdWithClients(mock, mockHTTP)
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{dir, "--prune"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !containsStr(stdout.String(), "- favicon.png") {
+		t.Errorf("expected removed favicon with --prune: %s", stdout.String())
+	}
+	if _, err := os.Stat(favicon); !os.IsNotExist(err) {
+		t.Errorf("favicon should be removed with --prune")
+	}
+}
+
+func TestSyncCmd_PruneOnlyRequestedBrands(t *testing.T) {
+	resetSyncFlags()
+	defer resetSyncFlags()
+	outputFormat = "text"
+
+	dir := t.TempDir()
+	other := filepath.Join(dir, "github", "logo.svg")
+	if err := os.MkdirAll(filepath.Dir(other), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(other, []byte("github"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	state := `{"brands":{"github.com":{"dir":"github","files":{"logo.svg":{"url":"https://asset.brandfetch.io/github/logo.svg","sha256":"x"}}}}}`
+	if err := os.WriteFile(filepath.Join(dir, syncStateFile), []byte(state), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	mock := &MockAPIClient{
+		GetBrandFunc: func(ctx context.Context, domain string) (*api.Brand, error) {
+			return syncTestBrand("https://asset.brandfetch.io/stripe/logo-light.svg"), nil
+		},
+	}
+	mockHTTP := &MockHTTPClient{
+		GetFunc: func(url string) (*http.Response, error) {
+			return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(url))}, nil
+		},
+	}
+	cmd := newSyncCmdWithClients(mock, mockHTTP)
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{dir, "stripe.com", "--prune"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if _, err := os.Stat(other); err != nil {
+		t.Errorf("--prune removed a brand that was not synced: %v", err)
+	}
+	saved, err := loadSyncState(filepath.Join(dir, syncStateFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Brands["github.com"] == nil || saved.Brands["stripe.com"] == nil {
+		t.Errorf("manifest brands = %v, want both tracked", saved.Brands)
+	}
+}
+
+func TestSyncCmd_RejectsManifestDirOutsideTarget(t *testing.T) {
+	resetSyncFlags()
+	defer resetSyncFlags()
+	outputFormat = "text"
+
+	root := t.TempDir()
+	dir := filepath.Join(root, "assets")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	state := `{"brands":{"stripe.com":{"dir":"../escape","files":{}}}}`
+	if err := os.WriteFile(filepath.Join(dir, syncStateFile), []byte(state), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	mock := &MockAPIClient{
+		GetBrandFunc: func(ctx context.Context, domain string) (*api.Brand, error) {
+			return syncTestBrand("https://asset.brandfetch.io/stripe/logo-light.svg"), nil
+		},
+	}
+	mockHTTP := &MockHTTPClient{
+		GetFunc: func(url string) (*http.Response, error) {
+			return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(url))}, nil
+		},
+	}
+	cmd := newSyncCmdWithClients(mock, mockHTTP)
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{dir})
+	err := cmd.Execute()
+	if err == nil || !containsStr(err.Error(), "stripe.com") {
+		t.Fatalf("error = %v, want stripe.com to fail", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "escape")); !os.IsNotExist(err) {
+		t.Errorf("sync wrote outside the target directory (stat err %v)", err)
+	}
+}
+
+func TestSyncCmd_NoBrands(t *testing.T) {
+	resetSyncFlags()
+	cmd := newSyncCmdWithClients(&MockAPIClient{}, &MockHTTPClient{})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs([]string{t.TempDir()})
+	if err := cmd.Execute(); err == nil {
+		t.Fatal("expected error when no brands are known")
+	}
+}
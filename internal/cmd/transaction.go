@@ -10,6 +10,7 @@ import (
 )
 
 var transactionCountry string
+var transactionRaw bool
 
 // NewTransactionCmd creates the transaction command.
 func NewTransactionCmd() *cobra.Command {
@@ -20,7 +21,8 @@ func NewTransactionCmd() *cobra.Command {
 
 Examples:
   brandfetch transaction "STARBUCKS 1234 SEATTLE WA"
-  brandfetch transaction "Spotify USA" --country US`,
+  brandfetch transaction "Spotify USA" --country US
+  brandfetch transaction "Spotify USA" --country US --raw`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client, err := createClient(clientRequirements{requireAPIKey: true})
@@ -32,6 +34,7 @@ Examples:
 	}
 
 	cmd.Flags().StringVar(&transactionCountry, "country", "", "Country code (ISO 3166-1 alpha-2)")
+	cmd.Flags().BoolVar(&transactionRaw, "raw", false, "Print the API response body unchanged")
 
 	return cmd
 }
@@ -45,6 +48,7 @@ func newTransactionCmdWithClient(client APIClient) *cobra.Command {
 		},
 	}
 	cmd.Flags().StringVar(&transactionCountry, "country", "", "Country code")
+	cmd.Flags().BoolVar(&transactionRaw, "raw", false, "Print the API response body unchanged")
 	return cmd
 }
 
@@ -63,6 +67,9 @@ func runTransactionCmd(cmd *cobra.Command, args []string, client APIClient) erro
 	if err != nil {
 		return err
 	}
+	if transactionRaw {
+		return printRawBrand(cmd, brand)
+	}
 
 	format, colorize, err := resolveOutput(cmd)
 	if err != nil {
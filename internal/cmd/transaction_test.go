@@ -12,6 +12,7 @@ import (
 
 func resetTransactionFlags() {
 	transactionCountry = ""
+	transactionRaw = false
 }
 
 func TestTransactionCmd_JSON(t *testing.T) {
@@ -67,3 +68,24 @@ func TestTransactionCmd_MissingCountry(t *testing.T) {
 		t.Errorf("error should mention ISO format: %v", err)
 	}
 }
+
+func TestTransactionCmd_Raw(t *testing.T) {
+	resetTransactionFlags()
+	raw := `{"name":"Spotify","domain":"spotify.com","newField":1}`
+	mock := &MockAPIClient{
+		CreateTransactionFunc: func(ctx context.Context, label, countryCode string) (*api.Brand, error) {
+			return &api.Brand{Name: "Spotify", Domain: "spotify.com", Raw: json.RawMessage(raw)}, nil
+		},
+	}
+
+	var stdout bytes.Buffer
+	cmd := newTransactionCmdWithClient(mock)
+	cmd.SetOut(&stdout)
+	cmd.SetArgs([]string{"SPOTIFY USA", "--country", "US", "--raw"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if stdout.String() != raw+"\n" {
+		t.Errorf("stdout = %q, want raw body", stdout.String())
+	}
+}
@@ -0,0 +1,299 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/brandfetch-cli/internal/output"
+)
+
+// Exit codes reported by verify, in order of precedence.
+const (
+	exitVerifyChanged = 2
+	exitVerifyMissing = 3
+	exitVerifyExtra   = 4
+	exitVerifySig     = 5
+)
+
+var (
+	verifyManifest    string
+	verifyAlgorithm   string
+	verifyIgnoreExtra bool
+	verifyKey         string
+)
+
+type verifyReport struct {
+	Algorithm string   `json:"algorithm"`
+	Manifest  string   `json:"manifest"`
+	OK        []string `json:"ok"`
+	Changed   []string `json:"changed"`
+	Missing   []string `json:"missing"`
+	Extra     []string `json:"extra"`
+}
+
+// NewVerifyCmd creates the verify command.
+func NewVerifyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify [dir]",
+		Short: "Verify a directory against a checksum manifest",
+		Long: `Walk a directory and compare every file against a checksum manifest.
+
+The manifest uses the "<digest>  <path>" format written by quick
+--sha256-manifest-out, build (brandfetch.lock), sha256sum, sha512sum, b2sum and
+b3sum. Paths are relative to the directory. Hidden files, checksum sidecar
+files and manifest signatures are ignored.
+
+Without a directory argument only the directories the manifest lists files in
+are checked for extra files, so a project root with a brandfetch.lock verifies
+its generated assets without flagging the project's own sources.
+
+With --verify-key the manifest's detached ed25519 signature (<manifest>.sig,
+written with --sign-key) is checked before any file is hashed.
+
+The algorithm is inferred from the manifest extension (.sha256, .sha512, .b2,
+.b3) or digest length unless --algorithm is set.
+
+Exit codes:
+  0  all files match
+  2  one or more files changed
+  3  one or more files are missing
+  4  unexpected extra files (disable with --ignore-extra)
+  5  manifest signature is missing or invalid
+
+Examples:
+  brandfetch verify ./brand-assets --manifest ./checksums.sha256
+  brandfetch verify                       # uses ./brandfetch.lock
+  brandfetch verify assets --manifest assets.b3 --algorithm blake3
+  brandfetch verify assets --manifest checksums.sha512 --output json
+  brandfetch verify --verify-key brandfetch.pub`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runVerifyCmd(cmd, args)
+		},
+	}
+
+	cmd.Flags().StringVarP(&verifyManifest, "manifest", "m", "", "Checksum manifest (defaults to <dir>/brandfetch.lock)")
+	cmd.Flags().StringVarP(&verifyAlgorithm, "algorithm", "a", "", "Checksum algorithm: sha256, sha512, blake2b, blake3 (default: inferred)")
+	cmd.Flags().BoolVar(&verifyIgnoreExtra, "ignore-extra", false, "Do not fail on files missing from the manifest")
+	cmd.Flags().StringVar(&verifyKey, "verify-key", "", "Require a valid manifest signature from an ed25519 public key")
+
+	return cmd
+}
+
+func runVerifyCmd(cmd *cobra.Command, args []string) error {
+	dir := "."
+	scoped := len(args) == 0
+	if !scoped {
+		dir = args[0]
+	}
+
+	manifestPath := verifyManifest
+	if manifestPath == "" {
+		manifestPath = filepath.Join(dir, "brandfetch.lock")
+	}
+	if verifyKey != "" {
+		if err := verifyManifestSignature(manifestPath, verifyKey); err != nil {
+			return &ExitError{Code: exitVerifySig, Err: err}
+		}
+	}
+	manifest, err := parseSHA256Manifest(manifestPath)
+	if err != nil {
+		return err
+	}
+
+	algo := checksumAlgorithmForManifest(manifestPath, manifest)
+	if verifyAlgorithm != "" {
+		algo, err = parseChecksumAlgorithm(verifyAlgorithm)
+		if err != nil {
+			return err
+		}
+	}
+
+	var scope []string
+	if scoped {
+		scope = manifestDirs(manifest)
+	}
+	report, err := verifyDirectory(dir, manifestPath, manifest, algo, scoped, scope)
+	if err != nil {
+		return err
+	}
+
+	format, _, err := resolveOutput(cmd)
+	if err != nil {
+		return err
+	}
+	if format == output.FormatJSON {
+		if err := output.PrintJSON(cmd.OutOrStdout(), report); err != nil {
+			return err
+		}
+	} else {
+		renderVerifyReport(cmd.OutOrStdout(), report, verifyIgnoreExtra)
+	}
+
+	return verifyResultError(report, verifyIgnoreExtra)
+}
+
+// manifestDirs returns the directories, relative to the manifest root, that
+// contain listed files. The root itself is left out so that top-level entries
+// do not pull every project file into the extras check.
+func manifestDirs(manifest map[string]string) []string {
+	seen := make(map[string]bool)
+	var dirs []string
+	for name := range manifest {
+		parent := path.Dir(filepath.ToSlash(name))
+		if parent != "." && !seen[parent] {
+			seen[parent] = true
+			dirs = append(dirs, parent)
+		}
+	}
+	sort.Strings(dirs)
+	return dirs
+}
+
+// inScope reports whether rel lies at or below one of the scope directories.
+func inScope(rel string, scope []string) bool {
+	for _, dir := range scope {
+		if rel == dir || strings.HasPrefix(rel, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// coversScope reports whether the directory rel contains a scope directory.
+func coversScope(rel string, scope []string) bool {
+	for _, dir := range scope {
+		if strings.HasPrefix(dir, rel+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyDirectory compares files under dir against manifest entries. Unless
+// scoped is set, the whole tree is checked for extra files; when it is, files
+// outside the scope directories are only compared if the manifest lists them.
+func verifyDirectory(dir, manifestPath string, manifest map[string]string, algo string, scoped bool, scope []string) (*verifyReport, error) {
+	report := &verifyReport{
+		Algorithm: algo,
+		Manifest:  manifestPath,
+		OK:        []string{},
+		Changed:   []string{},
+		Missing:   []string{},
+		Extra:     []string{},
+	}
+
+	skip := map[string]bool{}
+	if abs, err := filepath.Abs(manifestPath); err == nil {
+		skip[abs] = true
+	}
+
+	seen := make(map[string]bool)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != dir && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() {
+			if scoped && path != dir && !inScope(rel, scope) && !coversScope(rel, scope) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() || isChecksumSidecar(path) {
+			return nil
+		}
+		if abs, err := filepath.Abs(path); err == nil && skip[abs] {
+			return nil
+		}
+		seen[rel] = true
+
+		expected, ok := manifest[rel]
+		if !ok {
+			if !scoped || inScope(rel, scope) {
+				report.Extra = append(report.Extra, rel)
+			}
+			return nil
+		}
+		sum, err := computeChecksum(path, algo)
+		if err != nil {
+			return err
+		}
+		if strings.EqualFold(sum, expected) {
+			report.OK = append(report.OK, rel)
+		} else {
+			report.Changed = append(report.Changed, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk %s: %w", dir, err)
+	}
+
+	for name := range manifest {
+		if !seen[filepath.ToSlash(name)] {
+			report.Missing = append(report.Missing, name)
+		}
+	}
+
+	sort.Strings(report.OK)
+	sort.Strings(report.Changed)
+	sort.Strings(report.Missing)
+	sort.Strings(report.Extra)
+	return report, nil
+}
+
+// isChecksumSidecar reports whether path is a per-file checksum like logo.svg.sha256
+// or a manifest signature.
+func isChecksumSidecar(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".sha256", ".sha512", ".b2", ".b3", signatureExt:
+		return true
+	}
+	return false
+}
+
+func renderVerifyReport(w io.Writer, report *verifyReport, ignoreExtra bool) {
+	for _, name := range report.Changed {
+		fmt.Fprintf(w, "CHANGED  %s\n", name)
+	}
+	for _, name := range report.Missing {
+		fmt.Fprintf(w, "MISSING  %s\n", name)
+	}
+	if !ignoreExtra {
+		for _, name := range report.Extra {
+			fmt.Fprintf(w, "EXTRA    %s\n", name)
+		}
+	}
+	fmt.Fprintf(w, "%d ok, %d changed, %d missing, %d extra (%s)\n",
+		len(report.OK), len(report.Changed), len(report.Missing), len(report.Extra), report.Algorithm)
+}
+
+func verifyResultError(report *verifyReport, ignoreExtra bool) error {
+	switch {
+	case len(report.Changed) > 0:
+		return &ExitError{Code: exitVerifyChanged, Err: fmt.Errorf("verification failed: %d file(s) changed", len(report.Changed))}
+	case len(report.Missing) > 0:
+		return &ExitError{Code: exitVerifyMissing, Err: fmt.Errorf("verification failed: %d file(s) missing", len(report.Missing))}
+	case len(report.Extra) > 0 && !ignoreExtra:
+		return &ExitError{Code: exitVerifyExtra, Err: fmt.Errorf("verification failed: %d unexpected file(s)", len(report.Extra))}
+	}
+	return nil
+}
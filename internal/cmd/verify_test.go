@@ -0,0 +1,318 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func resetVerifyFlags() {
+	verifyManifest = ""
+	verifyAlgorithm = ""
+	verifyIgnoreExtra = false
+	verifyKey = ""
+}
+
+// writeVerifyFixture creates dir/stripe/logo.svg and dir/styles.css plus a
+// manifest for them using algo.
+func writeVerifyFixture(t *testing.T, algo, manifestName string) (string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"stripe/logo.svg": "<svg></svg>",
+		"styles.css":      ":root {}",
+	}
+	var lines []string
+	for rel, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		sum, err := computeChecksum(path, algo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lines = append(lines, sum+"  "+rel)
+	}
+	manifest := filepath.Join(dir, manifestName)
+	if err := os.WriteFile(manifest, []byte(strings.Join(lines, "\n")+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return dir, manifest
+}
+
+func runVerifyTest(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	var stdout bytes.Buffer
+	cmd := NewVerifyCmd()
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return stdout.String(), err
+}
+
+func TestVerifyCmd_OK(t *testing.T) {
+	resetVerifyFlags()
+	defer resetVerifyFlags()
+	outputFormat = "text"
+
+	dir, _ := writeVerifyFixture(t, algoSHA256, "brandfetch.lock")
+	// Hidden files and sidecars are ignored.
+	if err := os.WriteFile(filepath.Join(dir, ".brandfetch-sync.json"), []byte("{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "styles.css.sha256"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// Download sidecars next to assets are not reported as extra.
+	for _, name := range []string{".logo.svg.part", ".logo.svg.part.etag", ".logo.svg.etag"} {
+		if err := os.WriteFile(filepath.Join(dir, "stripe", name), []byte("x"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out, err := runVerifyTest(t, dir)
+	if err != nil {
+		t.Fatalf("Execute() error = %v (output: %s)", err, out)
+	}
+	if !containsStr(out, "2 ok, 0 changed, 0 missing, 0 extra (sha256)") {
+		t.Errorf("unexpected summary: %s", out)
+	}
+}
+
+func TestVerifyCmd_ExitCodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		mutate   func(t *testing.T, dir string)
+		args     []string
+		wantCode int
+		wantLine string
+	}{
+		{
+			name: "changed",
+			mutate: func(t *testing.T, dir string) {
+				if err := os.WriteFile(filepath.Join(dir, "styles.css"), []byte("changed"), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantCode: exitVerifyChanged,
+			wantLine: "CHANGED  styles.css",
+		},
+		{
+			name: "missing",
+			mutate: func(t *testing.T, dir string) {
+				if err := os.Remove(filepath.Join(dir, "stripe", "logo.svg")); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantCode: exitVerifyMissing,
+			wantLine: "MISSING  stripe/logo.svg",
+		},
+		{
+			name: "extra",
+			mutate: func(t *testing.T, dir string) {
+				if err := os.WriteFile(filepath.Join(dir, "stripe", "favicon.png"), []byte("png"), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantCode: exitVerifyExtra,
+			wantLine: "EXTRA    stripe/favicon.png",
+		},
+		{
+			name: "extra ignored",
+			mutate: func(t *testing.T, dir string) {
+				if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("hi"), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			},
+			args:     []string{"--ignore-extra"},
+			wantCode: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetVerifyFlags()
+			defer resetVerifyFlags()
+			outputFormat = "text"
+
+			dir, _ := writeVerifyFixture(t, algoSHA256, "brandfetch.lock")
+			tt.mutate(t, dir)
+
+			out, err := runVerifyTest(t, append([]string{dir}, tt.args...)...)
+			if got := ExitCode(err); got != tt.wantCode {
+				t.Fatalf("ExitCode() = %d, want %d (err: %v)", got, tt.wantCode, err)
+			}
+			if tt.wantLine != "" && !containsStr(out, tt.wantLine) {
+				t.Errorf("output missing %q: %s", tt.wantLine, out)
+			}
+		})
+	}
+}
+
+func TestVerifyCmd_ProjectRootDefault(t *testing.T) {
+	resetVerifyFlags()
+	defer resetVerifyFlags()
+	outputFormat = "text"
+
+	dir, _ := writeVerifyFixture(t, algoSHA256, "brandfetch.lock")
+	for _, rel := range []string{"brandfetch.yaml", "README.md", "src/main.go"} {
+		path := filepath.Join(dir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(rel), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(wd) }()
+
+	out, err := runVerifyTest(t)
+	if err != nil {
+		t.Fatalf("verify with no arguments: %v (output: %s)", err, out)
+	}
+	if !containsStr(out, "2 ok, 0 changed, 0 missing, 0 extra (sha256)") {
+		t.Errorf("unexpected summary: %s", out)
+	}
+
+	// Directories holding locked assets are still checked for strays.
+	if err := os.WriteFile(filepath.Join(dir, "stripe", "favicon.png"), []byte("png"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out, err = runVerifyTest(t)
+	if ExitCode(err) != exitVerifyExtra || !containsStr(out, "EXTRA    stripe/favicon.png") {
+		t.Errorf("stray asset: err = %v, output: %s", err, out)
+	}
+}
+
+func TestVerifyCmd_ProjectRootOnlyRootFiles(t *testing.T) {
+	resetVerifyFlags()
+	defer resetVerifyFlags()
+	outputFormat = "text"
+
+	dir := t.TempDir()
+	for rel, content := range map[string]string{
+		"brands.css":  ":root {}",
+		"README.md":   "readme",
+		"src/main.go": "package main",
+	} {
+		path := filepath.Join(dir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sum, err := computeChecksum(filepath.Join(dir, "brands.css"), algoSHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "brandfetch.lock"), []byte(sum+"  brands.css\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(wd) }()
+
+	out, err := runVerifyTest(t)
+	if err != nil {
+		t.Fatalf("verify with a root-only lock: %v (output: %s)", err, out)
+	}
+	if !containsStr(out, "1 ok, 0 changed, 0 missing, 0 extra (sha256)") {
+		t.Errorf("unexpected summary: %s", out)
+	}
+}
+
+func TestVerifyCmd_Algorithms(t *testing.T) {
+	tests := []struct {
+		algo     string
+		manifest string
+		flag     string
+	}{
+		{algoSHA512, "checksums.sha512", ""},
+		{algoBLAKE2b, "checksums.b2", ""},
+		{algoBLAKE3, "checksums.b3", ""},
+		{algoBLAKE3, "checksums.txt", "blake3"},
+		{algoSHA512, "checksums.txt", ""}, // inferred from digest length
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.manifest+"/"+tt.algo, func(t *testing.T) {
+			resetVerifyFlags()
+			defer resetVerifyFlags()
+			outputFormat = "json"
+			defer func() { outputFormat = "text" }()
+
+			dir, manifest := writeVerifyFixture(t, tt.algo, tt.manifest)
+			args := []string{dir, "--manifest", manifest}
+			if tt.flag != "" {
+				args = append(args, "--algorithm", tt.flag)
+			}
+			out, err := runVerifyTest(t, args...)
+			if err != nil {
+				t.Fatalf("Execute() error = %v (output: %s)", err, out)
+			}
+
+			var report verifyReport
+			if err := json.Unmarshal([]byte(out), &report); err != nil {
+				t.Fatalf("output not valid JSON: %v", err)
+			}
+			if report.Algorithm != tt.algo || len(report.OK) != 2 {
+				t.Errorf("report = %+v, want %s with 2 ok", report, tt.algo)
+			}
+		})
+	}
+}
+
+func TestParseChecksumAlgorithm(t *testing.T) {
+	for in, want := range map[string]string{
+		"SHA-256": algoSHA256,
+		"sha512":  algoSHA512,
+		"b2":      algoBLAKE2b,
+		"BLAKE3":  algoBLAKE3,
+	} {
+		got, err := parseChecksumAlgorithm(in)
+		if err != nil || got != want {
+			t.Errorf("parseChecksumAlgorithm(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := parseChecksumAlgorithm("md5"); err == nil {
+		t.Error("expected error for md5")
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	if got := ExitCode(nil); got != 0 {
+		t.Errorf("ExitCode(nil) = %d, want 0", got)
+	}
+	if got := ExitCode(errors.New("boom")); got != 1 {
+		t.Errorf("ExitCode(error) = %d, want 1", got)
+	}
+	wrapped := errors.Join(errors.New("context"), &ExitError{Code: 3, Err: errors.New("missing")})
+	if got := ExitCode(wrapped); got != 3 {
+		t.Errorf("ExitCode(wrapped) = %d, want 3", got)
+	}
+}
@@ -0,0 +1,498 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/brandfetch-cli/internal/api"
+	"github.com/salmonumbrella/brandfetch-cli/internal/branddiff"
+	"github.com/salmonumbrella/brandfetch-cli/internal/output"
+)
+
+// watchStateFile is the state watch keeps in the --state directory.
+const watchStateFile = "watch.json"
+
+// watchPostTimeout bounds a --post request, so a hung endpoint cannot stall
+// the watch loop.
+const watchPostTimeout = 30 * time.Second
+
+// Watch result statuses.
+const (
+	watchBaseline  = "baseline"
+	watchChanged   = "changed"
+	watchUnchanged = "unchanged"
+	watchSkipped   = "skipped"
+	watchFailed    = "error"
+)
+
+var (
+	watchInterval  time.Duration
+	watchStateDir  string
+	watchBrandFile string
+	watchOnce      bool
+	watchBudget    int
+	watchHash      bool
+	watchExec      string
+	watchPost      string
+	watchReport    string
+)
+
+// watchNow is the clock used for check times, replaced in tests.
+var watchNow = time.Now
+
+type watchState struct {
+	Brands map[string]*watchBrandState `json:"brands"`
+}
+
+type watchBrandState struct {
+	Snapshot  string    `json:"snapshot"`
+	CheckedAt time.Time `json:"checked_at"`
+	ChangedAt time.Time `json:"changed_at,omitempty"`
+}
+
+type watchResult struct {
+	Identifier string             `json:"identifier"`
+	Status     string             `json:"status"`
+	Changes    []branddiff.Change `json:"changes,omitempty"`
+	Error      string             `json:"error,omitempty"`
+
+	diff *branddiff.Diff
+}
+
+type watchRun struct {
+	CheckedAt time.Time     `json:"checked_at"`
+	Brands    []watchResult `json:"brands"`
+	Checked   int           `json:"checked"`
+	Changed   int           `json:"changed"`
+	Skipped   int           `json:"skipped"`
+	Failed    int           `json:"failed"`
+}
+
+// watchEvent is sent to --exec and --post when a brand changed.
+type watchEvent struct {
+	Event      string                `json:"event"`
+	Identifier string                `json:"identifier"`
+	Name       string                `json:"name"`
+	Domain     string                `json:"domain"`
+	CheckedAt  time.Time             `json:"checked_at"`
+	Changes    []branddiff.Change    `json:"changes"`
+	Patch      []branddiff.Operation `json:"patch"`
+}
+
+// NewWatchCmd creates the watch command.
+func NewWatchCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "watch [identifier...] --state <dir>",
+		Short: "Poll brands and act when they change",
+		Long: `Periodically fetch brands, keep a snapshot of each and report what changed.
+
+The first check of a brand saves a baseline snapshot. Later checks compare the
+live brand against it (see "brandfetch diff") and, when it changed, run the
+configured actions and replace the snapshot:
+
+  --exec     run a shell command with the change event as JSON on stdin and
+             BRANDFETCH_IDENTIFIER, BRANDFETCH_DOMAIN and BRANDFETCH_CHANGES set
+  --post     POST the change event as JSON to a URL
+  --report   append a Markdown table of the changes to a file
+
+If an action fails the old snapshot is kept, so the change is reported again
+on the next run.
+
+Every check uses the Brand API which has limited quota. --budget caps the
+requests per run; brands checked least recently go first, so the rest are
+picked up by later runs. Snapshots and check times are kept in the --state
+directory (` + watchStateFile + `), which also supplies the brand list when
+no identifiers are given.
+
+Examples:
+  brandfetch watch stripe.com github.com --state ./brand-watch
+  brandfetch watch --file brands.txt --state ./brand-watch --budget 20 --interval 12h
+  brandfetch watch stripe.com --state ./brand-watch --once --report changes.md
+  brandfetch watch --state ./brand-watch --post http://localhost:3000/hooks/brand
+  brandfetch watch --state ./brand-watch --exec 'jq -r .domain >> changed.txt'`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := createClient(clientRequirements{requireAPIKey: true})
+			if err != nil {
+				return err
+			}
+			httpClient, err := newDownloadClient()
+			if err != nil {
+				return err
+			}
+			return runWatchCmd(cmd, args, client, httpClient)
+		},
+	}
+
+	addWatchFlags(cmd)
+
+	return cmd
+}
+
+func newWatchCmdWithClients(client APIClient, httpClient HTTPClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "watch [identifier...]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWatchCmd(cmd, args, client, httpClient)
+		},
+	}
+	addWatchFlags(cmd)
+	return cmd
+}
+
+func addWatchFlags(cmd *cobra.Command) {
+	cmd.Flags().DurationVar(&watchInterval, "interval", 24*time.Hour, "Time between runs")
+	cmd.Flags().StringVar(&watchStateDir, "state", "", "Directory for snapshots and watch state (required)")
+	cmd.Flags().StringVar(&watchBrandFile, "file", "", "Read identifiers from a file (one per line)")
+	cmd.Flags().BoolVar(&watchOnce, "once", false, "Run once and exit (for cron)")
+	cmd.Flags().IntVar(&watchBudget, "budget", 0, "Maximum Brand API requests per run (0 = no limit)")
+	cmd.Flags().BoolVar(&watchHash, "hash", false, "Compare logos by the SHA-256 of their content instead of their URLs")
+	cmd.Flags().StringVar(&watchExec, "exec", "", "Shell command to run when a brand changed")
+	cmd.Flags().StringVar(&watchPost, "post", "", "URL to POST change events to")
+	cmd.Flags().StringVar(&watchReport, "report", "", "Markdown file to append changes to")
+}
+
+func runWatchCmd(cmd *cobra.Command, args []string, client APIClient, httpClient HTTPClient) error {
+	if watchStateDir == "" {
+		return fmt.Errorf("--state is required")
+	}
+	if watchInterval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+	if watchBudget < 0 {
+		return fmt.Errorf("--budget must not be negative")
+	}
+	format, _, err := resolveOutput(cmd)
+	if err != nil {
+		return err
+	}
+
+	statePath := filepath.Join(watchStateDir, watchStateFile)
+	state, err := loadWatchState(statePath)
+	if err != nil {
+		return err
+	}
+
+	identifiers := args
+	if watchBrandFile != "" {
+		fromFile, err := readIdentifierFile(watchBrandFile)
+		if err != nil {
+			return err
+		}
+		identifiers = append(identifiers, fromFile...)
+	}
+	if len(identifiers) == 0 {
+		for id := range state.Brands {
+			identifiers = append(identifiers, id)
+		}
+		sort.Strings(identifiers)
+	}
+	identifiers = dedupeStrings(identifiers)
+	if len(identifiers) == 0 {
+		return fmt.Errorf("no brands to watch: pass identifiers, --file, or a --state directory from a previous run")
+	}
+
+	if err := os.MkdirAll(watchStateDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", watchStateDir, err)
+	}
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	for {
+		// Hashes are cached for one run only; content behind a URL may change.
+		var opts branddiff.Options
+		if watchHash {
+			opts.Hash = logoHasher(httpClient)
+		}
+		run := runWatchPass(ctx, cmd, client, httpClient, identifiers, state, opts)
+		if err := saveWatchState(statePath, state); err != nil {
+			return err
+		}
+		if format == output.FormatJSON {
+			if err := output.PrintJSON(cmd.OutOrStdout(), run); err != nil {
+				return err
+			}
+		} else {
+			renderWatchRun(cmd.OutOrStdout(), run, watchInterval, watchOnce)
+		}
+
+		if watchOnce {
+			if run.Failed > 0 {
+				return fmt.Errorf("failed to check %d brand(s)", run.Failed)
+			}
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(watchInterval):
+		}
+	}
+}
+
+// runWatchPass checks up to --budget brands, least recently checked first.
+func runWatchPass(ctx context.Context, cmd *cobra.Command, client APIClient, httpClient HTTPClient, identifiers []string, state *watchState, opts branddiff.Options) *watchRun {
+	run := &watchRun{CheckedAt: watchNow().UTC()}
+
+	order := append([]string(nil), identifiers...)
+	sort.SliceStable(order, func(i, j int) bool {
+		return lastChecked(state, order[i]).Before(lastChecked(state, order[j]))
+	})
+	due := make(map[string]bool, len(order))
+	for i, id := range order {
+		if watchBudget == 0 || i < watchBudget {
+			due[id] = true
+		}
+	}
+
+	for _, id := range identifiers {
+		if !due[id] {
+			run.Brands = append(run.Brands, watchResult{Identifier: id, Status: watchSkipped})
+			run.Skipped++
+			continue
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		result := watchBrand(ctx, cmd, client, httpClient, id, state, opts, run.CheckedAt)
+		run.Checked++
+		switch result.Status {
+		case watchChanged:
+			run.Changed++
+		case watchFailed:
+			run.Failed++
+		}
+		run.Brands = append(run.Brands, result)
+	}
+	return run
+}
+
+func lastChecked(state *watchState, id string) time.Time {
+	if b := state.Brands[id]; b != nil {
+		return b.CheckedAt
+	}
+	return time.Time{}
+}
+
+func watchBrand(ctx context.Context, cmd *cobra.Command, client APIClient, httpClient HTTPClient, id string, state *watchState, opts branddiff.Options, now time.Time) watchResult {
+	result := watchResult{Identifier: id}
+	fail := func(err error) watchResult {
+		result.Status = watchFailed
+		result.Error = err.Error()
+		return result
+	}
+
+	// A failed check still spends quota, so it counts for --budget rotation.
+	prev := state.Brands[id]
+	if prev == nil {
+		prev = &watchBrandState{}
+		state.Brands[id] = prev
+	}
+	// The snapshot name is rebuilt rather than trusted from watch.json, so an
+	// edited state file cannot point outside the state directory.
+	prev.Snapshot = watchSnapshotName(id)
+	prev.CheckedAt = now
+
+	brand, err := client.GetBrand(ctx, id)
+	if err != nil {
+		return fail(err)
+	}
+
+	path := filepath.Join(watchStateDir, prev.Snapshot)
+	old, err := loadBrandSnapshot(path)
+	if errors.Is(err, os.ErrNotExist) {
+		if err := writeBrandSnapshot(path, brand); err != nil {
+			return fail(err)
+		}
+		result.Status = watchBaseline
+		return result
+	}
+	if err != nil {
+		return fail(err)
+	}
+
+	d := branddiff.Compare(old, brand, opts)
+	if len(d.Changes) == 0 {
+		result.Status = watchUnchanged
+		return result
+	}
+	result.Changes = d.Changes
+	result.diff = d
+
+	event := watchEvent{
+		Event:      "brand.changed",
+		Identifier: id,
+		Name:       d.Name,
+		Domain:     d.Domain,
+		CheckedAt:  now,
+		Changes:    d.Changes,
+		Patch:      d.Patch(),
+	}
+	if err := runWatchActions(ctx, cmd, httpClient, event, d); err != nil {
+		return fail(err)
+	}
+	if err := writeBrandSnapshot(path, brand); err != nil {
+		return fail(err)
+	}
+	prev.ChangedAt = now
+	result.Status = watchChanged
+	return result
+}
+
+func runWatchActions(ctx context.Context, cmd *cobra.Command, httpClient HTTPClient, event watchEvent, d *branddiff.Diff) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if watchExec != "" {
+		shell, flag := "sh", "-c"
+		if runtime.GOOS == "windows" {
+			shell, flag = "cmd", "/C"
+		}
+		c := exec.CommandContext(ctx, shell, flag, watchExec)
+		c.Stdin = bytes.NewReader(payload)
+		c.Stdout = cmd.ErrOrStderr()
+		c.Stderr = cmd.ErrOrStderr()
+		c.Env = append(os.Environ(),
+			"BRANDFETCH_IDENTIFIER="+event.Identifier,
+			"BRANDFETCH_DOMAIN="+event.Domain,
+			"BRANDFETCH_CHANGES="+strconv.Itoa(len(event.Changes)),
+		)
+		if err := c.Run(); err != nil {
+			return fmt.Errorf("--exec failed: %w", err)
+		}
+	}
+
+	if watchPost != "" {
+		postCtx, cancel := context.WithTimeout(ctx, watchPostTimeout)
+		defer cancel()
+		req, err := http.NewRequestWithContext(postCtx, http.MethodPost, watchPost, bytes.NewReader(payload))
+		if err != nil {
+			return fmt.Errorf("--post failed: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("--post failed: %w", err)
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return fmt.Errorf("--post failed: HTTP %d", resp.StatusCode)
+		}
+	}
+
+	if watchReport != "" {
+		f, err := os.OpenFile(watchReport, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return fmt.Errorf("--report failed: %w", err)
+		}
+		_, err = fmt.Fprintf(f, "## %s\n\n%s\n", event.CheckedAt.Format(time.RFC3339), d.Markdown())
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return fmt.Errorf("--report failed: %w", err)
+		}
+	}
+	return nil
+}
+
+func watchSnapshotName(id string) string {
+	return sanitizeFileName(api.NormalizeIdentifier(id)) + ".json"
+}
+
+// writeBrandSnapshot stores the API response, indented, so snapshots keep
+// fields the typed model does not know about and can be read by diff.
+func writeBrandSnapshot(path string, brand *api.Brand) error {
+	var buf bytes.Buffer
+	if len(brand.Raw) == 0 || json.Indent(&buf, brand.Raw, "", "  ") != nil {
+		buf.Reset()
+		data, err := json.MarshalIndent(brand, "", "  ")
+		if err != nil {
+			return err
+		}
+		buf.Write(data)
+	}
+	buf.WriteByte('\n')
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
+
+func loadWatchState(path string) (*watchState, error) {
+	state := &watchState{Brands: make(map[string]*watchBrandState)}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return state, nil
+		}
+		return nil, fmt.Errorf("failed to read watch state: %w", err)
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse watch state %s: %w", path, err)
+	}
+	if state.Brands == nil {
+		state.Brands = make(map[string]*watchBrandState)
+	}
+	return state, nil
+}
+
+func saveWatchState(path string, state *watchState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+func renderWatchRun(w io.Writer, run *watchRun, interval time.Duration, once bool) {
+	fmt.Fprintf(w, "%s\n", run.CheckedAt.Format(time.RFC3339))
+	for _, b := range run.Brands {
+		switch b.Status {
+		case watchBaseline:
+			fmt.Fprintf(w, "  + %s: baseline saved\n", b.Identifier)
+		case watchUnchanged:
+			fmt.Fprintf(w, "  = %s: no changes\n", b.Identifier)
+		case watchChanged:
+			lines := strings.Split(strings.TrimRight(b.diff.Text(), "\n"), "\n")
+			fmt.Fprintf(w, "  ~ %s\n", lines[0])
+			for _, line := range lines[1:] {
+				fmt.Fprintf(w, "  %s\n", line)
+			}
+		case watchFailed:
+			fmt.Fprintf(w, "  ! %s: %s\n", b.Identifier, b.Error)
+		}
+	}
+	fmt.Fprintf(w, "%d checked, %d changed, %d failed", run.Checked, run.Changed, run.Failed)
+	if run.Skipped > 0 {
+		fmt.Fprintf(w, ", %d deferred by --budget", run.Skipped)
+	}
+	fmt.Fprintln(w)
+	if !once {
+		fmt.Fprintf(w, "Next run in %s\n\n", interval)
+	}
+}
@@ -0,0 +1,250 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/salmonumbrella/brandfetch-cli/internal/api"
+)
+
+func resetWatchFlags() {
+	watchInterval = 24 * time.Hour
+	watchStateDir = ""
+	watchBrandFile = ""
+	watchOnce = false
+	watchBudget = 0
+	watchHash = false
+	watchExec = ""
+	watchPost = ""
+	watchReport = ""
+}
+
+// watchTestClient serves brands whose accent color can be changed between runs.
+type watchTestClient struct {
+	MockAPIClient
+	accent   map[string]string
+	requests []string
+}
+
+func newWatchTestClient() *watchTestClient {
+	c := &watchTestClient{accent: map[string]string{}}
+	c.GetBrandFunc = func(ctx context.Context, domain string) (*api.Brand, error) {
+		c.requests = append(c.requests, domain)
+		hex := c.accent[domain]
+		if hex == "" {
+			hex = "#635bff"
+		}
+		raw := `{"name":"` + domain + `","domain":"` + domain + `","colors":[{"hex":"` + hex + `","type":"accent"}],"newField":true}`
+		var brand api.Brand
+		if err := json.Unmarshal([]byte(raw), &brand); err != nil {
+			return nil, err
+		}
+		brand.Raw = json.RawMessage(raw)
+		return &brand, nil
+	}
+	return c
+}
+
+func runWatchTest(t *testing.T, client APIClient, httpClient HTTPClient, args ...string) (string, error) {
+	t.Helper()
+	var stdout bytes.Buffer
+	cmd := newWatchCmdWithClients(client, httpClient)
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(append(args, "--once"))
+	err := cmd.Execute()
+	return stdout.String(), err
+}
+
+func TestWatchCmd_BaselineThenChange(t *testing.T) {
+	resetWatchFlags()
+	outputFormat = "text"
+	state := t.TempDir()
+	report := filepath.Join(t.TempDir(), "changes.md")
+	client := newWatchTestClient()
+
+	var posted []byte
+	httpClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			if req.Method != http.MethodPost || req.URL.String() != "http://localhost:3000/hook" {
+				t.Errorf("unexpected request %s %s", req.Method, req.URL)
+			}
+			if _, ok := req.Context().Deadline(); !ok {
+				t.Error("--post request has no deadline")
+			}
+			posted, _ = io.ReadAll(req.Body)
+			return &http.Response{StatusCode: http.StatusNoContent, Body: io.NopCloser(strings.NewReader(""))}, nil
+		},
+	}
+	args := []string{"stripe.com", "--state", state, "--post", "http://localhost:3000/hook", "--report", report}
+
+	out, err := runWatchTest(t, client, httpClient, args...)
+	if err != nil {
+		t.Fatalf("first run: %v", err)
+	}
+	if !containsStr(out, "+ stripe.com: baseline saved") {
+		t.Errorf("first run output:\n%s", out)
+	}
+	snapshot, err := os.ReadFile(filepath.Join(state, "stripe.com.json"))
+	if err != nil || !containsStr(string(snapshot), `"newField": true`) {
+		t.Fatalf("snapshot = %s, %v; want the indented raw response", snapshot, err)
+	}
+
+	out, err = runWatchTest(t, client, httpClient, args...)
+	if err != nil || !containsStr(out, "= stripe.com: no changes") {
+		t.Errorf("second run output:\n%s (err %v)", out, err)
+	}
+	if posted != nil {
+		t.Error("unchanged brand was posted")
+	}
+
+	client.accent["stripe.com"] = "#533afd"
+	out, err = runWatchTest(t, client, httpClient, args...)
+	if err != nil {
+		t.Fatalf("third run: %v", err)
+	}
+	if !containsStr(out, "~ stripe.com (stripe.com): 1 change") || !containsStr(out, "~ colors[accent]  #635bff -> #533afd") {
+		t.Errorf("third run output:\n%s", out)
+	}
+
+	var event watchEvent
+	if err := json.Unmarshal(posted, &event); err != nil {
+		t.Fatalf("posted body %q: %v", posted, err)
+	}
+	if event.Event != "brand.changed" || event.Identifier != "stripe.com" || len(event.Changes) != 1 || len(event.Patch) != 1 {
+		t.Errorf("event = %+v", event)
+	}
+	md, err := os.ReadFile(report)
+	if err != nil || !containsStr(string(md), "| colors[accent] | changed | `#635bff` | `#533afd`") {
+		t.Errorf("report = %s, %v", md, err)
+	}
+	snapshot, _ = os.ReadFile(filepath.Join(state, "stripe.com.json"))
+	if !containsStr(string(snapshot), "#533afd") {
+		t.Error("snapshot was not updated after the change")
+	}
+}
+
+func TestWatchCmd_FailedActionKeepsSnapshot(t *testing.T) {
+	resetWatchFlags()
+	outputFormat = "text"
+	state := t.TempDir()
+	client := newWatchTestClient()
+	httpClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusBadGateway, Body: io.NopCloser(strings.NewReader(""))}, nil
+		},
+	}
+	args := []string{"stripe.com", "--state", state, "--post", "http://localhost:3000/hook"}
+
+	if _, err := runWatchTest(t, client, httpClient, args...); err != nil {
+		t.Fatal(err)
+	}
+	client.accent["stripe.com"] = "#533afd"
+	out, err := runWatchTest(t, client, httpClient, args...)
+	if err == nil || !containsStr(out, "! stripe.com: --post failed: HTTP 502") {
+		t.Errorf("output:\n%s (err %v)", out, err)
+	}
+	snapshot, _ := os.ReadFile(filepath.Join(state, "stripe.com.json"))
+	if containsStr(string(snapshot), "#533afd") {
+		t.Error("snapshot updated although the action failed")
+	}
+}
+
+func TestWatchCmd_Budget(t *testing.T) {
+	resetWatchFlags()
+	outputFormat = "json"
+	defer func() { outputFormat = "text" }()
+	state := t.TempDir()
+	client := newWatchTestClient()
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	watchNow = func() time.Time { return now }
+	defer func() { watchNow = time.Now }()
+
+	args := []string{"a.com", "b.com", "c.com", "--state", state, "--budget", "2"}
+	out, err := runWatchTest(t, client, &MockHTTPClient{}, args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var run watchRun
+	if err := json.Unmarshal([]byte(out), &run); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out)
+	}
+	if run.Checked != 2 || run.Skipped != 1 || run.Brands[2].Status != watchSkipped {
+		t.Errorf("first run = %+v", run)
+	}
+
+	now = now.Add(time.Hour)
+	client.requests = nil
+	if _, err := runWatchTest(t, client, &MockHTTPClient{}, args...); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(client.requests, ",") != "a.com,c.com" {
+		t.Errorf("second run fetched %v, want never-checked c.com plus a.com", client.requests)
+	}
+}
+
+func TestWatchCmd_Exec(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	resetWatchFlags()
+	outputFormat = "text"
+	state := t.TempDir()
+	marker := filepath.Join(t.TempDir(), "changed.txt")
+	client := newWatchTestClient()
+	args := []string{"stripe.com", "--state", state, "--exec", `echo "$BRANDFETCH_DOMAIN $BRANDFETCH_CHANGES" > ` + marker}
+
+	if _, err := runWatchTest(t, client, &MockHTTPClient{}, args...); err != nil {
+		t.Fatal(err)
+	}
+	client.accent["stripe.com"] = "#533afd"
+	if _, err := runWatchTest(t, client, &MockHTTPClient{}, args...); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(marker)
+	if err != nil || strings.TrimSpace(string(data)) != "stripe.com 1" {
+		t.Errorf("exec wrote %q, %v", data, err)
+	}
+}
+
+func TestWatchCmd_IgnoresStoredSnapshotPath(t *testing.T) {
+	resetWatchFlags()
+	outputFormat = "text"
+	root := t.TempDir()
+	state := filepath.Join(root, "state")
+	if err := os.MkdirAll(state, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	stored := `{"brands":{"stripe.com":{"snapshot":"../escape.json"}}}`
+	if err := os.WriteFile(filepath.Join(state, watchStateFile), []byte(stored), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := runWatchTest(t, newWatchTestClient(), &MockHTTPClient{}, "stripe.com", "--state", state); err != nil {
+		t.Fatalf("watch: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "escape.json")); !os.IsNotExist(err) {
+		t.Errorf("snapshot written outside the state directory (stat err %v)", err)
+	}
+	if _, err := os.Stat(filepath.Join(state, "stripe.com.json")); err != nil {
+		t.Errorf("snapshot not written to the state directory: %v", err)
+	}
+}
+
+func TestWatchCmd_RequiresState(t *testing.T) {
+	resetWatchFlags()
+	_, err := runWatchTest(t, newWatchTestClient(), &MockHTTPClient{}, "stripe.com")
+	if err == nil || !containsStr(err.Error(), "--state is required") {
+		t.Errorf("error = %v", err)
+	}
+}
@@ -97,6 +97,7 @@ Examples:
 	cmd.AddCommand(newWebhooksListCmd())
 	cmd.AddCommand(newWebhooksSubscribeCmd())
 	cmd.AddCommand(newWebhooksUnsubscribeCmd())
+	cmd.AddCommand(newWebhooksListenCmd())
 
 	return cmd
 }
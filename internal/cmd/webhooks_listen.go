@@ -0,0 +1,392 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/brandfetch-cli/internal/api"
+	"github.com/salmonumbrella/brandfetch-cli/internal/config"
+	"github.com/salmonumbrella/brandfetch-cli/internal/output"
+)
+
+// webhookMaxBody caps the size of a webhook delivery.
+const webhookMaxBody = 1 << 20
+
+var (
+	webhooksListenHost      string
+	webhooksListenPort      int
+	webhooksListenSecret    string
+	webhooksListenHeader    string
+	webhooksListenForward   string
+	webhooksListenKeepGoing bool
+	webhooksListenMaxEvents int
+)
+
+// webhookDelivery is the outcome of one request to the receiver.
+type webhookDelivery struct {
+	verified bool
+	err      error
+}
+
+// webhookReceiver verifies, prints and optionally forwards webhook deliveries.
+type webhookReceiver struct {
+	secret     []byte
+	header     string
+	forward    string
+	httpClient HTTPClient
+	format     output.Format
+	out        io.Writer
+	errOut     io.Writer
+	deliveries chan webhookDelivery
+	done       chan struct{}
+	now        func() time.Time
+
+	mu sync.Mutex // serializes output from concurrent deliveries
+}
+
+type webhookEventOutput struct {
+	ReceivedAt time.Time       `json:"received_at"`
+	Type       string          `json:"type"`
+	Payload    json.RawMessage `json:"payload"`
+}
+
+func newWebhooksListenCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "listen",
+		Short: "Receive webhook events locally",
+		Long: `Run an HTTP server that receives Brandfetch webhook deliveries.
+
+Each delivery is verified against the shared secret before it is printed or
+forwarded: the signature header must hold the HMAC-SHA256 of the raw request
+body, hex or base64 encoded, optionally prefixed with "sha256=". Requests with
+a missing or wrong signature are answered with 401.
+
+brand.updated and brand.verified events are summarised; other events are
+printed as JSON. With --output json every event is printed as one JSON line.
+With --forward, verified deliveries are re-sent unchanged to another URL, such
+as an app running locally; if it fails, the sender gets a 502 so it retries.
+
+The command exits with status 5 on the first verification failure, or with
+--keep-going when it is stopped after one. Expose the port with a tunnel and
+register the public URL with "brandfetch webhooks create".
+
+Examples:
+  brandfetch webhooks listen --port 8080 --secret env:WEBHOOK_SECRET
+  BRANDFETCH_WEBHOOK_SECRET=... brandfetch webhooks listen --forward http://localhost:3000/webhooks
+  brandfetch webhooks listen --keep-going --output json | jq .type
+  brandfetch webhooks listen --max-events 1   # Exit after one verified event`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWebhooksListenCmd(cmd, &http.Client{Timeout: 30 * time.Second})
+		},
+	}
+
+	cmd.Flags().StringVar(&webhooksListenHost, "host", "127.0.0.1", "Address to listen on")
+	cmd.Flags().IntVar(&webhooksListenPort, "port", 8080, "Port to listen on")
+	cmd.Flags().StringVar(&webhooksListenSecret, "secret", "",
+		"Shared webhook secret, or a secret reference (env:, file:, exec:, op://); defaults to $BRANDFETCH_WEBHOOK_SECRET")
+	cmd.Flags().StringVar(&webhooksListenHeader, "signature-header", "X-Brandfetch-Signature", "Header carrying the signature")
+	cmd.Flags().StringVar(&webhooksListenForward, "forward", "", "Forward verified deliveries to this URL")
+	cmd.Flags().BoolVar(&webhooksListenKeepGoing, "keep-going", false, "Keep listening after a verification failure")
+	cmd.Flags().IntVar(&webhooksListenMaxEvents, "max-events", 0, "Exit after this many verified events (0 = no limit)")
+
+	return cmd
+}
+
+func runWebhooksListenCmd(cmd *cobra.Command, httpClient HTTPClient) error {
+	// Read the env var here, not as the flag default, so it never shows in help.
+	secretRef := webhooksListenSecret
+	if secretRef == "" {
+		secretRef = os.Getenv("BRANDFETCH_WEBHOOK_SECRET")
+	}
+	if secretRef == "" {
+		return fmt.Errorf("--secret or BRANDFETCH_WEBHOOK_SECRET is required")
+	}
+	secret, err := config.ResolveSecret(secretRef)
+	if err != nil {
+		return fmt.Errorf("failed to resolve webhook secret: %w", err)
+	}
+	if webhooksListenForward != "" {
+		if u, err := url.Parse(webhooksListenForward); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid --forward URL: %s (must be http or https)", webhooksListenForward)
+		}
+	}
+	format, _, err := resolveOutput(cmd)
+	if err != nil {
+		return err
+	}
+
+	addr := net.JoinHostPort(webhooksListenHost, strconv.Itoa(webhooksListenPort))
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	receiver := &webhookReceiver{
+		secret:     []byte(secret),
+		header:     webhooksListenHeader,
+		forward:    webhooksListenForward,
+		httpClient: httpClient,
+		format:     format,
+		out:        cmd.OutOrStdout(),
+		errOut:     cmd.ErrOrStderr(),
+		deliveries: make(chan webhookDelivery, 16),
+		done:       make(chan struct{}),
+		now:        time.Now,
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "Listening for webhooks on http://%s (Ctrl+C to stop)\n", ln.Addr())
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return serveWebhooks(ctx, ln, receiver)
+}
+
+// serveWebhooks serves receiver on ln until ctx is done, --max-events is
+// reached or a delivery fails verification without --keep-going.
+func serveWebhooks(ctx context.Context, ln net.Listener, receiver *webhookReceiver) error {
+	server := &http.Server{Handler: receiver, ReadHeaderTimeout: 10 * time.Second}
+	errCh := make(chan error, 1)
+	go func() { errCh <- server.Serve(ln) }()
+
+	// Handlers stop reporting deliveries once the loop below has returned.
+	var once sync.Once
+	stopReporting := func() { once.Do(func() { close(receiver.done) }) }
+	defer stopReporting()
+
+	shutdown := func() {
+		stopReporting()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}
+
+	events, failures := 0, 0
+	for {
+		select {
+		case err := <-errCh:
+			if errors.Is(err, http.ErrServerClosed) {
+				err = nil
+			}
+			return err
+		case <-ctx.Done():
+			shutdown()
+			return webhookFailuresError(failures)
+		case d := <-receiver.deliveries:
+			if !d.verified {
+				failures++
+				if !webhooksListenKeepGoing {
+					shutdown()
+					return &ExitError{Code: exitVerifySig, Err: fmt.Errorf("webhook verification failed: %w", d.err)}
+				}
+				continue
+			}
+			events++
+			if webhooksListenMaxEvents > 0 && events >= webhooksListenMaxEvents {
+				shutdown()
+				return webhookFailuresError(failures)
+			}
+		}
+	}
+}
+
+func webhookFailuresError(failures int) error {
+	if failures == 0 {
+		return nil
+	}
+	return &ExitError{Code: exitVerifySig, Err: fmt.Errorf("%d webhook delivery(s) failed verification", failures)}
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, req.Body, webhookMaxBody))
+	if err != nil {
+		http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	if err := verifyWebhookSignature(r.secret, body, req.Header.Get(r.header)); err != nil {
+		fmt.Fprintf(r.errOut, "Rejected delivery from %s: %v\n", req.RemoteAddr, err)
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		r.report(webhookDelivery{err: err})
+		return
+	}
+
+	if !json.Valid(body) {
+		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		return
+	}
+	r.printEvent(body)
+
+	status := http.StatusOK
+	if r.forward != "" {
+		if err := r.forwardDelivery(req, body); err != nil {
+			fmt.Fprintf(r.errOut, "Forward to %s failed: %v\n", r.forward, err)
+			status = http.StatusBadGateway
+		}
+	}
+	w.WriteHeader(status)
+	r.report(webhookDelivery{verified: true})
+}
+
+func (r *webhookReceiver) report(d webhookDelivery) {
+	select {
+	case r.deliveries <- d:
+	case <-r.done:
+	}
+}
+
+// verifyWebhookSignature checks that signature is the HMAC-SHA256 of body.
+func verifyWebhookSignature(secret, body []byte, signature string) error {
+	signature = strings.TrimSpace(signature)
+	if signature == "" {
+		return fmt.Errorf("missing signature")
+	}
+	signature = strings.TrimPrefix(signature, "sha256=")
+
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		if got, err = base64.StdEncoding.DecodeString(signature); err != nil {
+			return fmt.Errorf("malformed signature")
+		}
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return fmt.Errorf("signature mismatch")
+	}
+	return nil
+}
+
+func (r *webhookReceiver) printEvent(body []byte) {
+	var envelope struct {
+		Type  string          `json:"type"`
+		Event string          `json:"event"`
+		URN   string          `json:"urn"`
+		Data  json.RawMessage `json:"data"`
+		Brand json.RawMessage `json:"brand"`
+	}
+	_ = json.Unmarshal(body, &envelope)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	eventType := envelope.Type
+	if eventType == "" {
+		eventType = envelope.Event
+	}
+	now := r.now().UTC()
+
+	if r.format == output.FormatJSON {
+		line, _ := json.Marshal(webhookEventOutput{ReceivedAt: now, Type: eventType, Payload: body})
+		fmt.Fprintln(r.out, string(line))
+		return
+	}
+
+	brand := webhookBrand(envelope.Brand, envelope.Data)
+	if brand == nil || (eventType != "brand.updated" && eventType != "brand.verified") {
+		var pretty bytes.Buffer
+		_ = json.Indent(&pretty, body, "  ", "  ")
+		fmt.Fprintf(r.out, "%s %s\n  %s\n", now.Format(time.RFC3339), eventOrUnknown(eventType), pretty.String())
+		return
+	}
+
+	title := brand.Domain
+	if brand.Name != "" {
+		title = fmt.Sprintf("%s (%s)", brand.Name, brand.Domain)
+	}
+	fmt.Fprintf(r.out, "%s %s  %s\n", now.Format(time.RFC3339), eventType, title)
+	urn := brand.URN
+	if urn == "" {
+		urn = envelope.URN
+	}
+	if urn != "" {
+		fmt.Fprintf(r.out, "  urn: %s\n", urn)
+	}
+	if eventType == "brand.verified" {
+		fmt.Fprintf(r.out, "  claimed: %t\n", brand.Claimed)
+	}
+	fmt.Fprintf(r.out, "  logos: %d, colors: %d, fonts: %d, links: %d\n", len(brand.Logos), len(brand.Colors), len(brand.Fonts), len(brand.Links))
+}
+
+// webhookBrand finds the brand in a delivery: under "brand", "data.brand" or
+// as "data" itself.
+func webhookBrand(brandField, data json.RawMessage) *api.Brand {
+	candidates := []json.RawMessage{brandField}
+	var nested struct {
+		Brand json.RawMessage `json:"brand"`
+	}
+	if json.Unmarshal(data, &nested) == nil {
+		candidates = append(candidates, nested.Brand)
+	}
+	candidates = append(candidates, data)
+	for _, raw := range candidates {
+		if len(raw) == 0 {
+			continue
+		}
+		var brand api.Brand
+		if json.Unmarshal(raw, &brand) == nil && (brand.Domain != "" || brand.Name != "") {
+			return &brand
+		}
+	}
+	return nil
+}
+
+func eventOrUnknown(eventType string) string {
+	if eventType == "" {
+		return "(unknown event)"
+	}
+	return eventType
+}
+
+// forwardDelivery re-sends a verified delivery with its body and headers
+// unchanged, so the receiving app can verify the signature itself.
+func (r *webhookReceiver) forwardDelivery(req *http.Request, body []byte) error {
+	fwd, err := http.NewRequestWithContext(req.Context(), http.MethodPost, r.forward, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for name, values := range req.Header {
+		switch http.CanonicalHeaderKey(name) {
+		case "Host", "Content-Length", "Connection", "Accept-Encoding":
+			continue
+		}
+		fwd.Header[name] = values
+	}
+	resp, err := r.httpClient.Do(fwd)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/brandfetch-cli/internal/output"
+)
+
+func resetWebhooksListenFlags() {
+	webhooksListenHost = "127.0.0.1"
+	webhooksListenPort = 8080
+	webhooksListenSecret = ""
+	webhooksListenHeader = "X-Brandfetch-Signature"
+	webhooksListenForward = ""
+	webhooksListenKeepGoing = false
+	webhooksListenMaxEvents = 0
+}
+
+const testWebhookSecret = "whsec_test"
+
+func signWebhook(body string) string {
+	mac := hmac.New(sha256.New, []byte(testWebhookSecret))
+	mac.Write([]byte(body))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// startWebhookReceiver serves a receiver on a free port and returns its URL,
+// its stdout and a channel with serveWebhooks' result.
+func startWebhookReceiver(t *testing.T, httpClient HTTPClient, forward string) (string, *bytes.Buffer, <-chan error) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stdout bytes.Buffer
+	receiver := &webhookReceiver{
+		secret:     []byte(testWebhookSecret),
+		header:     "X-Brandfetch-Signature",
+		forward:    forward,
+		httpClient: httpClient,
+		format:     output.FormatText,
+		out:        &stdout,
+		errOut:     io.Discard,
+		deliveries: make(chan webhookDelivery, 16),
+		done:       make(chan struct{}),
+		now:        func() time.Time { return time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC) },
+	}
+	result := make(chan error, 1)
+	go func() { result <- serveWebhooks(context.Background(), ln, receiver) }()
+	return "http://" + ln.Addr().String(), &stdout, result
+}
+
+func postWebhook(t *testing.T, url, body, signature string) int {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if signature != "" {
+		req.Header.Set("X-Brandfetch-Signature", signature)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func waitForServe(t *testing.T, result <-chan error) error {
+	t.Helper()
+	select {
+	case err := <-result:
+		return err
+	case <-time.After(5 * time.Second):
+		t.Fatal("receiver did not stop")
+		return nil
+	}
+}
+
+func TestVerifyWebhookSignature(t *testing.T) {
+	body := []byte(`{"type":"brand.updated"}`)
+	mac := hmac.New(sha256.New, []byte(testWebhookSecret))
+	mac.Write(body)
+	sum := mac.Sum(nil)
+
+	for _, sig := range []string{
+		hex.EncodeToString(sum),
+		"sha256=" + hex.EncodeToString(sum),
+		base64.StdEncoding.EncodeToString(sum),
+	} {
+		if err := verifyWebhookSignature([]byte(testWebhookSecret), body, sig); err != nil {
+			t.Errorf("verifyWebhookSignature(%q) = %v", sig, err)
+		}
+	}
+	for sig, want := range map[string]string{
+		"":                   "missing signature",
+		"not-a-signature!":   "malformed signature",
+		signWebhook("other"): "signature mismatch",
+	} {
+		err := verifyWebhookSignature([]byte(testWebhookSecret), body, sig)
+		if err == nil || err.Error() != want {
+			t.Errorf("verifyWebhookSignature(%q) = %v, want %s", sig, err, want)
+		}
+	}
+}
+
+func TestWebhooksListen_PrintsAndForwards(t *testing.T) {
+	resetWebhooksListenFlags()
+	webhooksListenMaxEvents = 1
+
+	var forwarded *http.Request
+	var forwardedBody []byte
+	httpClient := &MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			forwarded = req
+			forwardedBody, _ = io.ReadAll(req.Body)
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(""))}, nil
+		},
+	}
+	url, stdout, result := startWebhookReceiver(t, httpClient, "http://localhost:3000/webhooks")
+
+	body := `{"type":"brand.updated","urn":"urn:bf:brand:abc","data":{"brand":{"name":"Stripe","domain":"stripe.com","colors":[{"hex":"#533afd"}]}}}`
+	if status := postWebhook(t, url, body, signWebhook(body)); status != http.StatusOK {
+		t.Errorf("status = %d, want 200", status)
+	}
+	if err := waitForServe(t, result); err != nil {
+		t.Fatalf("serveWebhooks() = %v", err)
+	}
+
+	for _, want := range []string{
+		"2026-01-01T12:00:00Z brand.updated  Stripe (stripe.com)",
+		"urn: urn:bf:brand:abc",
+		"logos: 0, colors: 1, fonts: 0, links: 0",
+	} {
+		if !containsStr(stdout.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, stdout.String())
+		}
+	}
+	if forwarded == nil {
+		t.Fatal("delivery was not forwarded")
+	}
+	if string(forwardedBody) != body || forwarded.Header.Get("X-Brandfetch-Signature") != signWebhook(body) {
+		t.Errorf("forwarded %q with signature %q", forwardedBody, forwarded.Header.Get("X-Brandfetch-Signature"))
+	}
+}
+
+func TestWebhooksListen_VerificationFailure(t *testing.T) {
+	resetWebhooksListenFlags()
+	url, stdout, result := startWebhookReceiver(t, &MockHTTPClient{}, "")
+
+	body := `{"type":"brand.updated"}`
+	if status := postWebhook(t, url, body, signWebhook("tampered")); status != http.StatusUnauthorized {
+		t.Errorf("status = %d, want 401", status)
+	}
+	err := waitForServe(t, result)
+	var exitErr *ExitError
+	if !errors.As(err, &exitErr) || exitErr.Code != exitVerifySig {
+		t.Fatalf("serveWebhooks() = %v, want exit code %d", err, exitVerifySig)
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("unverified delivery was printed:\n%s", stdout.String())
+	}
+}
+
+func TestWebhooksListen_KeepGoing(t *testing.T) {
+	resetWebhooksListenFlags()
+	webhooksListenKeepGoing = true
+	webhooksListenMaxEvents = 1
+	url, _, result := startWebhookReceiver(t, &MockHTTPClient{}, "")
+
+	postWebhook(t, url, `{}`, "")
+	body := `{"type":"brand.verified","brand":{"domain":"stripe.com","claimed":true}}`
+	postWebhook(t, url, body, signWebhook(body))
+
+	err := waitForServe(t, result)
+	if ExitCode(err) != exitVerifySig || !containsStr(err.Error(), "1 webhook delivery(s) failed verification") {
+		t.Errorf("serveWebhooks() = %v", err)
+	}
+}
+
+func TestWebhooksListen_SecretNotInHelp(t *testing.T) {
+	resetWebhooksListenFlags()
+	t.Setenv("BRANDFETCH_WEBHOOK_SECRET", "supersecret123")
+	var out bytes.Buffer
+	cmd := newWebhooksListenCmd()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--help"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if containsStr(out.String(), "supersecret123") {
+		t.Errorf("help output leaks the webhook secret:\n%s", out.String())
+	}
+}
+
+func TestWebhooksListen_RequiresSecret(t *testing.T) {
+	resetWebhooksListenFlags()
+	t.Setenv("BRANDFETCH_WEBHOOK_SECRET", "")
+	err := runWebhooksListenCmd(&cobra.Command{}, &MockHTTPClient{})
+	if err == nil || !containsStr(err.Error(), "BRANDFETCH_WEBHOOK_SECRET") {
+		t.Errorf("error = %v", err)
+	}
+}
@@ -3,7 +3,10 @@ package config
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
+
+	"github.com/salmonumbrella/brandfetch-cli/internal/secrets"
 )
 
 // Credential sources
@@ -21,6 +24,17 @@ type Credentials struct {
 	ClientID string `json:"client_id"` // Logo API key (high quota)
 	APIKey   string `json:"api_key"`   // Brand API key (limited quota)
 	Source   Source `json:"-"`         // Where credentials were loaded from
+	Profile  string `json:"-"`         // Profile the credentials belong to
+
+	ClientIDSource Source `json:"-"` // Where the client ID was loaded from
+	APIKeySource   Source `json:"-"` // Where the API key was loaded from
+}
+
+// credentialsFile is the config.json layout: top-level keys for the default
+// profile plus optional named profiles.
+type credentialsFile struct {
+	Credentials
+	Profiles map[string]Credentials `json:"profiles,omitempty"`
 }
 
 // ErrNoCredentials is returned when no credentials are found.
@@ -36,6 +50,12 @@ var ErrMissingAPIKey = errors.New("missing Brandfetch API key: set BRANDFETCH_AP
 type Requirements struct {
 	RequireClientID bool
 	RequireAPIKey   bool
+	Profile         string // Credential profile; empty means the default profile
+
+	// ResolveAll resolves secret references for keys that are not required
+	// too. Without it such references are dropped and the key is reported
+	// missing, so a broken reference only fails the commands that need it.
+	ResolveAll bool
 }
 
 // KeychainGetter abstracts keychain access for testing.
@@ -76,13 +96,13 @@ func LoadCredentialsWithOptions(keychain KeychainGetter, configFilePath string,
 	// 2. Keychain (if not already set)
 	if keychain != nil {
 		if clientID == "" {
-			if kcClientID, err := keychain.Get("client_id"); err == nil && kcClientID != "" {
+			if kcClientID, err := keychain.Get(secrets.ProfileKey(req.Profile, "client_id")); err == nil && kcClientID != "" {
 				clientID = kcClientID
 				clientSource = SourceKeychain
 			}
 		}
 		if apiKey == "" {
-			if kcAPIKey, err := keychain.Get("api_key"); err == nil && kcAPIKey != "" {
+			if kcAPIKey, err := keychain.Get(secrets.ProfileKey(req.Profile, "api_key")); err == nil && kcAPIKey != "" {
 				apiKey = kcAPIKey
 				apiSource = SourceKeychain
 			}
@@ -94,8 +114,12 @@ func LoadCredentialsWithOptions(keychain KeychainGetter, configFilePath string,
 		if clientID == "" || apiKey == "" {
 			data, err := os.ReadFile(configFilePath)
 			if err == nil {
-				var fileCreds Credentials
-				if err := json.Unmarshal(data, &fileCreds); err == nil {
+				var file credentialsFile
+				if err := json.Unmarshal(data, &file); err == nil {
+					fileCreds := file.Credentials
+					if req.Profile != "" && req.Profile != DefaultProfile {
+						fileCreds = file.Profiles[req.Profile]
+					}
 					if clientID == "" && fileCreds.ClientID != "" {
 						clientID = fileCreds.ClientID
 						clientSource = SourceFile
@@ -109,14 +133,29 @@ func LoadCredentialsWithOptions(keychain KeychainGetter, configFilePath string,
 		}
 	}
 
+	// 4. Secret references (file:, env:, exec:, op://) resolve to the real keys
+	var err error
+	if clientID, err = resolveCredential("client ID", clientID, clientSource, req.RequireClientID || req.ResolveAll); err != nil {
+		return nil, err
+	}
+	if clientID == "" {
+		clientSource = ""
+	}
+	if apiKey, err = resolveCredential("API key", apiKey, apiSource, req.RequireAPIKey || req.ResolveAll); err != nil {
+		return nil, err
+	}
+	if apiKey == "" {
+		apiSource = ""
+	}
+
 	if clientID == "" && apiKey == "" {
-		return nil, ErrNoCredentials
+		return nil, profileError(ErrNoCredentials, req.Profile)
 	}
 	if req.RequireClientID && clientID == "" {
-		return nil, ErrMissingClientID
+		return nil, profileError(ErrMissingClientID, req.Profile)
 	}
 	if req.RequireAPIKey && apiKey == "" {
-		return nil, ErrMissingAPIKey
+		return nil, profileError(ErrMissingAPIKey, req.Profile)
 	}
 
 	source := clientSource
@@ -127,13 +166,46 @@ func LoadCredentialsWithOptions(keychain KeychainGetter, configFilePath string,
 		source = SourceMixed
 	}
 
+	profile := req.Profile
+	if profile == "" {
+		profile = DefaultProfile
+	}
+
 	return &Credentials{
-		ClientID: clientID,
-		APIKey:   apiKey,
-		Source:   source,
+		ClientID:       clientID,
+		APIKey:         apiKey,
+		Source:         source,
+		Profile:        profile,
+		ClientIDSource: clientSource,
+		APIKeySource:   apiSource,
 	}, nil
 }
 
+// resolveCredential resolves a secret reference, naming the credential and its
+// source in errors. Literal values are returned unchanged; references are
+// dropped unless resolve is set.
+func resolveCredential(name, value string, source Source, resolve bool) (string, error) {
+	if !IsSecretReference(value) {
+		return value, nil
+	}
+	if !resolve {
+		return "", nil
+	}
+	resolved, err := ResolveSecret(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s from %s: %w", name, source, err)
+	}
+	return resolved, nil
+}
+
+// profileError names the profile in credential errors for non-default profiles.
+func profileError(err error, profile string) error {
+	if profile == "" || profile == DefaultProfile {
+		return err
+	}
+	return fmt.Errorf("%w (profile %q: use 'brandfetch auth set --profile %s')", err, profile, profile)
+}
+
 // SaveToFile saves credentials to a JSON file with mode 0600.
 func SaveToFile(creds *Credentials, path string) error {
 	data, err := json.MarshalIndent(creds, "", "  ")
@@ -1,8 +1,10 @@
 package config
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -97,3 +99,64 @@ func TestCredentials_PartialEnv_MissingAPIKey(t *testing.T) {
 		t.Errorf("LoadCredentialsWithOptions() expected error for missing API key")
 	}
 }
+
+type mapKeychain map[string]string
+
+func (m mapKeychain) Get(key string) (string, error) {
+	return m[key], nil
+}
+
+func TestCredentials_Profile(t *testing.T) {
+	os.Unsetenv("BRANDFETCH_CLIENT_ID")
+	os.Unsetenv("BRANDFETCH_API_KEY")
+
+	keychain := mapKeychain{
+		"client_id":         "default_id",
+		"staging.client_id": "staging_id",
+	}
+	configFile := filepath.Join(t.TempDir(), "config.json")
+	content := `{"api_key": "default_key", "profiles": {"staging": {"api_key": "staging_key"}}}`
+	if err := os.WriteFile(configFile, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	creds, err := LoadCredentialsWithOptions(keychain, configFile, Requirements{Profile: "staging"})
+	if err != nil {
+		t.Fatalf("LoadCredentialsWithOptions() error = %v", err)
+	}
+	if creds.ClientID != "staging_id" || creds.APIKey != "staging_key" || creds.Profile != "staging" {
+		t.Errorf("creds = %+v, want staging credentials", creds)
+	}
+
+	creds, err = LoadCredentialsWithOptions(keychain, configFile, Requirements{})
+	if err != nil {
+		t.Fatalf("LoadCredentialsWithOptions() error = %v", err)
+	}
+	if creds.ClientID != "default_id" || creds.APIKey != "default_key" || creds.Profile != DefaultProfile {
+		t.Errorf("creds = %+v, want default credentials", creds)
+	}
+
+	_, err = LoadCredentialsWithOptions(keychain, configFile, Requirements{Profile: "prod"})
+	if !errors.Is(err, ErrNoCredentials) || !strings.Contains(err.Error(), `profile "prod"`) {
+		t.Errorf("error = %v, want ErrNoCredentials naming the profile", err)
+	}
+}
+
+func TestCredentials_PerKeySource(t *testing.T) {
+	t.Setenv("BRANDFETCH_CLIENT_ID", "env_client_id")
+	t.Setenv("BRANDFETCH_API_KEY", "")
+
+	creds, err := LoadCredentialsWithOptions(mapKeychain{"api_key": "kc_api_key"}, "", Requirements{})
+	if err != nil {
+		t.Fatalf("LoadCredentialsWithOptions() error = %v", err)
+	}
+	if creds.ClientIDSource != SourceEnv {
+		t.Errorf("ClientIDSource = %v, want %v", creds.ClientIDSource, SourceEnv)
+	}
+	if creds.APIKeySource != SourceKeychain {
+		t.Errorf("APIKeySource = %v, want %v", creds.APIKeySource, SourceKeychain)
+	}
+	if creds.Source != SourceMixed {
+		t.Errorf("Source = %v, want %v", creds.Source, SourceMixed)
+	}
+}
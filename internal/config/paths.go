@@ -34,3 +34,12 @@ func ConfigFilePath() (string, error) {
 func EnsureDir(path string) error {
 	return os.MkdirAll(path, 0o700)
 }
+
+// KeyringDir returns the directory used by the encrypted file secrets backend
+func KeyringDir() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "keyring"), nil
+}
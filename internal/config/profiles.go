@@ -0,0 +1,143 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/salmonumbrella/brandfetch-cli/internal/secrets"
+)
+
+// DefaultProfile is the profile used when none is selected.
+// Its keychain entries use the unprefixed keys from before profiles existed.
+const DefaultProfile = secrets.DefaultProfile
+
+var profileNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// ValidateProfileName checks that name is usable as a profile name.
+func ValidateProfileName(name string) error {
+	if !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q: use letters, digits, '-' or '_'", name)
+	}
+	return nil
+}
+
+// ProfilesFilePath returns the path to profiles.json
+func ProfilesFilePath() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "profiles.json"), nil
+}
+
+// Profiles records the known credential profiles and the active one.
+// Secrets themselves live in the keychain; this file only holds names.
+type Profiles struct {
+	Active string   `json:"active,omitempty"`
+	Names  []string `json:"profiles"`
+	path   string
+}
+
+// LoadProfiles reads the profile registry. A missing file yields an empty registry.
+func LoadProfiles(path string) (*Profiles, error) {
+	p := &Profiles{path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return p, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profiles: %w", err)
+	}
+	if err := json.Unmarshal(data, p); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return p, nil
+}
+
+// Save writes the registry back to the file it was loaded from.
+func (p *Profiles) Save() error {
+	if err := EnsureDir(filepath.Dir(p.path)); err != nil {
+		return err
+	}
+	sort.Strings(p.Names)
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(p.path, append(data, '\n'), 0o600)
+}
+
+// List returns all profile names, including the default profile.
+func (p *Profiles) List() []string {
+	names := []string{DefaultProfile}
+	for _, name := range p.Names {
+		if name != DefaultProfile {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names[1:])
+	return names
+}
+
+// Has reports whether name is a known profile.
+func (p *Profiles) Has(name string) bool {
+	if name == DefaultProfile {
+		return true
+	}
+	for _, n := range p.Names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Add registers name. It is a no-op for existing profiles.
+func (p *Profiles) Add(name string) {
+	if !p.Has(name) {
+		p.Names = append(p.Names, name)
+	}
+}
+
+// Remove unregisters name and resets the active profile if it was active.
+func (p *Profiles) Remove(name string) {
+	names := p.Names[:0]
+	for _, n := range p.Names {
+		if n != name {
+			names = append(names, n)
+		}
+	}
+	p.Names = names
+	if p.Active == name {
+		p.Active = ""
+	}
+}
+
+// Rename renames a registered profile, keeping it active if it was.
+func (p *Profiles) Rename(from, to string) {
+	for i, n := range p.Names {
+		if n == from {
+			p.Names[i] = to
+		}
+	}
+	if p.Active == from {
+		p.Active = to
+	}
+}
+
+// ResolveProfile returns the profile to use: the explicit name (from --profile or
+// BRANDFETCH_PROFILE) if set, otherwise the active profile, otherwise the default.
+func ResolveProfile(explicit string, profiles *Profiles) string {
+	if explicit != "" {
+		return explicit
+	}
+	if profiles != nil && profiles.Active != "" {
+		return profiles.Active
+	}
+	return DefaultProfile
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestProfiles_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "brandfetch", "profiles.json")
+
+	profiles, err := LoadProfiles(path)
+	if err != nil {
+		t.Fatalf("LoadProfiles() on missing file error = %v", err)
+	}
+	profiles.Add("staging")
+	profiles.Add("acme")
+	profiles.Add("staging")
+	profiles.Active = "staging"
+	if err := profiles.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := LoadProfiles(path)
+	if err != nil {
+		t.Fatalf("LoadProfiles() error = %v", err)
+	}
+	if got, want := loaded.List(), []string{"default", "acme", "staging"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("List() = %v, want %v", got, want)
+	}
+	if ResolveProfile("", loaded) != "staging" {
+		t.Errorf("ResolveProfile() = %q, want staging", ResolveProfile("", loaded))
+	}
+	if ResolveProfile("acme", loaded) != "acme" {
+		t.Error("explicit profile should take precedence")
+	}
+
+	loaded.Rename("staging", "qa")
+	if loaded.Active != "qa" || !loaded.Has("qa") || loaded.Has("staging") {
+		t.Errorf("after Rename: %+v", loaded)
+	}
+	loaded.Remove("qa")
+	if loaded.Active != "" || ResolveProfile("", loaded) != DefaultProfile {
+		t.Errorf("after Remove: %+v", loaded)
+	}
+}
+
+func TestValidateProfileName(t *testing.T) {
+	for _, name := range []string{"staging", "acme_prod", "client-2"} {
+		if err := ValidateProfileName(name); err != nil {
+			t.Errorf("ValidateProfileName(%q) error = %v", name, err)
+		}
+	}
+	for _, name := range []string{"", "a.b", "../x", "with space"} {
+		if err := ValidateProfileName(name); err == nil {
+			t.Errorf("ValidateProfileName(%q) expected error", name)
+		}
+	}
+}
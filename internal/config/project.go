@@ -0,0 +1,128 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultProjectFile is the project file name looked up by `brandfetch build`.
+const DefaultProjectFile = "brandfetch.yaml"
+
+// Project describes a declarative brand kit (brandfetch.yaml).
+type Project struct {
+	// Output is the directory assets are downloaded into, one subdirectory per brand.
+	Output  string         `yaml:"output"`
+	Lock    string         `yaml:"lock"`
+	Brands  []string       `yaml:"brands"`
+	Logos   []ProjectLogo  `yaml:"logos"`
+	Exports ProjectExports `yaml:"exports"`
+	Dir     string         `yaml:"-"` // Directory containing the project file
+}
+
+// ProjectLogo is a logo variant resolved through the Logo API CDN.
+type ProjectLogo struct {
+	Type   string `yaml:"type"`
+	Theme  string `yaml:"theme"`
+	Format string `yaml:"format"`
+	Width  int    `yaml:"width"`
+	Height int    `yaml:"height"`
+}
+
+// ProjectExports lists export files to generate from brand colors and fonts.
+type ProjectExports struct {
+	CSS      string `yaml:"css"`
+	Tailwind string `yaml:"tailwind"`
+	Tokens   string `yaml:"tokens"`
+}
+
+// LoadProject reads and validates a project file.
+// Relative paths in the project are resolved against the file's directory.
+func LoadProject(path string) (*Project, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read project file: %w", err)
+	}
+
+	var project Project
+	if err := yaml.Unmarshal(data, &project); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	project.Dir = filepath.Dir(path)
+
+	if project.Output == "" {
+		project.Output = "brand-assets"
+	}
+	if project.Lock == "" {
+		project.Lock = "brandfetch.lock"
+	}
+	if err := project.validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", path, err)
+	}
+	return &project, nil
+}
+
+func (p *Project) validate() error {
+	if len(p.Brands) == 0 {
+		return fmt.Errorf("brands must list at least one identifier")
+	}
+	seen := make(map[string]bool, len(p.Brands))
+	for i, b := range p.Brands {
+		b = strings.TrimSpace(b)
+		if b == "" {
+			return fmt.Errorf("brands must not contain empty identifiers")
+		}
+		if seen[b] {
+			return fmt.Errorf("duplicate brand %q", b)
+		}
+		seen[b] = true
+		p.Brands[i] = b
+	}
+	names := make(map[string]int, len(p.Logos))
+	for i, l := range p.Logos {
+		if l.Format == "" {
+			return fmt.Errorf("logos[%d]: format is required", i)
+		}
+		if l.Width < 0 || l.Height < 0 {
+			return fmt.Errorf("logos[%d]: width and height must be positive", i)
+		}
+		name := l.FileName()
+		if j, ok := names[name]; ok {
+			return fmt.Errorf("logos[%d] and logos[%d] both write %s", j, i, name)
+		}
+		names[name] = i
+	}
+	return nil
+}
+
+// FileName returns the stable file name a logo variant is saved as, e.g.
+// icon-dark-256x256.png, symbol-w64.webp or logo-h128.svg.
+func (l ProjectLogo) FileName() string {
+	parts := []string{"logo"}
+	if l.Type != "" {
+		parts[0] = l.Type
+	}
+	if l.Theme != "" {
+		parts = append(parts, l.Theme)
+	}
+	switch {
+	case l.Width > 0 && l.Height > 0:
+		parts = append(parts, fmt.Sprintf("%dx%d", l.Width, l.Height))
+	case l.Width > 0:
+		parts = append(parts, fmt.Sprintf("w%d", l.Width))
+	case l.Height > 0:
+		parts = append(parts, fmt.Sprintf("h%d", l.Height))
+	}
+	return strings.Join(parts, "-") + "." + l.Format
+}
+
+// Path resolves a project-relative path.
+func (p *Project) Path(rel string) string {
+	if rel == "" || filepath.IsAbs(rel) {
+		return rel
+	}
+	return filepath.Join(p.Dir, rel)
+}
@@ -0,0 +1,104 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadProject(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "brandfetch.yaml")
+	content := `brands:
+  - stripe.com
+  - github.com
+logos:
+  - type: icon
+    format: png
+    width: 256
+exports:
+  css: styles/brands.css
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	project, err := LoadProject(path)
+	if err != nil {
+		t.Fatalf("LoadProject() error = %v", err)
+	}
+
+	if len(project.Brands) != 2 || project.Brands[1] != "github.com" {
+		t.Errorf("Brands = %v, want [stripe.com github.com]", project.Brands)
+	}
+	if project.Output != "brand-assets" {
+		t.Errorf("Output = %v, want default brand-assets", project.Output)
+	}
+	if project.Lock != "brandfetch.lock" {
+		t.Errorf("Lock = %v, want default brandfetch.lock", project.Lock)
+	}
+	if len(project.Logos) != 1 || project.Logos[0].Width != 256 {
+		t.Errorf("Logos = %+v, want one icon variant", project.Logos)
+	}
+	if got := project.Path(project.Exports.CSS); got != filepath.Join(tmpDir, "styles/brands.css") {
+		t.Errorf("Path() = %v, want path relative to project dir", got)
+	}
+}
+
+func TestLoadProject_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"no brands", "brands: []\n"},
+		{"duplicate brands", "brands: [stripe.com, stripe.com]\n"},
+		{"logo without format", "brands: [stripe.com]\nlogos:\n  - type: icon\n"},
+		{"duplicate trimmed brands", "brands: [stripe.com, ' stripe.com ']\n"},
+		{"logos with the same file name", "brands: [stripe.com]\nlogos:\n  - {type: icon, format: png}\n  - {type: icon, format: png}\n"},
+		{"malformed yaml", "brands: [stripe.com\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "brandfetch.yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatalf("WriteFile() error = %v", err)
+			}
+			if _, err := LoadProject(path); err == nil {
+				t.Errorf("LoadProject() expected error")
+			}
+		})
+	}
+}
+
+func TestProjectLogoFileName(t *testing.T) {
+	tests := []struct {
+		logo ProjectLogo
+		want string
+	}{
+		{ProjectLogo{Format: "svg"}, "logo.svg"},
+		{ProjectLogo{Type: "icon", Theme: "dark", Format: "png"}, "icon-dark.png"},
+		{ProjectLogo{Type: "icon", Format: "png", Width: 256, Height: 128}, "icon-256x128.png"},
+		{ProjectLogo{Type: "symbol", Format: "webp", Width: 64}, "symbol-w64.webp"},
+		{ProjectLogo{Type: "symbol", Format: "webp", Height: 64}, "symbol-h64.webp"},
+	}
+	for _, tt := range tests {
+		if got := tt.logo.FileName(); got != tt.want {
+			t.Errorf("FileName(%+v) = %q, want %q", tt.logo, got, tt.want)
+		}
+	}
+}
+
+func TestLoadProject_TrimsBrands(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "brandfetch.yaml")
+	if err := os.WriteFile(path, []byte("brands: [' stripe.com ', github.com]\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	project, err := LoadProject(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if project.Brands[0] != "stripe.com" {
+		t.Errorf("Brands[0] = %q, want the trimmed identifier", project.Brands[0])
+	}
+}
@@ -0,0 +1,96 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// RotationsFilePath returns the path to rotations.json
+func RotationsFilePath() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "rotations.json"), nil
+}
+
+// Rotation records when a stored credential was last rotated or rolled back.
+// The previous value itself lives in the secrets backend.
+type Rotation struct {
+	RotatedAt     time.Time  `json:"rotated_at"`
+	PreviousUntil *time.Time `json:"previous_until,omitempty"` // End of the rollback grace period
+	RolledBackAt  *time.Time `json:"rolled_back_at,omitempty"`
+}
+
+// HasPrevious reports whether the previous value may still be restored at now.
+func (r Rotation) HasPrevious(now time.Time) bool {
+	return r.PreviousUntil != nil && now.Before(*r.PreviousUntil)
+}
+
+// Rotations holds rotation records per profile and credential key.
+type Rotations struct {
+	Profiles map[string]map[string]Rotation `json:"profiles"`
+	path     string
+}
+
+// LoadRotations reads rotation records. A missing file yields no records.
+func LoadRotations(path string) (*Rotations, error) {
+	r := &Rotations{Profiles: make(map[string]map[string]Rotation), path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return r, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rotations: %w", err)
+	}
+	if err := json.Unmarshal(data, r); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if r.Profiles == nil {
+		r.Profiles = make(map[string]map[string]Rotation)
+	}
+	return r, nil
+}
+
+// Save writes the records back to the file they were loaded from.
+func (r *Rotations) Save() error {
+	if err := EnsureDir(filepath.Dir(r.path)); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(data, '\n'), 0o600)
+}
+
+// Get returns the record for a profile's credential key.
+func (r *Rotations) Get(profile, key string) (Rotation, bool) {
+	rot, ok := r.Profiles[profile][key]
+	return rot, ok
+}
+
+// Set stores the record for a profile's credential key.
+func (r *Rotations) Set(profile, key string, rot Rotation) {
+	if r.Profiles[profile] == nil {
+		r.Profiles[profile] = make(map[string]Rotation)
+	}
+	r.Profiles[profile][key] = rot
+}
+
+// RemoveProfile drops all records for a profile.
+func (r *Rotations) RemoveProfile(profile string) {
+	delete(r.Profiles, profile)
+}
+
+// RenameProfile moves a profile's records to a new name.
+func (r *Rotations) RenameProfile(from, to string) {
+	if records, ok := r.Profiles[from]; ok {
+		r.Profiles[to] = records
+		delete(r.Profiles, from)
+	}
+}
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRotations_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "brandfetch", "rotations.json")
+
+	rotations, err := LoadRotations(path)
+	if err != nil {
+		t.Fatalf("LoadRotations() on missing file error = %v", err)
+	}
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	until := now.Add(24 * time.Hour)
+	rotations.Set("staging", "api_key", Rotation{RotatedAt: now, PreviousUntil: &until})
+	if err := rotations.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := LoadRotations(path)
+	if err != nil {
+		t.Fatalf("LoadRotations() error = %v", err)
+	}
+	rot, ok := loaded.Get("staging", "api_key")
+	if !ok || !rot.RotatedAt.Equal(now) {
+		t.Fatalf("Get() = %+v, %v", rot, ok)
+	}
+	if !rot.HasPrevious(now.Add(time.Hour)) {
+		t.Error("previous key should be available within the grace period")
+	}
+	if rot.HasPrevious(until) {
+		t.Error("previous key should expire at the end of the grace period")
+	}
+	if _, ok := loaded.Get("default", "api_key"); ok {
+		t.Error("unexpected record for default profile")
+	}
+
+	loaded.RenameProfile("staging", "qa")
+	if _, ok := loaded.Get("qa", "api_key"); !ok {
+		t.Error("record not moved by RenameProfile")
+	}
+	loaded.RemoveProfile("qa")
+	if _, ok := loaded.Get("qa", "api_key"); ok {
+		t.Error("record not removed by RemoveProfile")
+	}
+}
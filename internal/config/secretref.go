@@ -0,0 +1,196 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Secret reference prefixes accepted wherever a credential value is read.
+const (
+	refEnv  = "env:"
+	refFile = "file:"
+	refExec = "exec:"
+	refOp   = "op://"
+)
+
+// secretCommandTimeout bounds exec: and op:// lookups.
+const secretCommandTimeout = 30 * time.Second
+
+// secretCache holds resolved references for the life of the process, so a
+// password manager is asked at most once per reference.
+var secretCache = struct {
+	sync.Mutex
+	values map[string]string
+}{values: make(map[string]string)}
+
+// IsSecretReference reports whether value is a reference rather than a literal key.
+func IsSecretReference(value string) bool {
+	for _, prefix := range []string{refEnv, refFile, refExec, refOp} {
+		if strings.HasPrefix(value, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveSecret returns the secret a credential value refers to:
+//
+//	env:NAME              the NAME environment variable
+//	file:/path/to/secret  the file contents, trailing whitespace trimmed
+//	exec:command args     the command's standard output, trimmed
+//	op://vault/item/field the 1Password CLI's "op read" output
+//
+// Any other value is returned unchanged as a literal key.
+func ResolveSecret(value string) (string, error) {
+	if !IsSecretReference(value) {
+		return value, nil
+	}
+
+	secretCache.Lock()
+	defer secretCache.Unlock()
+	if v, ok := secretCache.values[value]; ok {
+		return v, nil
+	}
+
+	v, err := resolveReference(value)
+	if err != nil {
+		return "", err
+	}
+	if v == "" {
+		return "", fmt.Errorf("%s resolved to an empty value", describeReference(value))
+	}
+	secretCache.values[value] = v
+	return v, nil
+}
+
+func resolveReference(value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, refEnv):
+		name := strings.TrimPrefix(value, refEnv)
+		v, ok := os.LookupEnv(name)
+		if !ok {
+			return "", fmt.Errorf("%s: variable is not set", describeReference(value))
+		}
+		return strings.TrimSpace(v), nil
+	case strings.HasPrefix(value, refFile):
+		path, err := expandHome(strings.TrimPrefix(value, refFile))
+		if err != nil {
+			return "", err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", describeReference(value), err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	case strings.HasPrefix(value, refExec):
+		args, err := splitCommandLine(strings.TrimPrefix(value, refExec))
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", describeReference(value), err)
+		}
+		if len(args) == 0 {
+			return "", fmt.Errorf("%s: no command", describeReference(value))
+		}
+		return runSecretCommand(value, args[0], args[1:]...)
+	default:
+		return runSecretCommand(value, "op", "read", "--no-newline", value)
+	}
+}
+
+func runSecretCommand(ref, name string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), secretCommandTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	c := exec.CommandContext(ctx, name, args...)
+	c.Stdout = &stdout
+	c.Stderr = &stderr
+	if err := c.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%s: %w: %s", describeReference(ref), err, msg)
+		}
+		return "", fmt.Errorf("%s: %w", describeReference(ref), err)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// describeReference names a reference in errors without echoing command arguments,
+// which may contain tokens.
+func describeReference(value string) string {
+	switch {
+	case strings.HasPrefix(value, refExec):
+		fields := strings.Fields(strings.TrimPrefix(value, refExec))
+		if len(fields) > 0 {
+			return fmt.Sprintf("secret reference exec:%s", fields[0])
+		}
+		return "secret reference exec:"
+	}
+	return "secret reference " + value
+}
+
+func expandHome(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~")), nil
+}
+
+// splitCommandLine splits s into arguments, honouring single quotes, double
+// quotes and backslash escapes. No shell is involved.
+func splitCommandLine(s string) ([]string, error) {
+	var (
+		args    []string
+		current strings.Builder
+		inArg   bool
+		quote   rune
+		escaped bool
+	)
+	for _, r := range s {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inArg = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if escaped {
+		return nil, fmt.Errorf("trailing backslash")
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
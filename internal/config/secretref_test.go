@@ -0,0 +1,186 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// resetSecretCache clears resolved references between tests.
+func resetSecretCache(t *testing.T) {
+	t.Helper()
+	secretCache.Lock()
+	secretCache.values = make(map[string]string)
+	secretCache.Unlock()
+}
+
+func TestResolveSecret_Literal(t *testing.T) {
+	got, err := ResolveSecret("plain_key")
+	if err != nil || got != "plain_key" {
+		t.Errorf("ResolveSecret() = %q, %v", got, err)
+	}
+}
+
+func TestResolveSecret_EnvAndFile(t *testing.T) {
+	resetSecretCache(t)
+	t.Setenv("BF_TEST_SECRET", "from_env")
+
+	path := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(path, []byte("from_file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]string{
+		"env:BF_TEST_SECRET": "from_env",
+		"file:" + path:       "from_file",
+	}
+	for ref, want := range tests {
+		got, err := ResolveSecret(ref)
+		if err != nil {
+			t.Fatalf("ResolveSecret(%q) error = %v", ref, err)
+		}
+		if got != want {
+			t.Errorf("ResolveSecret(%q) = %q, want %q", ref, got, want)
+		}
+	}
+
+	if _, err := ResolveSecret("env:BF_TEST_MISSING"); err == nil {
+		t.Error("expected error for an unset variable")
+	}
+	if _, err := ResolveSecret("file:" + filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("expected error for a missing file")
+	}
+}
+
+func TestResolveSecret_ExecCached(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script")
+	}
+	resetSecretCache(t)
+
+	dir := t.TempDir()
+	counter := filepath.Join(dir, "calls")
+	script := filepath.Join(dir, "secret.sh")
+	body := "#!/bin/sh\necho x >> \"$1\"\necho \"from exec\"\n"
+	if err := os.WriteFile(script, []byte(body), 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	ref := "exec:" + script + " '" + counter + "'"
+	for i := 0; i < 2; i++ {
+		got, err := ResolveSecret(ref)
+		if err != nil {
+			t.Fatalf("ResolveSecret() error = %v", err)
+		}
+		if got != "from exec" {
+			t.Errorf("ResolveSecret() = %q, want %q", got, "from exec")
+		}
+	}
+	data, _ := os.ReadFile(counter)
+	if calls := strings.Count(string(data), "x"); calls != 1 {
+		t.Errorf("command ran %d times, want 1", calls)
+	}
+}
+
+func TestResolveSecret_ExecFailureHidesArgs(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	resetSecretCache(t)
+
+	_, err := ResolveSecret("exec:sh -c 'exit 3' hunter2")
+	if err == nil {
+		t.Fatal("expected error for a failing command")
+	}
+	if strings.Contains(err.Error(), "hunter2") {
+		t.Errorf("error should not echo command arguments: %v", err)
+	}
+}
+
+func TestResolveSecret_OnePassword(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script")
+	}
+	resetSecretCache(t)
+
+	dir := t.TempDir()
+	op := "#!/bin/sh\n[ \"$1\" = read ] && printf 'op:%s' \"$3\"\n"
+	if err := os.WriteFile(filepath.Join(dir, "op"), []byte(op), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	got, err := ResolveSecret("op://Vault/Brandfetch/api_key")
+	if err != nil {
+		t.Fatalf("ResolveSecret() error = %v", err)
+	}
+	if got != "op:op://Vault/Brandfetch/api_key" {
+		t.Errorf("ResolveSecret() = %q", got)
+	}
+}
+
+func TestSplitCommandLine(t *testing.T) {
+	got, err := splitCommandLine(`vault kv get -field="api key" 'secret/brand fetch' a\ b`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"vault", "kv", "get", "-field=api key", "secret/brand fetch", "a b"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("splitCommandLine() = %q, want %q", got, want)
+	}
+	if _, err := splitCommandLine(`echo "open`); err == nil {
+		t.Error("expected error for an unterminated quote")
+	}
+}
+
+func TestCredentials_ResolvesReferences(t *testing.T) {
+	resetSecretCache(t)
+	t.Setenv("BF_TEST_CLIENT", "resolved_client")
+	t.Setenv("BRANDFETCH_CLIENT_ID", "env:BF_TEST_CLIENT")
+	t.Setenv("BRANDFETCH_API_KEY", "")
+
+	path := filepath.Join(t.TempDir(), "api_key")
+	if err := os.WriteFile(path, []byte("resolved_key\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	keychain := mapKeychain{"api_key": "file:" + path}
+
+	creds, err := LoadCredentials(keychain, "")
+	if err != nil {
+		t.Fatalf("LoadCredentials() error = %v", err)
+	}
+	if creds.ClientID != "resolved_client" || creds.APIKey != "resolved_key" {
+		t.Errorf("creds = %+v", creds)
+	}
+	if creds.ClientIDSource != SourceEnv || creds.APIKeySource != SourceKeychain {
+		t.Errorf("sources = %s, %s", creds.ClientIDSource, creds.APIKeySource)
+	}
+
+	t.Setenv("BRANDFETCH_CLIENT_ID", "env:BF_TEST_UNSET")
+	if _, err := LoadCredentials(keychain, ""); err == nil || !strings.Contains(err.Error(), "client ID from env") {
+		t.Errorf("expected resolution error naming the source, got %v", err)
+	}
+}
+
+func TestCredentials_SkipsUnneededReferences(t *testing.T) {
+	resetSecretCache(t)
+	t.Setenv("BRANDFETCH_CLIENT_ID", "env:BF_TEST_UNSET")
+	t.Setenv("BRANDFETCH_API_KEY", "literal_key")
+
+	creds, err := LoadCredentialsWithOptions(nil, "", Requirements{RequireAPIKey: true})
+	if err != nil {
+		t.Fatalf("broken client ID reference failed an API-key-only load: %v", err)
+	}
+	if creds.ClientID != "" || creds.ClientIDSource != "" || creds.APIKey != "literal_key" {
+		t.Errorf("creds = %+v, want the client ID treated as missing", creds)
+	}
+
+	if _, err := LoadCredentialsWithOptions(nil, "", Requirements{RequireClientID: true}); err == nil || !strings.Contains(err.Error(), "client ID from env") {
+		t.Errorf("required reference error = %v", err)
+	}
+	if _, err := LoadCredentialsWithOptions(nil, "", Requirements{ResolveAll: true}); err == nil || !strings.Contains(err.Error(), "client ID from env") {
+		t.Errorf("ResolveAll error = %v", err)
+	}
+}
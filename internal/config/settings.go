@@ -0,0 +1,164 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SettingsFilePath returns the path to settings.yaml
+func SettingsFilePath() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "settings.yaml"), nil
+}
+
+// Settings holds user defaults from settings.yaml, addressed by dotted keys.
+//
+// Top-level keys are global (e.g. "output"); nested keys follow the command
+// path and end in a flag name (e.g. "logo.format" or "logo.download.dir"):
+//
+//	output: json
+//	logo:
+//	  format: png
+//	  download:
+//	    dir: ./assets
+type Settings struct {
+	values map[string]string
+	path   string
+}
+
+// LoadSettings reads a settings file. A missing file yields empty settings.
+func LoadSettings(path string) (*Settings, error) {
+	s := &Settings{values: make(map[string]string), path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read settings: %w", err)
+	}
+	if err := s.parse(data); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// ParseSettings validates settings file contents without a backing file.
+func ParseSettings(data []byte) (*Settings, error) {
+	s := &Settings{values: make(map[string]string)}
+	if err := s.parse(data); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Settings) parse(data []byte) error {
+	var tree map[string]interface{}
+	if err := yaml.Unmarshal(data, &tree); err != nil {
+		return err
+	}
+	return flattenSettings("", tree, s.values)
+}
+
+func flattenSettings(prefix string, tree map[string]interface{}, out map[string]string) error {
+	for k, v := range tree {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		switch val := v.(type) {
+		case map[string]interface{}:
+			if err := flattenSettings(key, val, out); err != nil {
+				return err
+			}
+		case []interface{}:
+			parts := make([]string, 0, len(val))
+			for _, item := range val {
+				parts = append(parts, fmt.Sprint(item))
+			}
+			out[key] = strings.Join(parts, ",")
+		case nil:
+			// "key:" with no value is treated as unset.
+		default:
+			out[key] = fmt.Sprint(val)
+		}
+	}
+	return nil
+}
+
+// Path returns the file the settings were loaded from.
+func (s *Settings) Path() string {
+	return s.path
+}
+
+// Get returns the value for key and whether it is set.
+func (s *Settings) Get(key string) (string, bool) {
+	v, ok := s.values[key]
+	return v, ok
+}
+
+// Set assigns a value. Keys cannot be both a value and a section.
+func (s *Settings) Set(key, value string) error {
+	if key == "" || strings.HasPrefix(key, ".") || strings.HasSuffix(key, ".") || strings.Contains(key, "..") {
+		return fmt.Errorf("invalid key %q", key)
+	}
+	for existing := range s.values {
+		if strings.HasPrefix(existing, key+".") || strings.HasPrefix(key, existing+".") {
+			return fmt.Errorf("key %q conflicts with %q", key, existing)
+		}
+	}
+	s.values[key] = value
+	return nil
+}
+
+// Unset removes a key and reports whether it was set.
+func (s *Settings) Unset(key string) bool {
+	_, ok := s.values[key]
+	delete(s.values, key)
+	return ok
+}
+
+// Keys returns all set keys in sorted order.
+func (s *Settings) Keys() []string {
+	keys := make([]string, 0, len(s.values))
+	for k := range s.values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Save writes the settings back as nested YAML.
+func (s *Settings) Save() error {
+	tree := make(map[string]interface{})
+	for key, value := range s.values {
+		node := tree
+		parts := strings.Split(key, ".")
+		for _, part := range parts[:len(parts)-1] {
+			child, ok := node[part].(map[string]interface{})
+			if !ok {
+				child = make(map[string]interface{})
+				node[part] = child
+			}
+			node = child
+		}
+		node[parts[len(parts)-1]] = value
+	}
+
+	data, err := yaml.Marshal(tree)
+	if err != nil {
+		return err
+	}
+	if err := EnsureDir(filepath.Dir(s.path)); err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0o600)
+}
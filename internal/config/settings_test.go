@@ -0,0 +1,66 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSettings_LoadSetSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "brandfetch", "settings.yaml")
+	content := "output: json\nlogo:\n  format: png\n  width: 256\n  download:\n    dir: ./assets\n"
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	settings, err := LoadSettings(path)
+	if err != nil {
+		t.Fatalf("LoadSettings() error = %v", err)
+	}
+	want := []string{"logo.download.dir", "logo.format", "logo.width", "output"}
+	if got := settings.Keys(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Keys() = %v, want %v", got, want)
+	}
+	if v, _ := settings.Get("logo.width"); v != "256" {
+		t.Errorf("Get(logo.width) = %q, want 256", v)
+	}
+
+	if err := settings.Set("logo", "x"); err == nil {
+		t.Error("expected conflict setting a section to a value")
+	}
+	if err := settings.Set("quick.max-size", "10MB"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if !settings.Unset("output") || settings.Unset("output") {
+		t.Error("Unset() should report whether the key was set")
+	}
+	if err := settings.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	reloaded, err := LoadSettings(path)
+	if err != nil {
+		t.Fatalf("LoadSettings() after save error = %v", err)
+	}
+	want = []string{"logo.download.dir", "logo.format", "logo.width", "quick.max-size"}
+	if got := reloaded.Keys(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Keys() after save = %v, want %v", got, want)
+	}
+}
+
+func TestSettings_Missing(t *testing.T) {
+	settings, err := LoadSettings(filepath.Join(t.TempDir(), "missing.yaml"))
+	if err != nil {
+		t.Fatalf("LoadSettings() error = %v", err)
+	}
+	if len(settings.Keys()) != 0 {
+		t.Errorf("expected no keys, got %v", settings.Keys())
+	}
+	if _, err := ParseSettings([]byte("output: [unclosed")); err == nil {
+		t.Error("expected parse error")
+	}
+}
@@ -0,0 +1,163 @@
+package mockserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+)
+
+type graphQLRequest struct {
+	Query         string          `json:"query"`
+	OperationName string          `json:"operationName"`
+	Variables     json.RawMessage `json:"variables"`
+}
+
+// operationPattern matches the name of a named query or mutation.
+var operationPattern = regexp.MustCompile(`\b(?:query|mutation)\s+([_A-Za-z][_0-9A-Za-z]*)`)
+
+// operation returns the request's operationName, or the name of the first
+// named operation in the document.
+func (r graphQLRequest) operation() string {
+	if r.OperationName != "" {
+		return r.OperationName
+	}
+	if m := operationPattern.FindStringSubmatch(r.Query); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+type webhookInput struct {
+	URL           string   `json:"url"`
+	Events        []string `json:"events"`
+	Enabled       *bool    `json:"enabled"`
+	Description   string   `json:"description"`
+	WebhookURN    string   `json:"webhookUrn"`
+	Subscriptions []string `json:"subscriptions"`
+}
+
+type mutationPayload struct {
+	Code    string   `json:"code"`
+	Message string   `json:"message"`
+	Success bool     `json:"success"`
+	Webhook *webhook `json:"webhook"`
+}
+
+// serveGraphQL implements the webhook operations used by 'brandfetch webhooks'.
+// Operations are recognised by the operation names the CLI sends.
+func (h *Handler) serveGraphQL(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	if !h.authorizedBearer(r) {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	var req graphQLRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid GraphQL request body")
+		return
+	}
+	var vars struct {
+		Input webhookInput `json:"input"`
+	}
+	if len(req.Variables) > 0 && string(req.Variables) != "null" {
+		if err := json.Unmarshal(req.Variables, &vars); err != nil {
+			writeGraphQLError(w, "invalid variables: "+err.Error(), "BAD_USER_INPUT")
+			return
+		}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	switch req.operation() {
+	case "CreateWebhook":
+		writeGraphQLData(w, "createWebhook", h.createWebhook(vars.Input))
+	case "AddWebhookSubscriptions":
+		writeGraphQLData(w, "addWebhookSubscriptions", h.updateSubscriptions(vars.Input, true))
+	case "RemoveWebhookSubscriptions":
+		writeGraphQLData(w, "removeWebhookSubscriptions", h.updateSubscriptions(vars.Input, false))
+	case "ListWebhooks":
+		type edge struct {
+			Node *webhook `json:"node"`
+		}
+		edges := make([]edge, 0, len(h.webhooks))
+		for _, hook := range h.webhooks {
+			edges = append(edges, edge{Node: hook})
+		}
+		writeGraphQLData(w, "webhooks", map[string]interface{}{"edges": edges})
+	default:
+		writeGraphQLError(w, "operation not supported by the mock server", "GRAPHQL_VALIDATION_FAILED")
+	}
+}
+
+func (h *Handler) createWebhook(input webhookInput) mutationPayload {
+	if input.URL == "" || len(input.Events) == 0 {
+		return mutationPayload{Code: "BAD_USER_INPUT", Message: "url and events are required"}
+	}
+	enabled := true
+	if input.Enabled != nil {
+		enabled = *input.Enabled
+	}
+	hook := &webhook{
+		URN:         fmt.Sprintf("urn:bf:webhook:mock%d", len(h.webhooks)+1),
+		URL:         input.URL,
+		Enabled:     enabled,
+		Events:      input.Events,
+		Description: input.Description,
+	}
+	h.webhooks = append(h.webhooks, hook)
+	return mutationPayload{Code: "OK", Message: "Webhook created", Success: true, Webhook: hook}
+}
+
+func (h *Handler) updateSubscriptions(input webhookInput, add bool) mutationPayload {
+	var hook *webhook
+	for _, candidate := range h.webhooks {
+		if candidate.URN == input.WebhookURN {
+			hook = candidate
+		}
+	}
+	if hook == nil {
+		return mutationPayload{Code: "NOT_FOUND", Message: "Webhook not found"}
+	}
+
+	current := make(map[string]bool, len(hook.Subscriptions))
+	for _, s := range hook.Subscriptions {
+		current[s] = true
+	}
+	for _, s := range input.Subscriptions {
+		current[s] = add
+	}
+	hook.Subscriptions = hook.Subscriptions[:0]
+	for s, subscribed := range current {
+		if subscribed {
+			hook.Subscriptions = append(hook.Subscriptions, s)
+		}
+	}
+	sort.Strings(hook.Subscriptions)
+
+	message := "Subscriptions added"
+	if !add {
+		message = "Subscriptions removed"
+	}
+	return mutationPayload{Code: "OK", Message: message, Success: true, Webhook: hook}
+}
+
+func writeGraphQLData(w http.ResponseWriter, field string, value interface{}) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"data": map[string]interface{}{field: value},
+	})
+}
+
+func writeGraphQLError(w http.ResponseWriter, message, code string) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"errors": []map[string]interface{}{{
+			"message":    message,
+			"extensions": map[string]string{"code": code},
+		}},
+	})
+}
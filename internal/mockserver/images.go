@@ -0,0 +1,180 @@
+package mockserver
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultImageSize = 128
+	maxImageSize     = 2048
+)
+
+// fallbackColor fills lettermarks served for unknown brands.
+const fallbackColor = "#9ca3af"
+
+// webp1x1 is a 1x1 lossless WebP; the standard library has no WebP encoder.
+var webp1x1, _ = base64.StdEncoding.DecodeString("UklGRhoAAABXRUJQVlA4TA0AAAAvAAAAEAcQERGIiP4HAA==")
+
+// serveLogo mimics the Logo API CDN path layout:
+// /<identifier>[/w/<px>][/h/<px>][/theme/<t>][/fallback/<f>][/type/<type>.<ext>]?c=<client id>
+func (h *Handler) serveLogo(w http.ResponseWriter, r *http.Request, rest string) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	if !h.authorizedClientID(r) {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	segments := strings.Split(strings.Trim(rest, "/"), "/")
+	identifier, err := url.PathUnescape(segments[0])
+	if err != nil || identifier == "" {
+		writeError(w, http.StatusBadRequest, "identifier is required")
+		return
+	}
+
+	params := make(map[string]string)
+	for i := 1; i+1 < len(segments); i += 2 {
+		params[segments[i]] = segments[i+1]
+	}
+	width := parseSize(params["w"])
+	height := parseSize(params["h"])
+	if width == 0 && height == 0 {
+		width, height = defaultImageSize, defaultImageSize
+	} else if width == 0 {
+		width = height
+	} else if height == 0 {
+		height = width
+	}
+	format := "svg"
+	if ext := path.Ext(params["type"]); ext != "" {
+		format = strings.TrimPrefix(ext, ".")
+	}
+
+	brand := h.findBrand(identifier)
+	if brand == nil {
+		if params["fallback"] == "404" {
+			writeError(w, http.StatusNotFound, "Brand not found")
+			return
+		}
+		writeImage(w, r, format, width, height, fallbackColor, initial(identifier))
+		return
+	}
+	writeImage(w, r, format, width, height, brand.color(), initial(brand.Name))
+}
+
+// serveAsset serves the /assets/<domain>/<name>.<ext> links used in brand fixtures.
+func (h *Handler) serveAsset(w http.ResponseWriter, r *http.Request, rest string) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	domain, name, ok := strings.Cut(rest, "/")
+	brand := h.findBrand(domain)
+	if !ok || brand == nil {
+		writeError(w, http.StatusNotFound, "Not found")
+		return
+	}
+	format := strings.TrimPrefix(path.Ext(name), ".")
+	writeImage(w, r, format, defaultImageSize*2, defaultImageSize*2, brand.color(), initial(brand.Name))
+}
+
+// color picks the brand's accent colour, falling back to its first colour.
+func (b *brandFixture) color() string {
+	for _, c := range b.Colors {
+		if c.Type == "accent" {
+			return c.Hex
+		}
+	}
+	if len(b.Colors) > 0 {
+		return b.Colors[0].Hex
+	}
+	return fallbackColor
+}
+
+func writeImage(w http.ResponseWriter, r *http.Request, format string, width, height int, hex, letter string) {
+	var (
+		body        []byte
+		contentType string
+	)
+	switch format {
+	case "svg":
+		contentType = "image/svg+xml"
+		body = []byte(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+
+			`<rect width="100%%" height="100%%" fill="%s"/>`+
+			`<text x="50%%" y="50%%" dominant-baseline="central" text-anchor="middle" font-family="sans-serif" font-size="%d" fill="#ffffff">%s</text>`+
+			`</svg>`, width, height, width, height, hex, min(width, height)/2, html.EscapeString(letter)))
+	case "png":
+		contentType = "image/png"
+		var buf bytes.Buffer
+		_ = png.Encode(&buf, solidImage(width, height, hex))
+		body = buf.Bytes()
+	case "jpg", "jpeg":
+		contentType = "image/jpeg"
+		var buf bytes.Buffer
+		_ = jpeg.Encode(&buf, solidImage(width, height, hex), nil)
+		body = buf.Bytes()
+	case "webp":
+		contentType = "image/webp"
+		body = webp1x1
+	default:
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("unsupported format %q", format))
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	if r.Method == http.MethodHead {
+		return
+	}
+	_, _ = w.Write(body)
+}
+
+// initial is the lettermark character for name.
+func initial(name string) string {
+	for _, r := range name {
+		return strings.ToUpper(string(r))
+	}
+	return "?"
+}
+
+func solidImage(width, height int, hex string) image.Image {
+	fill := parseHex(hex)
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for i := 0; i < len(img.Pix); i += 4 {
+		img.Pix[i], img.Pix[i+1], img.Pix[i+2], img.Pix[i+3] = fill.R, fill.G, fill.B, fill.A
+	}
+	return img
+}
+
+func parseHex(hex string) color.NRGBA {
+	v, err := strconv.ParseUint(strings.TrimPrefix(hex, "#"), 16, 32)
+	if err != nil || len(strings.TrimPrefix(hex, "#")) != 6 {
+		return color.NRGBA{R: 0x9c, G: 0xa3, B: 0xaf, A: 0xff}
+	}
+	return color.NRGBA{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: 0xff}
+}
+
+func parseSize(s string) int {
+	n, err := strconv.Atoi(s)
+	if err != nil || n <= 0 {
+		return 0
+	}
+	if n > maxImageSize {
+		return maxImageSize
+	}
+	return n
+}
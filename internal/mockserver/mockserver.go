@@ -0,0 +1,352 @@
+// Package mockserver implements an offline stand-in for the Brandfetch Brand,
+// Search, Transaction, GraphQL and Logo CDN APIs, backed by fixture files.
+package mockserver
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"math/rand"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+//go:embed fixtures
+var embedded embed.FS
+
+// DefaultErrorStatuses are injected when Options.ErrorRate is set without
+// Options.ErrorStatuses.
+var DefaultErrorStatuses = []int{http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusServiceUnavailable}
+
+// Path prefixes for the endpoints served alongside the /v2 API.
+const (
+	GraphQLPath = "/graphql"
+	CDNPath     = "/cdn"
+	assetsPath  = "/assets"
+)
+
+// baseURLToken in fixtures is replaced with the server's own URL, so asset
+// links in brand responses point back at the mock.
+const baseURLToken = "{{base}}"
+
+// Options configures the mock.
+type Options struct {
+	// Fixtures holds brands/<domain>.json files; nil uses the built-in fixtures.
+	Fixtures fs.FS
+	// Latency delays every response.
+	Latency time.Duration
+	// ErrorRate is the fraction of requests, from 0 to 1, answered with an
+	// injected error instead of the real response.
+	ErrorRate float64
+	// ErrorStatuses are the status codes injected errors pick from.
+	ErrorStatuses []int
+	// Seed makes error injection reproducible; 0 seeds from the clock.
+	Seed int64
+	// ClientID and APIKey, when set, are the only keys accepted. Otherwise
+	// any non-empty key is accepted.
+	ClientID string
+	APIKey   string
+}
+
+// Endpoints are the base URLs to point a client at, matching the CLI's
+// --api-url, --cdn-url and --graphql-url flags.
+type Endpoints struct {
+	API     string
+	CDN     string
+	GraphQL string
+}
+
+// EndpointsFor returns the endpoints of a mock served at baseURL.
+func EndpointsFor(baseURL string) Endpoints {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return Endpoints{
+		API:     baseURL,
+		CDN:     baseURL + CDNPath,
+		GraphQL: baseURL + GraphQLPath,
+	}
+}
+
+type brandFixture struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Domain string `json:"domain"`
+	Colors []struct {
+		Hex  string `json:"hex"`
+		Type string `json:"type"`
+	} `json:"colors"`
+
+	body []byte
+}
+
+type webhook struct {
+	URN           string   `json:"urn"`
+	URL           string   `json:"url"`
+	Enabled       bool     `json:"enabled"`
+	Events        []string `json:"events"`
+	Description   string   `json:"description"`
+	Subscriptions []string `json:"-"`
+}
+
+// Handler serves the mock API.
+type Handler struct {
+	opts   Options
+	brands []*brandFixture
+
+	mu       sync.Mutex
+	rng      *rand.Rand
+	webhooks []*webhook
+}
+
+// NewHandler loads the fixtures and returns a handler serving them.
+func NewHandler(opts Options) (*Handler, error) {
+	if opts.ErrorRate < 0 || opts.ErrorRate > 1 {
+		return nil, fmt.Errorf("error rate must be between 0 and 1, got %g", opts.ErrorRate)
+	}
+	if len(opts.ErrorStatuses) == 0 {
+		opts.ErrorStatuses = DefaultErrorStatuses
+	}
+	for _, status := range opts.ErrorStatuses {
+		if status < 400 || status > 599 {
+			return nil, fmt.Errorf("error status must be 4xx or 5xx, got %d", status)
+		}
+	}
+	fixtures := opts.Fixtures
+	if fixtures == nil {
+		sub, err := fs.Sub(embedded, "fixtures")
+		if err != nil {
+			return nil, err
+		}
+		fixtures = sub
+	}
+	brands, err := loadBrands(fixtures)
+	if err != nil {
+		return nil, err
+	}
+
+	seed := opts.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return &Handler{
+		opts:   opts,
+		brands: brands,
+		rng:    rand.New(rand.NewSource(seed)),
+	}, nil
+}
+
+func loadBrands(fixtures fs.FS) ([]*brandFixture, error) {
+	paths, err := fs.Glob(fixtures, "brands/*.json")
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no brand fixtures found (expected brands/<domain>.json)")
+	}
+	sort.Strings(paths)
+
+	brands := make([]*brandFixture, 0, len(paths))
+	for _, p := range paths {
+		data, err := fs.ReadFile(fixtures, p)
+		if err != nil {
+			return nil, err
+		}
+		brand := &brandFixture{body: data}
+		if err := json.Unmarshal(data, brand); err != nil {
+			return nil, fmt.Errorf("invalid fixture %s: %w", p, err)
+		}
+		if brand.Domain == "" {
+			brand.Domain = strings.TrimSuffix(path.Base(p), ".json")
+		}
+		brands = append(brands, brand)
+	}
+	return brands, nil
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.opts.Latency > 0 {
+		select {
+		case <-time.After(h.opts.Latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	if status, ok := h.injectedError(); ok {
+		if status == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "1")
+		}
+		writeError(w, status, http.StatusText(status))
+		return
+	}
+
+	p := r.URL.Path
+	switch {
+	case p == "/v2/brands/transaction":
+		h.serveTransaction(w, r)
+	case strings.HasPrefix(p, "/v2/brands/"):
+		h.serveBrand(w, r, strings.TrimPrefix(p, "/v2/brands/"))
+	case strings.HasPrefix(p, "/v2/search/"):
+		h.serveSearch(w, r, strings.TrimPrefix(p, "/v2/search/"))
+	case p == GraphQLPath || p == GraphQLPath+"/":
+		h.serveGraphQL(w, r)
+	case strings.HasPrefix(p, CDNPath+"/"):
+		h.serveLogo(w, r, strings.TrimPrefix(p, CDNPath+"/"))
+	case strings.HasPrefix(p, assetsPath+"/"):
+		h.serveAsset(w, r, strings.TrimPrefix(p, assetsPath+"/"))
+	default:
+		writeError(w, http.StatusNotFound, "Not found")
+	}
+}
+
+func (h *Handler) injectedError() (int, bool) {
+	if h.opts.ErrorRate == 0 {
+		return 0, false
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.rng.Float64() >= h.opts.ErrorRate {
+		return 0, false
+	}
+	return h.opts.ErrorStatuses[h.rng.Intn(len(h.opts.ErrorStatuses))], true
+}
+
+// authorizedBearer checks the Brand API key in the Authorization header.
+func (h *Handler) authorizedBearer(r *http.Request) bool {
+	key := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return acceptKey(key, h.opts.APIKey)
+}
+
+// authorizedClientID checks the Logo API key in the c query parameter.
+func (h *Handler) authorizedClientID(r *http.Request) bool {
+	return acceptKey(r.URL.Query().Get("c"), h.opts.ClientID)
+}
+
+func acceptKey(got, want string) bool {
+	if want != "" {
+		return got == want
+	}
+	return strings.TrimSpace(got) != ""
+}
+
+// findBrand looks a brand up by domain, brand ID or URN.
+func (h *Handler) findBrand(identifier string) *brandFixture {
+	identifier = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(identifier), "www."))
+	for _, b := range h.brands {
+		if strings.ToLower(b.Domain) == identifier || strings.ToLower(b.ID) == identifier ||
+			"urn:bf:brand:"+strings.ToLower(b.ID) == identifier {
+			return b
+		}
+	}
+	return nil
+}
+
+func (h *Handler) serveBrand(w http.ResponseWriter, r *http.Request, identifier string) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	if !h.authorizedBearer(r) {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	brand := h.findBrand(identifier)
+	if brand == nil {
+		writeError(w, http.StatusNotFound, "Brand not found")
+		return
+	}
+	writeBrand(w, r, brand)
+}
+
+func (h *Handler) serveTransaction(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	if !h.authorizedBearer(r) {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	var payload struct {
+		TransactionLabel string `json:"transactionLabel"`
+		CountryCode      string `json:"countryCode"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil || strings.TrimSpace(payload.TransactionLabel) == "" {
+		writeError(w, http.StatusBadRequest, "transactionLabel is required")
+		return
+	}
+
+	label := strings.ToLower(payload.TransactionLabel)
+	for _, b := range h.brands {
+		name := strings.ToLower(b.Name)
+		domainLabel := strings.ToLower(strings.SplitN(b.Domain, ".", 2)[0])
+		if (name != "" && strings.Contains(label, name)) || strings.Contains(label, domainLabel) {
+			writeBrand(w, r, b)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "Brand not found")
+}
+
+type searchResult struct {
+	Name    string `json:"name"`
+	Domain  string `json:"domain"`
+	Claimed bool   `json:"claimed"`
+	Icon    string `json:"icon"`
+	BrandID string `json:"brandId"`
+}
+
+func (h *Handler) serveSearch(w http.ResponseWriter, r *http.Request, query string) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	if !h.authorizedClientID(r) {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	query = strings.ToLower(strings.TrimSpace(query))
+	results := []searchResult{}
+	for _, b := range h.brands {
+		if query != "" && !strings.Contains(strings.ToLower(b.Name), query) && !strings.Contains(strings.ToLower(b.Domain), query) {
+			continue
+		}
+		results = append(results, searchResult{
+			Name:    b.Name,
+			Domain:  b.Domain,
+			Claimed: true,
+			Icon:    fmt.Sprintf("%s%s/%s/icon.png", baseURL(r), assetsPath, b.Domain),
+			BrandID: b.ID,
+		})
+	}
+	writeJSON(w, http.StatusOK, results)
+}
+
+func writeBrand(w http.ResponseWriter, r *http.Request, b *brandFixture) {
+	body := strings.ReplaceAll(string(b.body), baseURLToken, baseURL(r))
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(body))
+}
+
+func baseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"message": message})
+}
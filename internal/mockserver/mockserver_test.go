@@ -0,0 +1,189 @@
+package mockserver_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/salmonumbrella/brandfetch-cli/internal/api"
+	"github.com/salmonumbrella/brandfetch-cli/internal/mockserver"
+	"github.com/salmonumbrella/brandfetch-cli/pkg/brandfetch/brandfetchtest"
+)
+
+func newClient(t *testing.T, opts mockserver.Options) (*api.Client, string) {
+	t.Helper()
+	server := brandfetchtest.NewServer(t, opts)
+	endpoints := mockserver.EndpointsFor(server.URL)
+	return api.NewClientWithOptions("test_client_id", "test_api_key", api.ClientOptions{
+		BaseURL:     endpoints.API,
+		LogoBaseURL: endpoints.CDN,
+		GraphQLURL:  endpoints.GraphQL,
+	}), server.URL
+}
+
+func TestBrandSearchAndTransaction(t *testing.T) {
+	client, baseURL := newClient(t, mockserver.Options{})
+	ctx := context.Background()
+
+	brand, err := client.GetBrand(ctx, "https://www.github.com")
+	if err != nil {
+		t.Fatalf("GetBrand() error = %v", err)
+	}
+	if brand.Name != "GitHub" || len(brand.Logos) == 0 {
+		t.Fatalf("unexpected brand: %+v", brand)
+	}
+	if src := brand.Logos[0].Formats[0].Src; !strings.HasPrefix(src, baseURL+"/assets/github.com/") {
+		t.Errorf("asset link should point at the mock: %s", src)
+	}
+	if byID, err := client.GetBrand(ctx, brand.ID); err != nil || byID.Domain != "github.com" {
+		t.Errorf("GetBrand(id) = %v, %v", byID, err)
+	}
+	if _, err := client.GetBrand(ctx, "unknown.example"); !errors.Is(err, api.ErrNotFound) {
+		t.Errorf("GetBrand(unknown) error = %v, want not found", err)
+	}
+
+	results, err := client.Search(ctx, "stri", 0)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(results) != 1 || results[0].Domain != "stripe.com" {
+		t.Errorf("Search() = %+v", results)
+	}
+
+	txn, err := client.CreateTransaction(ctx, "STRIPE* PAYMENT 4242", "US")
+	if err != nil {
+		t.Fatalf("CreateTransaction() error = %v", err)
+	}
+	if txn.Domain != "stripe.com" {
+		t.Errorf("CreateTransaction() domain = %s", txn.Domain)
+	}
+}
+
+func TestUnauthorized(t *testing.T) {
+	server := brandfetchtest.NewServer(t, mockserver.Options{APIKey: "expected"})
+	client := api.NewClientWithOptions("cid", "wrong", api.ClientOptions{BaseURL: server.URL})
+	if _, err := client.GetBrand(context.Background(), "github.com"); !errors.Is(err, api.ErrUnauthorized) {
+		t.Errorf("GetBrand() error = %v, want unauthorized", err)
+	}
+}
+
+func TestWebhooks(t *testing.T) {
+	client, _ := newClient(t, mockserver.Options{})
+	ctx := context.Background()
+
+	data, err := client.GraphQL(ctx, `mutation CreateWebhook($input: CreateWebhookInput!) { createWebhook(input: $input) { success } }`, map[string]interface{}{
+		"input": map[string]interface{}{"url": "https://example.com/hook", "events": []string{"brand.updated"}},
+	})
+	if err != nil {
+		t.Fatalf("createWebhook error = %v", err)
+	}
+	if !strings.Contains(string(data), `"urn":"urn:bf:webhook:mock1"`) {
+		t.Errorf("createWebhook data = %s", data)
+	}
+
+	data, err = client.GraphQL(ctx, `mutation AddWebhookSubscriptions($input: AddWebhookSubscriptionsInput!) { addWebhookSubscriptions(input: $input) { success } }`, map[string]interface{}{
+		"input": map[string]interface{}{"webhookUrn": "urn:bf:webhook:mock1", "subscriptions": []string{"urn:bf:brand:idZAyF9rlg"}},
+	})
+	if err != nil || !strings.Contains(string(data), `"success":true`) {
+		t.Errorf("addWebhookSubscriptions = %s, %v", data, err)
+	}
+
+	data, err = client.GraphQL(ctx, `query ListWebhooks { webhooks { edges { node { urn } } } }`, nil)
+	if err != nil || !strings.Contains(string(data), "https://example.com/hook") {
+		t.Errorf("webhooks = %s, %v", data, err)
+	}
+
+	var gqlErr *api.GraphQLError
+	if _, err := client.GraphQL(ctx, `query BrandWebhooks { brand { webhooks { urn } } }`, nil); !errors.As(err, &gqlErr) {
+		t.Errorf("unsupported operation error = %v, want GraphQL error", err)
+	}
+}
+
+func TestLogoCDN(t *testing.T) {
+	client, _ := newClient(t, mockserver.Options{})
+
+	tests := []struct {
+		opts        api.LogoOptions
+		status      int
+		contentType string
+	}{
+		{api.LogoOptions{Identifier: "github.com"}, http.StatusOK, "image/svg+xml"},
+		{api.LogoOptions{Identifier: "github.com", Type: "icon", Format: "png", Width: 64}, http.StatusOK, "image/png"},
+		{api.LogoOptions{Identifier: "stripe.com", Format: "webp"}, http.StatusOK, "image/webp"},
+		{api.LogoOptions{Identifier: "unknown.example"}, http.StatusOK, "image/svg+xml"},
+		{api.LogoOptions{Identifier: "unknown.example", Fallback: "404"}, http.StatusNotFound, "application/json"},
+	}
+	for _, tt := range tests {
+		u, err := client.BuildLogoURL(tt.opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := http.Get(u)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != tt.status || resp.Header.Get("Content-Type") != tt.contentType {
+			t.Errorf("%s: status %d %s, want %d %s", u, resp.StatusCode, resp.Header.Get("Content-Type"), tt.status, tt.contentType)
+		}
+		if tt.status == http.StatusOK && len(body) == 0 {
+			t.Errorf("%s: empty body", u)
+		}
+	}
+}
+
+func TestErrorInjectionAndLatency(t *testing.T) {
+	client, _ := newClient(t, mockserver.Options{
+		ErrorRate:     1,
+		ErrorStatuses: []int{http.StatusTooManyRequests},
+		Latency:       20 * time.Millisecond,
+	})
+
+	start := time.Now()
+	_, err := client.GetBrand(context.Background(), "github.com")
+	if !errors.Is(err, api.ErrRateLimited) {
+		t.Errorf("GetBrand() error = %v, want rate limited", err)
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("response took %v, want at least the configured latency", elapsed)
+	}
+}
+
+func TestCustomFixtures(t *testing.T) {
+	fixtures := fstest.MapFS{
+		"brands/acme.test.json": {Data: []byte(`{"id":"id_acme","name":"Acme","colors":[{"hex":"#ff0000","type":"accent"}],"custom":{"kept":true}}`)},
+	}
+	server := brandfetchtest.NewServer(t, mockserver.Options{Fixtures: fixtures})
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/v2/brands/acme.test", nil)
+	req.Header.Set("Authorization", "Bearer key")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), `"custom":{"kept":true}`) {
+		t.Errorf("fixture not served verbatim: %d %s", resp.StatusCode, body)
+	}
+}
+
+func TestNewHandlerValidatesOptions(t *testing.T) {
+	invalid := []mockserver.Options{
+		{ErrorRate: 1.5},
+		{ErrorRate: 0.5, ErrorStatuses: []int{200}},
+		{Fixtures: fstest.MapFS{}},
+		{Fixtures: fstest.MapFS{"brands/bad.json": {Data: []byte(`{`)}}},
+	}
+	for _, opts := range invalid {
+		if _, err := mockserver.NewHandler(opts); err == nil {
+			t.Errorf("NewHandler(%+v) expected error", opts)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package mockserver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Server runs the mock on a TCP listener.
+type Server struct {
+	listener net.Listener
+	server   *http.Server
+}
+
+// Listen loads the fixtures and listens on addr, e.g. "127.0.0.1:8080".
+func Listen(addr string, opts Options) (*Server, error) {
+	handler, err := NewHandler(opts)
+	if err != nil {
+		return nil, err
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	return &Server{
+		listener: listener,
+		server: &http.Server{
+			Handler:           handler,
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+	}, nil
+}
+
+// URL returns the server's base URL.
+func (s *Server) URL() string {
+	return fmt.Sprintf("http://%s", s.listener.Addr().String())
+}
+
+// Endpoints returns the URLs to point a client at.
+func (s *Server) Endpoints() Endpoints {
+	return EndpointsFor(s.URL())
+}
+
+// Serve handles requests until Shutdown is called.
+func (s *Server) Serve() error {
+	if err := s.server.Serve(s.listener); err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// Shutdown stops the server, waiting for in-flight requests until ctx ends.
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
@@ -4,8 +4,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
 	"strconv"
 	"strings"
+
+	"github.com/salmonumbrella/brandfetch-cli/pkg/brandfetch"
 )
 
 // Format represents output format.
@@ -82,6 +85,18 @@ func PrintJSON(w io.Writer, data interface{}) error {
 	return enc.Encode(data)
 }
 
+// PrintRaw writes an API response body unchanged, ending it with a newline.
+func PrintRaw(w io.Writer, data []byte) error {
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	if len(data) > 0 && data[len(data)-1] != '\n' {
+		_, err := io.WriteString(w, "\n")
+		return err
+	}
+	return nil
+}
+
 // PrintText writes formatted text with newline.
 func PrintText(w io.Writer, format string, args ...interface{}) {
 	fmt.Fprintf(w, format+"\n", args...)
@@ -97,6 +112,7 @@ type LogoResult struct {
 	Fallback   string `json:"fallback,omitempty"`
 	Width      int    `json:"width,omitempty"`
 	Height     int    `json:"height,omitempty"`
+	Variant    string `json:"variant,omitempty"`
 }
 
 // FormatLogo formats logo result.
@@ -108,28 +124,89 @@ func FormatLogo(logo *LogoResult, format Format) string {
 	return logo.URL
 }
 
+// ResolvedLogo represents what the Logo CDN served for a logo URL.
+type ResolvedLogo struct {
+	URL         string `json:"url"`
+	FinalURL    string `json:"finalUrl"`
+	Status      int    `json:"status"`
+	ContentType string `json:"contentType,omitempty"`
+	Size        int64  `json:"size"`
+	Width       int    `json:"width,omitempty"`
+	Height      int    `json:"height,omitempty"`
+	Fallback    bool   `json:"fallback"`
+	Variant     string `json:"variant,omitempty"`
+}
+
+// FormatResolvedLogo formats a resolved logo.
+func FormatResolvedLogo(logo *ResolvedLogo, format Format) string {
+	if format == FormatJSON {
+		data, _ := json.MarshalIndent(logo, "", "  ")
+		return string(data)
+	}
+
+	var sb strings.Builder
+	if logo.Variant != "" {
+		sb.WriteString(fmt.Sprintf("Variant:      %s\n", logo.Variant))
+	}
+	sb.WriteString(fmt.Sprintf("URL:          %s\n", logo.URL))
+	if logo.FinalURL != "" && logo.FinalURL != logo.URL {
+		sb.WriteString(fmt.Sprintf("Final URL:    %s\n", logo.FinalURL))
+	}
+	sb.WriteString(fmt.Sprintf("Status:       %d %s\n", logo.Status, http.StatusText(logo.Status)))
+	if logo.ContentType != "" {
+		sb.WriteString(fmt.Sprintf("Content type: %s\n", logo.ContentType))
+	}
+	sb.WriteString(fmt.Sprintf("Size:         %d bytes\n", logo.Size))
+	if logo.Width > 0 && logo.Height > 0 {
+		sb.WriteString(fmt.Sprintf("Dimensions:   %dx%d\n", logo.Width, logo.Height))
+	}
+	fallback := "no"
+	if logo.Fallback {
+		fallback = "yes (no logo for this identifier)"
+	}
+	sb.WriteString(fmt.Sprintf("Fallback:     %s", fallback))
+	return sb.String()
+}
+
 // BrandResult represents brand output data.
 type BrandResult struct {
-	ID              string      `json:"id,omitempty"`
-	Name            string      `json:"name"`
-	Domain          string      `json:"domain"`
-	Description     string      `json:"description,omitempty"`
-	LongDescription string      `json:"longDescription,omitempty"`
-	Claimed         bool        `json:"claimed,omitempty"`
-	QualityScore    float64     `json:"qualityScore,omitempty"`
-	IsNSFW          bool        `json:"isNsfw,omitempty"`
-	URN             string      `json:"urn,omitempty"`
-	Logos           []LogoInfo  `json:"logos,omitempty"`
-	Colors          []ColorInfo `json:"colors,omitempty"`
-	Fonts           []FontInfo  `json:"fonts,omitempty"`
-	Links           []LinkInfo  `json:"links,omitempty"`
+	ID              string       `json:"id,omitempty"`
+	Name            string       `json:"name"`
+	Domain          string       `json:"domain"`
+	Description     string       `json:"description,omitempty"`
+	LongDescription string       `json:"longDescription,omitempty"`
+	Claimed         bool         `json:"claimed,omitempty"`
+	QualityScore    float64      `json:"qualityScore,omitempty"`
+	IsNSFW          bool         `json:"isNsfw,omitempty"`
+	URN             string       `json:"urn,omitempty"`
+	Company         *CompanyInfo `json:"company,omitempty"`
+	Logos           []LogoInfo   `json:"logos,omitempty"`
+	Colors          []ColorInfo  `json:"colors,omitempty"`
+	Fonts           []FontInfo   `json:"fonts,omitempty"`
+	Links           []LinkInfo   `json:"links,omitempty"`
+}
+
+type CompanyInfo struct {
+	Kind        string         `json:"kind,omitempty"`
+	Employees   int            `json:"employees,omitempty"`
+	FoundedYear int            `json:"foundedYear,omitempty"`
+	Location    string         `json:"location,omitempty"`
+	Industries  []IndustryInfo `json:"industries,omitempty"`
+}
+
+type IndustryInfo struct {
+	Name   string  `json:"name"`
+	Slug   string  `json:"slug,omitempty"`
+	Parent string  `json:"parent,omitempty"`
+	Score  float64 `json:"score,omitempty"`
 }
 
 type LogoInfo struct {
-	Type   string `json:"type"`
-	Theme  string `json:"theme"`
-	URL    string `json:"url"`
-	Format string `json:"format"`
+	Type   string   `json:"type"`
+	Theme  string   `json:"theme"`
+	URL    string   `json:"url"`
+	Format string   `json:"format"`
+	Tags   []string `json:"tags,omitempty"`
 }
 
 type ColorInfo struct {
@@ -180,10 +257,43 @@ func FormatBrand(brand *BrandResult, format Format, colorize bool) string {
 		sb.WriteString("NSFW: yes\n")
 	}
 
+	if c := brand.Company; c != nil {
+		sb.WriteString("\nCompany:\n")
+		if c.Kind != "" {
+			sb.WriteString(fmt.Sprintf("  Kind: %s\n", formatCompanyKind(c.Kind)))
+		}
+		if c.Employees > 0 {
+			sb.WriteString(fmt.Sprintf("  Employees: %d\n", c.Employees))
+		}
+		if c.FoundedYear > 0 {
+			sb.WriteString(fmt.Sprintf("  Founded: %d\n", c.FoundedYear))
+		}
+		if c.Location != "" {
+			sb.WriteString(fmt.Sprintf("  Location: %s\n", c.Location))
+		}
+		if len(c.Industries) > 0 {
+			sb.WriteString("  Industries:\n")
+			for _, ind := range c.Industries {
+				line := ind.Name
+				if ind.Parent != "" {
+					line = ind.Parent + " > " + line
+				}
+				if ind.Score > 0 {
+					line = fmt.Sprintf("%s (%.2f)", line, ind.Score)
+				}
+				sb.WriteString("    " + line + "\n")
+			}
+		}
+	}
+
 	if len(brand.Logos) > 0 {
 		sb.WriteString(fmt.Sprintf("\nLogos: %d available\n", len(brand.Logos)))
 		for _, l := range brand.Logos {
-			sb.WriteString(fmt.Sprintf("  - %s (%s): %s\n", l.Type, l.Theme, l.URL))
+			line := fmt.Sprintf("  - %s (%s): %s", l.Type, l.Theme, l.URL)
+			if len(l.Tags) > 0 {
+				line += " [" + strings.Join(l.Tags, ", ") + "]"
+			}
+			sb.WriteString(line + "\n")
 		}
 	}
 
@@ -204,6 +314,15 @@ func FormatBrand(brand *BrandResult, format Format, colorize bool) string {
 	return sb.String()
 }
 
+// formatCompanyKind turns API enums such as PRIVATELY_HELD into "Privately held".
+func formatCompanyKind(kind string) string {
+	s := strings.ToLower(strings.ReplaceAll(kind, "_", " "))
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
 // SearchResult represents search output data.
 type SearchResult struct {
 	Name    string `json:"name"`
@@ -321,205 +440,32 @@ func FormatQuick(result *QuickResult, format Format, colorize bool) string {
 
 // FormatQuickCSS formats quick result as CSS custom properties.
 func FormatQuickCSS(result *QuickResult) string {
-	var sb strings.Builder
-	sb.WriteString(":root {\n")
-
-	// Colors
-	if len(result.Colors) > 0 {
-		sb.WriteString("  /* Colors */\n")
-		colorVars := buildColorVariables(result.Colors)
-		for _, v := range colorVars {
-			sb.WriteString(fmt.Sprintf("  %s: %s;\n", v.name, v.value))
-		}
-	}
-
-	// Fonts
-	if len(result.Fonts) > 0 {
-		if len(result.Colors) > 0 {
-			sb.WriteString("\n")
-		}
-		sb.WriteString("  /* Fonts */\n")
-		fontVars := buildFontVariables(result.Fonts)
-		for _, v := range fontVars {
-			sb.WriteString(fmt.Sprintf("  %s: %s;\n", v.name, v.value))
-		}
-	}
-
-	sb.WriteString("}")
-	return sb.String()
-}
-
-type cssVar struct {
-	name  string
-	value string
-}
-
-// buildColorVariables generates CSS variable names for colors, handling duplicates.
-func buildColorVariables(colors []ColorInfo) []cssVar {
-	// Count occurrences of each type
-	typeCounts := make(map[string]int)
-	for _, c := range colors {
-		typeCounts[c.Type]++
-	}
-
-	// Track which types we've seen (for numbering duplicates)
-	typeIndex := make(map[string]int)
-
-	var vars []cssVar
-	for _, c := range colors {
-		varName := fmt.Sprintf("--color-%s", c.Type)
-
-		// If there are duplicates of this type, append a number
-		if typeCounts[c.Type] > 1 {
-			typeIndex[c.Type]++
-			varName = fmt.Sprintf("--color-%s-%d", c.Type, typeIndex[c.Type])
-		}
-
-		vars = append(vars, cssVar{name: varName, value: c.Hex})
-	}
-
-	return vars
+	return brandfetch.ExportCSS(quickTheme(result))
 }
 
 // FormatQuickTailwind formats quick result as Tailwind CSS config JavaScript.
 func FormatQuickTailwind(result *QuickResult) string {
-	var sb strings.Builder
-
-	// Header comment
-	sb.WriteString(fmt.Sprintf("// Tailwind CSS config for %s\n", result.Name))
-	sb.WriteString("// Add to your tailwind.config.js theme.extend\n")
-	sb.WriteString("module.exports = {\n")
-
-	// Colors
-	if len(result.Colors) > 0 {
-		sb.WriteString("  colors: {\n")
-		colorEntries := buildTailwindColors(result.Colors)
-		for _, entry := range colorEntries {
-			sb.WriteString(entry)
-		}
-		sb.WriteString("  },\n")
-	}
-
-	// Fonts
-	if len(result.Fonts) > 0 {
-		sb.WriteString("  fontFamily: {\n")
-		fontEntries := buildTailwindFonts(result.Fonts)
-		for _, entry := range fontEntries {
-			sb.WriteString(entry)
-		}
-		sb.WriteString("  },\n")
-	}
-
-	sb.WriteString("}")
-	return sb.String()
+	return brandfetch.ExportTailwind(quickTheme(result))
 }
 
-// buildTailwindColors generates Tailwind color entries, handling duplicates with object nesting.
-func buildTailwindColors(colors []ColorInfo) []string {
-	// Group colors by type to handle duplicates properly
-	typeColors := make(map[string][]string)
-	typeOrder := []string{} // Preserve order of first occurrence
-
-	for _, c := range colors {
-		if _, exists := typeColors[c.Type]; !exists {
-			typeOrder = append(typeOrder, c.Type)
-		}
-		typeColors[c.Type] = append(typeColors[c.Type], c.Hex)
-	}
-
-	var entries []string
-	for _, colorType := range typeOrder {
-		hexValues := typeColors[colorType]
-		if len(hexValues) == 1 {
-			// Single color: simple key-value
-			entries = append(entries, fmt.Sprintf("    %s: '%s',\n", colorType, hexValues[0]))
-		} else {
-			// Multiple colors: nested object
-			var nested strings.Builder
-			nested.WriteString(fmt.Sprintf("    %s: {\n", colorType))
-			for i, hex := range hexValues {
-				nested.WriteString(fmt.Sprintf("      %d: '%s',\n", i+1, hex))
-			}
-			nested.WriteString("    },\n")
-			entries = append(entries, nested.String())
-		}
+// quickTheme converts a quick result for the SDK exporters.
+func quickTheme(result *QuickResult) brandfetch.Theme {
+	theme := brandfetch.Theme{Name: result.Name, Domain: result.Domain}
+	for _, c := range result.Colors {
+		theme.Colors = append(theme.Colors, brandfetch.Color{Hex: c.Hex, Type: c.Type, Brightness: c.Brightness})
 	}
-
-	return entries
-}
-
-// buildTailwindFonts generates Tailwind fontFamily entries, handling duplicates.
-func buildTailwindFonts(fonts []FontInfo) []string {
-	// Count occurrences of each type
-	typeCounts := make(map[string]int)
-	for _, f := range fonts {
-		typeCounts[f.Type]++
+	for _, f := range result.Fonts {
+		theme.Fonts = append(theme.Fonts, brandfetch.Font{Name: f.Name, Type: f.Type})
 	}
-
-	// Track which types we've seen (for numbering duplicates)
-	typeIndex := make(map[string]int)
-
-	// Track unique fonts for deduplication (same name + type = skip)
-	seen := make(map[string]bool)
-
-	var entries []string
-	for _, f := range fonts {
-		key := f.Name + "|" + f.Type
-		if seen[key] {
-			continue
-		}
-		seen[key] = true
-
-		if typeCounts[f.Type] > 1 {
-			// Duplicate types - but for fonts we typically just list them
-			// The spec says to use object nesting for colors, but for fonts
-			// we'll follow the same pattern as CSS and number them
-			typeIndex[f.Type]++
-			entries = append(entries, fmt.Sprintf("    %s%d: ['\"%s\"', 'sans-serif'],\n", f.Type, typeIndex[f.Type], f.Name))
-		} else {
-			entries = append(entries, fmt.Sprintf("    %s: ['\"%s\"', 'sans-serif'],\n", f.Type, f.Name))
-		}
-	}
-
-	return entries
+	return theme
 }
 
-// buildFontVariables generates CSS variable names for fonts, handling duplicates.
-func buildFontVariables(fonts []FontInfo) []cssVar {
-	// Count occurrences of each type
-	typeCounts := make(map[string]int)
-	for _, f := range fonts {
-		typeCounts[f.Type]++
-	}
-
-	// Track which types we've seen (for numbering duplicates)
-	typeIndex := make(map[string]int)
-
-	// Track unique fonts for deduplication (same name + type = skip)
-	seen := make(map[string]bool)
-
-	var vars []cssVar
-	for _, f := range fonts {
-		key := f.Name + "|" + f.Type
-		if seen[key] {
-			continue
-		}
-		seen[key] = true
-
-		varName := fmt.Sprintf("--font-%s", f.Type)
-
-		// If there are duplicates of this type (after dedup), append a number
-		if typeCounts[f.Type] > 1 {
-			typeIndex[f.Type]++
-			varName = fmt.Sprintf("--font-%s-%d", f.Type, typeIndex[f.Type])
-		}
-
-		// Quote font name and add sans-serif fallback
-		value := fmt.Sprintf("'%s', sans-serif", f.Name)
-		vars = append(vars, cssVar{name: varName, value: value})
+func quickThemes(results []*QuickResult) []brandfetch.Theme {
+	themes := make([]brandfetch.Theme, len(results))
+	for i, result := range results {
+		themes[i] = quickTheme(result)
 	}
-
-	return vars
+	return themes
 }
 
 // FormatQuickBatch formats multiple quick results for batch output.
@@ -551,257 +497,12 @@ func FormatQuickBatch(results []*QuickResult, format Format, colorize bool) stri
 
 // FormatQuickCSSBatch formats multiple quick results as CSS with brand-prefixed variables.
 func FormatQuickCSSBatch(results []*QuickResult) string {
-	if len(results) == 0 {
-		return ":root {\n}"
-	}
-
-	// Single result: use original format
-	if len(results) == 1 {
-		return FormatQuickCSS(results[0])
-	}
-
-	var sb strings.Builder
-	sb.WriteString(":root {\n")
-
-	for i, result := range results {
-		if i > 0 {
-			sb.WriteString("\n")
-		}
-		brandPrefix := sanitizeCSSName(result.Domain)
-		sb.WriteString(fmt.Sprintf("  /* %s */\n", result.Name))
-
-		// Colors
-		if len(result.Colors) > 0 {
-			colorVars := buildColorVariablesWithPrefix(result.Colors, brandPrefix)
-			for _, v := range colorVars {
-				sb.WriteString(fmt.Sprintf("  %s: %s;\n", v.name, v.value))
-			}
-		}
-
-		// Fonts
-		if len(result.Fonts) > 0 {
-			fontVars := buildFontVariablesWithPrefix(result.Fonts, brandPrefix)
-			for _, v := range fontVars {
-				sb.WriteString(fmt.Sprintf("  %s: %s;\n", v.name, v.value))
-			}
-		}
-	}
-
-	sb.WriteString("}")
-	return sb.String()
-}
-
-// sanitizeCSSName converts a domain to a valid CSS variable name prefix.
-func sanitizeCSSName(domain string) string {
-	// Remove common TLDs
-	name := strings.TrimSuffix(domain, ".com")
-	name = strings.TrimSuffix(name, ".io")
-	name = strings.TrimSuffix(name, ".org")
-	name = strings.TrimSuffix(name, ".net")
-	name = strings.TrimSuffix(name, ".co")
-	// Replace dots with hyphens
-	name = strings.ReplaceAll(name, ".", "-")
-	return name
-}
-
-// buildColorVariablesWithPrefix generates CSS variable names with brand prefix.
-func buildColorVariablesWithPrefix(colors []ColorInfo, prefix string) []cssVar {
-	typeCounts := make(map[string]int)
-	for _, c := range colors {
-		typeCounts[c.Type]++
-	}
-
-	typeIndex := make(map[string]int)
-
-	var vars []cssVar
-	for _, c := range colors {
-		varName := fmt.Sprintf("--%s-color-%s", prefix, c.Type)
-
-		if typeCounts[c.Type] > 1 {
-			typeIndex[c.Type]++
-			varName = fmt.Sprintf("--%s-color-%s-%d", prefix, c.Type, typeIndex[c.Type])
-		}
-
-		vars = append(vars, cssVar{name: varName, value: c.Hex})
-	}
-
-	return vars
-}
-
-// buildFontVariablesWithPrefix generates CSS font variable names with brand prefix.
-func buildFontVariablesWithPrefix(fonts []FontInfo, prefix string) []cssVar {
-	typeCounts := make(map[string]int)
-	for _, f := range fonts {
-		typeCounts[f.Type]++
-	}
-
-	typeIndex := make(map[string]int)
-	seen := make(map[string]bool)
-
-	var vars []cssVar
-	for _, f := range fonts {
-		key := f.Name + "|" + f.Type
-		if seen[key] {
-			continue
-		}
-		seen[key] = true
-
-		varName := fmt.Sprintf("--%s-font-%s", prefix, f.Type)
-
-		if typeCounts[f.Type] > 1 {
-			typeIndex[f.Type]++
-			varName = fmt.Sprintf("--%s-font-%s-%d", prefix, f.Type, typeIndex[f.Type])
-		}
-
-		value := fmt.Sprintf("'%s', sans-serif", f.Name)
-		vars = append(vars, cssVar{name: varName, value: value})
-	}
-
-	return vars
+	return brandfetch.ExportCSS(quickThemes(results)...)
 }
 
 // FormatQuickTailwindBatch formats multiple quick results as Tailwind config with nested brand objects.
 func FormatQuickTailwindBatch(results []*QuickResult) string {
-	if len(results) == 0 {
-		return "module.exports = {\n}"
-	}
-
-	// Single result: use original format
-	if len(results) == 1 {
-		return FormatQuickTailwind(results[0])
-	}
-
-	var sb strings.Builder
-	sb.WriteString("// Tailwind CSS config for multiple brands\n")
-	sb.WriteString("// Add to your tailwind.config.js theme.extend\n")
-	sb.WriteString("module.exports = {\n")
-
-	// Colors section
-	hasColors := false
-	for _, result := range results {
-		if len(result.Colors) > 0 {
-			hasColors = true
-			break
-		}
-	}
-
-	if hasColors {
-		sb.WriteString("  colors: {\n")
-		for _, result := range results {
-			if len(result.Colors) == 0 {
-				continue
-			}
-			brandKey := sanitizeTailwindKey(result.Domain)
-			sb.WriteString(fmt.Sprintf("    %s: {\n", brandKey))
-			colorEntries := buildTailwindColorsNested(result.Colors)
-			for _, entry := range colorEntries {
-				sb.WriteString(entry)
-			}
-			sb.WriteString("    },\n")
-		}
-		sb.WriteString("  },\n")
-	}
-
-	// Fonts section
-	hasFonts := false
-	for _, result := range results {
-		if len(result.Fonts) > 0 {
-			hasFonts = true
-			break
-		}
-	}
-
-	if hasFonts {
-		sb.WriteString("  fontFamily: {\n")
-		for _, result := range results {
-			if len(result.Fonts) == 0 {
-				continue
-			}
-			brandKey := sanitizeTailwindKey(result.Domain)
-			sb.WriteString(fmt.Sprintf("    %s: {\n", brandKey))
-			fontEntries := buildTailwindFontsNested(result.Fonts)
-			for _, entry := range fontEntries {
-				sb.WriteString(entry)
-			}
-			sb.WriteString("    },\n")
-		}
-		sb.WriteString("  },\n")
-	}
-
-	sb.WriteString("}")
-	return sb.String()
-}
-
-// sanitizeTailwindKey converts a domain to a valid Tailwind config key.
-func sanitizeTailwindKey(domain string) string {
-	name := strings.TrimSuffix(domain, ".com")
-	name = strings.TrimSuffix(name, ".io")
-	name = strings.TrimSuffix(name, ".org")
-	name = strings.TrimSuffix(name, ".net")
-	name = strings.TrimSuffix(name, ".co")
-	name = strings.ReplaceAll(name, ".", "_")
-	name = strings.ReplaceAll(name, "-", "_")
-	return name
-}
-
-// buildTailwindColorsNested generates Tailwind color entries for nesting inside a brand object.
-func buildTailwindColorsNested(colors []ColorInfo) []string {
-	typeColors := make(map[string][]string)
-	typeOrder := []string{}
-
-	for _, c := range colors {
-		if _, exists := typeColors[c.Type]; !exists {
-			typeOrder = append(typeOrder, c.Type)
-		}
-		typeColors[c.Type] = append(typeColors[c.Type], c.Hex)
-	}
-
-	var entries []string
-	for _, colorType := range typeOrder {
-		hexValues := typeColors[colorType]
-		if len(hexValues) == 1 {
-			entries = append(entries, fmt.Sprintf("      %s: '%s',\n", colorType, hexValues[0]))
-		} else {
-			var nested strings.Builder
-			nested.WriteString(fmt.Sprintf("      %s: {\n", colorType))
-			for i, hex := range hexValues {
-				nested.WriteString(fmt.Sprintf("        %d: '%s',\n", i+1, hex))
-			}
-			nested.WriteString("      },\n")
-			entries = append(entries, nested.String())
-		}
-	}
-
-	return entries
-}
-
-// buildTailwindFontsNested generates Tailwind font entries for nesting inside a brand object.
-func buildTailwindFontsNested(fonts []FontInfo) []string {
-	typeCounts := make(map[string]int)
-	for _, f := range fonts {
-		typeCounts[f.Type]++
-	}
-
-	typeIndex := make(map[string]int)
-	seen := make(map[string]bool)
-
-	var entries []string
-	for _, f := range fonts {
-		key := f.Name + "|" + f.Type
-		if seen[key] {
-			continue
-		}
-		seen[key] = true
-
-		if typeCounts[f.Type] > 1 {
-			typeIndex[f.Type]++
-			entries = append(entries, fmt.Sprintf("      %s%d: ['\"%s\"', 'sans-serif'],\n", f.Type, typeIndex[f.Type], f.Name))
-		} else {
-			entries = append(entries, fmt.Sprintf("      %s: ['\"%s\"', 'sans-serif'],\n", f.Type, f.Name))
-		}
-	}
-
-	return entries
+	return brandfetch.ExportTailwind(quickThemes(results)...)
 }
 
 func colorizeHex(hex string, enabled bool) string {
@@ -821,3 +522,8 @@ func colorizeHex(hex string, enabled bool) string {
 
 	return fmt.Sprintf("\x1b[38;2;%d;%d;%dm%s\x1b[0m", r, g, b, hex)
 }
+
+// FormatQuickTokensBatch formats quick results as design tokens JSON, keyed by brand.
+func FormatQuickTokensBatch(results []*QuickResult) string {
+	return brandfetch.ExportDesignTokens(quickThemes(results)...)
+}
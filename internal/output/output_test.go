@@ -158,6 +158,41 @@ func TestFormatBrand_Text(t *testing.T) {
 	}
 }
 
+func TestFormatBrand_Text_Company(t *testing.T) {
+	brand := &BrandResult{
+		Name:   "Stripe",
+		Domain: "stripe.com",
+		Company: &CompanyInfo{
+			Kind:        "PRIVATELY_HELD",
+			Employees:   5001,
+			FoundedYear: 2010,
+			Location:    "South San Francisco, CA, United States",
+			Industries: []IndustryInfo{
+				{Name: "Financial Services", Slug: "financial-services", Parent: "Finance", Score: 0.91},
+			},
+		},
+		Logos: []LogoInfo{
+			{Type: "logo", Theme: "light", URL: "https://example.com/logo.svg", Format: "svg", Tags: []string{"wordmark"}},
+		},
+	}
+
+	result := FormatBrand(brand, FormatText, false)
+
+	for _, want := range []string{
+		"Company:",
+		"Kind: Privately held",
+		"Employees: 5001",
+		"Founded: 2010",
+		"Location: South San Francisco, CA, United States",
+		"Finance > Financial Services (0.91)",
+		"logo.svg [wordmark]",
+	} {
+		if !strings.Contains(result, want) {
+			t.Errorf("FormatBrand() text missing %q:\n%s", want, result)
+		}
+	}
+}
+
 func TestFormatBrand_Text_Empty(t *testing.T) {
 	brand := &BrandResult{
 		Name:   "MinimalBrand",
@@ -1103,45 +1138,30 @@ func TestFormatQuickTailwindBatch_Empty(t *testing.T) {
 	}
 }
 
-func TestSanitizeCSSName(t *testing.T) {
-	tests := []struct {
-		domain string
-		want   string
-	}{
-		{"stripe.com", "stripe"},
-		{"github.com", "github"},
-		{"example.io", "example"},
-		{"api.stripe.com", "api-stripe"},
-		{"my-app.org", "my-app"},
+func TestFormatQuickTokensBatch(t *testing.T) {
+	results := []*QuickResult{
+		{
+			Name:   "Stripe",
+			Domain: "stripe.com",
+			Colors: []ColorInfo{{Hex: "#635BFF", Type: "accent"}, {Hex: "#0A2540", Type: "dark"}, {Hex: "#FFFFFF", Type: "dark"}},
+			Fonts:  []FontInfo{{Name: "Sohne", Type: "title"}},
+		},
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.domain, func(t *testing.T) {
-			got := sanitizeCSSName(tt.domain)
-			if got != tt.want {
-				t.Errorf("sanitizeCSSName(%q) = %q, want %q", tt.domain, got, tt.want)
-			}
-		})
-	}
-}
+	out := FormatQuickTokensBatch(results)
 
-func TestSanitizeTailwindKey(t *testing.T) {
-	tests := []struct {
-		domain string
-		want   string
-	}{
-		{"stripe.com", "stripe"},
-		{"github.com", "github"},
-		{"my-app.io", "my_app"},
-		{"api.stripe.com", "api_stripe"},
+	var tokens map[string]map[string]map[string]map[string]string
+	if err := json.Unmarshal([]byte(out), &tokens); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
 	}
-
-	for _, tt := range tests {
-		t.Run(tt.domain, func(t *testing.T) {
-			got := sanitizeTailwindKey(tt.domain)
-			if got != tt.want {
-				t.Errorf("sanitizeTailwindKey(%q) = %q, want %q", tt.domain, got, tt.want)
-			}
-		})
+	stripe := tokens["stripe"]
+	if stripe["color"]["accent"]["$value"] != "#635BFF" {
+		t.Errorf("accent token = %v, want #635BFF", stripe["color"]["accent"])
+	}
+	if stripe["color"]["dark-2"]["$value"] != "#FFFFFF" {
+		t.Errorf("dark-2 token = %v, want #FFFFFF", stripe["color"]["dark-2"])
+	}
+	if stripe["font"]["title"]["$type"] != "fontFamily" {
+		t.Errorf("title font token = %v, want fontFamily type", stripe["font"]["title"])
 	}
 }
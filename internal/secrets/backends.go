@@ -0,0 +1,148 @@
+package secrets
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/99designs/keyring"
+	"golang.org/x/term"
+)
+
+// Backend names accepted by NewStoreWithOptions.
+const (
+	BackendAuto          = "auto"
+	BackendKeychain      = "keychain"
+	BackendWinCred       = "wincred"
+	BackendSecretService = "secret-service"
+	BackendKWallet       = "kwallet"
+	BackendPass          = "pass"
+	BackendFile          = "file"
+	BackendEnv           = "env"
+)
+
+// PassphraseEnv holds the passphrase for the encrypted file backend.
+const PassphraseEnv = "BRANDFETCH_KEYRING_PASSWORD"
+
+// ErrReadOnly is returned when writing to the env backend.
+var ErrReadOnly = errors.New("the env secrets backend is read-only: export BRANDFETCH_<KEY> variables instead")
+
+// Options configures how NewStoreWithOptions opens a backend.
+type Options struct {
+	// Backend is one of the Backend* names; empty means auto.
+	Backend string
+	// FileDir is where the encrypted file backend keeps its files.
+	FileDir string
+	// PassDir is the pass password-store directory (default ~/.password-store).
+	PassDir string
+	// Passphrase returns the file backend passphrase. Defaults to reading
+	// BRANDFETCH_KEYRING_PASSWORD, then prompting on the terminal.
+	Passphrase func(prompt string) (string, error)
+}
+
+// Backends lists the backend names accepted by NewStoreWithOptions.
+func Backends() []string {
+	return []string{BackendAuto, BackendKeychain, BackendWinCred, BackendSecretService, BackendKWallet, BackendPass, BackendFile, BackendEnv}
+}
+
+// NewStoreWithOptions opens the selected secrets backend.
+func NewStoreWithOptions(opts Options) (*Store, error) {
+	name := strings.ToLower(strings.TrimSpace(opts.Backend))
+	switch name {
+	case "", BackendAuto:
+		return openAutoBackend(opts)
+	case BackendEnv:
+		return &Store{ring: envKeyring{}, backend: BackendEnv}, nil
+	}
+
+	for _, b := range Backends() {
+		if b == name {
+			ring, err := keyring.Open(keyringConfig(opts, keyring.BackendType(name)))
+			if err != nil {
+				return nil, fmt.Errorf("%s backend unavailable: %w", name, err)
+			}
+			return &Store{ring: &realKeyring{ring: ring}, backend: name}, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid secrets backend %q (valid: %s)", opts.Backend, strings.Join(Backends(), ", "))
+}
+
+// openAutoBackend picks the first OS backend that opens. The file backend is
+// never picked implicitly because it needs a passphrase.
+func openAutoBackend(opts Options) (*Store, error) {
+	for _, b := range keyring.AvailableBackends() {
+		if b == keyring.FileBackend {
+			continue
+		}
+		ring, err := keyring.Open(keyringConfig(opts, b))
+		if err == nil {
+			return &Store{ring: &realKeyring{ring: ring}, backend: string(b)}, nil
+		}
+	}
+	return nil, fmt.Errorf("no OS keyring available: use --keyring-backend file (set %s) or env", PassphraseEnv)
+}
+
+func keyringConfig(opts Options, backend keyring.BackendType) keyring.Config {
+	passphrase := opts.Passphrase
+	if passphrase == nil {
+		passphrase = defaultPassphrase
+	}
+	return keyring.Config{
+		AllowedBackends:  []keyring.BackendType{backend},
+		ServiceName:      serviceName,
+		FileDir:          opts.FileDir,
+		FilePasswordFunc: keyring.PromptFunc(passphrase),
+		PassDir:          opts.PassDir,
+		PassPrefix:       serviceName,
+	}
+}
+
+func defaultPassphrase(prompt string) (string, error) {
+	if v := os.Getenv(PassphraseEnv); v != "" {
+		return v, nil
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", fmt.Errorf("no passphrase for the file secrets backend: set %s", PassphraseEnv)
+	}
+	fmt.Fprintf(os.Stderr, "%s: ", prompt)
+	b, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// envKeyring reads secrets from BRANDFETCH_<KEY> environment variables, e.g.
+// client_id from BRANDFETCH_CLIENT_ID and staging.api_key from BRANDFETCH_STAGING_API_KEY.
+type envKeyring struct{}
+
+// EnvVarName returns the environment variable the env backend reads for key.
+func EnvVarName(key string) string {
+	var sb strings.Builder
+	sb.WriteString("BRANDFETCH_")
+	for _, r := range strings.ToUpper(key) {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			sb.WriteRune(r)
+		} else {
+			sb.WriteRune('_')
+		}
+	}
+	return sb.String()
+}
+
+func (envKeyring) Get(key string) (string, error) {
+	if v := os.Getenv(EnvVarName(key)); v != "" {
+		return v, nil
+	}
+	return "", ErrNotFound
+}
+
+func (envKeyring) Set(key, value string) error {
+	return ErrReadOnly
+}
+
+func (envKeyring) Delete(key string) error {
+	return ErrReadOnly
+}
@@ -0,0 +1,91 @@
+package secrets
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEnvVarName(t *testing.T) {
+	tests := map[string]string{
+		"client_id":         "BRANDFETCH_CLIENT_ID",
+		"api_key":           "BRANDFETCH_API_KEY",
+		"staging.client_id": "BRANDFETCH_STAGING_CLIENT_ID",
+		"acme-prod.api_key": "BRANDFETCH_ACME_PROD_API_KEY",
+	}
+	for key, want := range tests {
+		if got := EnvVarName(key); got != want {
+			t.Errorf("EnvVarName(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestEnvBackend(t *testing.T) {
+	t.Setenv("BRANDFETCH_STAGING_API_KEY", "from-env")
+
+	store, err := NewStoreWithOptions(Options{Backend: "env"})
+	if err != nil {
+		t.Fatalf("NewStoreWithOptions() error = %v", err)
+	}
+	if store.Backend() != BackendEnv {
+		t.Errorf("Backend() = %q, want %q", store.Backend(), BackendEnv)
+	}
+
+	scoped := NewProfileStore(store, "staging")
+	got, err := scoped.Get("api_key")
+	if err != nil || got != "from-env" {
+		t.Errorf("Get() = %q, %v; want from-env", got, err)
+	}
+	if _, err := scoped.Get("client_id"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get(missing) error = %v, want ErrNotFound", err)
+	}
+	if err := scoped.Set("api_key", "x"); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Set() error = %v, want ErrReadOnly", err)
+	}
+	if scoped.Backend() != BackendEnv {
+		t.Errorf("ProfileStore.Backend() = %q, want %q", scoped.Backend(), BackendEnv)
+	}
+}
+
+func TestFileBackend(t *testing.T) {
+	dir := t.TempDir()
+	opts := Options{
+		Backend:    "file",
+		FileDir:    dir,
+		Passphrase: func(string) (string, error) { return "correct horse", nil },
+	}
+
+	store, err := NewStoreWithOptions(opts)
+	if err != nil {
+		t.Fatalf("NewStoreWithOptions() error = %v", err)
+	}
+	if store.Backend() != BackendFile {
+		t.Errorf("Backend() = %q, want %q", store.Backend(), BackendFile)
+	}
+	if err := store.Set("api_key", "secret"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	reopened, err := NewStoreWithOptions(opts)
+	if err != nil {
+		t.Fatalf("NewStoreWithOptions() error = %v", err)
+	}
+	got, err := reopened.Get("api_key")
+	if err != nil || got != "secret" {
+		t.Errorf("Get() = %q, %v; want secret", got, err)
+	}
+
+	opts.Passphrase = func(string) (string, error) { return "wrong", nil }
+	wrong, err := NewStoreWithOptions(opts)
+	if err != nil {
+		t.Fatalf("NewStoreWithOptions() error = %v", err)
+	}
+	if _, err := wrong.Get("api_key"); err == nil {
+		t.Error("Get() with wrong passphrase should fail")
+	}
+}
+
+func TestNewStoreWithOptionsInvalidBackend(t *testing.T) {
+	if _, err := NewStoreWithOptions(Options{Backend: "vault"}); err == nil {
+		t.Error("expected error for unknown backend")
+	}
+}
@@ -8,6 +8,9 @@ import (
 
 const serviceName = "brandfetch"
 
+// DefaultProfile is the profile whose keys are stored without a prefix.
+const DefaultProfile = "default"
+
 // ErrNotFound is returned when a key is not found in the store.
 var ErrNotFound = errors.New("key not found in secrets store")
 
@@ -47,18 +50,18 @@ func (r *realKeyring) Delete(key string) error {
 
 // Store provides secret storage operations.
 type Store struct {
-	ring KeyringBackend
+	ring    KeyringBackend
+	backend string
 }
 
-// NewStore creates a new Store with real keyring backend.
+// NewStore creates a new Store with the default OS keyring backend.
 func NewStore() (*Store, error) {
-	ring, err := keyring.Open(keyring.Config{
-		ServiceName: serviceName,
-	})
-	if err != nil {
-		return nil, err
-	}
-	return &Store{ring: &realKeyring{ring: ring}}, nil
+	return NewStoreWithOptions(Options{})
+}
+
+// Backend returns the name of the backend the store uses, e.g. "keychain" or "file".
+func (s *Store) Backend() string {
+	return s.backend
 }
 
 // Get retrieves a secret by key.
@@ -75,3 +78,56 @@ func (s *Store) Set(key, value string) error {
 func (s *Store) Delete(key string) error {
 	return s.ring.Delete(key)
 }
+
+// ProfileKey returns the keychain key for key in the named profile.
+// The default profile uses the bare key so existing credentials keep working.
+func ProfileKey(profile, key string) string {
+	if profile == "" || profile == DefaultProfile {
+		return key
+	}
+	return profile + "." + key
+}
+
+// ProfileStore scopes keys to a named credential profile.
+type ProfileStore struct {
+	ring    KeyringBackend
+	profile string
+}
+
+// NewProfileStore wraps ring so that keys are stored under profile.
+func NewProfileStore(ring KeyringBackend, profile string) *ProfileStore {
+	return &ProfileStore{ring: ring, profile: profile}
+}
+
+// Profile returns the profile name the store is scoped to.
+func (p *ProfileStore) Profile() string {
+	return p.profile
+}
+
+// Get retrieves a secret from the profile.
+func (p *ProfileStore) Get(key string) (string, error) {
+	return p.ring.Get(ProfileKey(p.profile, key))
+}
+
+// Set stores a secret in the profile.
+func (p *ProfileStore) Set(key, value string) error {
+	return p.ring.Set(ProfileKey(p.profile, key), value)
+}
+
+// Delete removes a secret from the profile.
+func (p *ProfileStore) Delete(key string) error {
+	return p.ring.Delete(ProfileKey(p.profile, key))
+}
+
+// Unscoped returns the underlying store, which takes fully qualified keys.
+func (p *ProfileStore) Unscoped() KeyringBackend {
+	return p.ring
+}
+
+// Backend returns the underlying store's backend name, or "" if unknown.
+func (p *ProfileStore) Backend() string {
+	if b, ok := p.ring.(interface{ Backend() string }); ok {
+		return b.Backend()
+	}
+	return ""
+}
@@ -335,3 +335,29 @@ func TestStore_DeleteError(t *testing.T) {
 		t.Errorf("Delete() error = %v, want %v", err, mockErr)
 	}
 }
+
+func TestProfileStore(t *testing.T) {
+	mock := NewMockKeyring()
+	store := &Store{ring: mock}
+
+	if err := NewProfileStore(store, DefaultProfile).Set("api_key", "default"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	staging := NewProfileStore(store, "staging")
+	if err := staging.Set("api_key", "staging"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	if mock.data["api_key"] != "default" || mock.data["staging.api_key"] != "staging" {
+		t.Errorf("unexpected keys: %v", mock.data)
+	}
+	if got, _ := staging.Get("api_key"); got != "staging" {
+		t.Errorf("Get() = %q, want staging", got)
+	}
+	if err := staging.Delete("api_key"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := staging.Get("api_key"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() after Delete error = %v, want ErrNotFound", err)
+	}
+}
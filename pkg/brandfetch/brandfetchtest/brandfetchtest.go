@@ -0,0 +1,48 @@
+// Package brandfetchtest starts a mock Brandfetch API server inside Go tests.
+//
+// The server answers the Brand, Search, Logo CDN and GraphQL endpoints from
+// built-in or custom fixtures, so clients can be tested without network
+// access or real keys:
+//
+//	server := brandfetchtest.NewServer(t, brandfetchtest.Options{})
+//	endpoints := brandfetchtest.EndpointsFor(server.URL)
+//	client := brandfetch.NewClient("client_id", "api_key",
+//		brandfetch.WithBaseURL(endpoints.API),
+//		brandfetch.WithLogoBaseURL(endpoints.CDN),
+//		brandfetch.WithGraphQLURL(endpoints.GraphQL),
+//	)
+//
+// It lives apart from the SDK so that the testing packages are not linked
+// into programs that only use the client.
+package brandfetchtest
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/salmonumbrella/brandfetch-cli/internal/mockserver"
+)
+
+// Options configures the mock server's fixtures, latency, error injection
+// and accepted keys.
+type Options = mockserver.Options
+
+// Endpoints are the base URLs to point a client at.
+type Endpoints = mockserver.Endpoints
+
+// NewServer starts the mock for a test and closes it when the test ends.
+func NewServer(tb testing.TB, opts Options) *httptest.Server {
+	tb.Helper()
+	handler, err := mockserver.NewHandler(opts)
+	if err != nil {
+		tb.Fatalf("brandfetchtest: %v", err)
+	}
+	server := httptest.NewServer(handler)
+	tb.Cleanup(server.Close)
+	return server
+}
+
+// EndpointsFor returns the API, CDN and GraphQL URLs of a server started at baseURL.
+func EndpointsFor(baseURL string) Endpoints {
+	return mockserver.EndpointsFor(baseURL)
+}
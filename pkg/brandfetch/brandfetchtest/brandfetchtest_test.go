@@ -0,0 +1,23 @@
+package brandfetchtest_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/salmonumbrella/brandfetch-cli/pkg/brandfetch"
+	"github.com/salmonumbrella/brandfetch-cli/pkg/brandfetch/brandfetchtest"
+)
+
+func TestNewServer(t *testing.T) {
+	server := brandfetchtest.NewServer(t, brandfetchtest.Options{APIKey: "test_api_key"})
+	endpoints := brandfetchtest.EndpointsFor(server.URL)
+	client := brandfetch.NewClient("test_client_id", "test_api_key", brandfetch.WithBaseURL(endpoints.API))
+
+	brand, err := client.GetBrand(context.Background(), "github.com")
+	if err != nil {
+		t.Fatalf("GetBrand() error = %v", err)
+	}
+	if brand.Domain != "github.com" {
+		t.Errorf("Domain = %q, want github.com", brand.Domain)
+	}
+}
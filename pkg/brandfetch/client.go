@@ -1,4 +1,4 @@
-package api
+package brandfetch
 
 import (
 	"context"
@@ -8,17 +8,15 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
-	"time"
 )
 
 const (
 	defaultBaseURL        = "https://api.brandfetch.io"
 	defaultLogoBaseURL    = "https://cdn.brandfetch.io"
 	defaultGraphQLBaseURL = "https://graphql.brandfetch.io/"
-	defaultTimeout        = 30 * time.Second
 )
 
-// Client is the Brandfetch API client.
+// Client is the Brandfetch API client. It is safe for concurrent use.
 type Client struct {
 	clientID       string // Logo API key (high quota)
 	apiKey         string // Brand API key (limited quota)
@@ -26,47 +24,80 @@ type Client struct {
 	logoBaseURL    string
 	graphQLBaseURL string
 	httpClient     *http.Client
+	retry          retryPolicy
 }
 
-// NewClient creates a new Brandfetch API client.
-func NewClient(clientID, apiKey string) *Client {
-	return &Client{
+// NewClient creates a Brandfetch API client. clientID authenticates the Logo
+// and Search APIs, apiKey the Brand, Transaction and GraphQL APIs; either may
+// be empty if the corresponding APIs are not used.
+func NewClient(clientID, apiKey string, opts ...Option) *Client {
+	cfg := clientConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	httpClient := &http.Client{Timeout: DefaultTimeout}
+	if cfg.httpClient != nil {
+		copied := *cfg.httpClient
+		httpClient = &copied
+	}
+	if cfg.timeoutSet {
+		httpClient.Timeout = cfg.timeout
+	}
+	if cfg.transport != nil {
+		httpClient.Transport = cfg.transport
+	}
+
+	c := &Client{
 		clientID:       clientID,
 		apiKey:         apiKey,
 		baseURL:        defaultBaseURL,
 		logoBaseURL:    defaultLogoBaseURL,
 		graphQLBaseURL: defaultGraphQLBaseURL,
-		httpClient: &http.Client{
-			Timeout: defaultTimeout,
-		},
+		httpClient:     httpClient,
+		retry:          cfg.retry,
 	}
+	if cfg.baseURL != "" {
+		c.baseURL = strings.TrimRight(cfg.baseURL, "/")
+	}
+	if cfg.logoBaseURL != "" {
+		c.logoBaseURL = strings.TrimRight(cfg.logoBaseURL, "/")
+	}
+	if cfg.graphQLURL != "" {
+		c.graphQLBaseURL = cfg.graphQLURL
+	}
+	return c
 }
 
 // Brand represents a brand from the API.
 type Brand struct {
-	ID              string                 `json:"id"`
-	Name            string                 `json:"name"`
-	Domain          string                 `json:"domain"`
-	Description     string                 `json:"description"`
-	LongDescription string                 `json:"longDescription"`
-	Claimed         bool                   `json:"claimed"`
-	Logos           []Logo                 `json:"logos"`
-	Colors          []Color                `json:"colors"`
-	Fonts           []Font                 `json:"fonts"`
-	Links           []Link                 `json:"links"`
-	Images          []Image                `json:"images"`
-	Company         map[string]interface{} `json:"company"`
-	QualityScore    float64                `json:"qualityScore"`
-	IsNSFW          bool                   `json:"isNsfw"`
-	URN             string                 `json:"urn"`
+	ID              string   `json:"id"`
+	Name            string   `json:"name"`
+	Domain          string   `json:"domain"`
+	Description     string   `json:"description"`
+	LongDescription string   `json:"longDescription"`
+	Claimed         bool     `json:"claimed"`
+	Logos           []Logo   `json:"logos"`
+	Colors          []Color  `json:"colors"`
+	Fonts           []Font   `json:"fonts"`
+	Links           []Link   `json:"links"`
+	Images          []Image  `json:"images"`
+	Company         *Company `json:"company"`
+	QualityScore    float64  `json:"qualityScore"`
+	IsNSFW          bool     `json:"isNsfw"`
+	URN             string   `json:"urn"`
+
+	// Raw is the response body exactly as the API sent it, including any
+	// fields not modelled above.
+	Raw json.RawMessage `json:"-"`
 }
 
 // Logo represents a logo entry.
 type Logo struct {
-	Type    string                   `json:"type"`
-	Theme   string                   `json:"theme"`
-	Formats []LogoFormat             `json:"formats"`
-	Tags    []map[string]interface{} `json:"tags,omitempty"`
+	Type    string       `json:"type"`
+	Theme   string       `json:"theme"`
+	Formats []LogoFormat `json:"formats"`
+	Tags    []Tag        `json:"tags,omitempty"`
 }
 
 // LogoFormat represents a specific logo format.
@@ -103,9 +134,9 @@ type Link struct {
 
 // Image represents a brand image entry.
 type Image struct {
-	Type    string                   `json:"type"`
-	Formats []LogoFormat             `json:"formats"`
-	Tags    []map[string]interface{} `json:"tags,omitempty"`
+	Type    string       `json:"type"`
+	Formats []LogoFormat `json:"formats"`
+	Tags    []Tag        `json:"tags,omitempty"`
 }
 
 // LogoResult represents a logo API response.
@@ -151,7 +182,7 @@ func (c *Client) GetBrand(ctx context.Context, domain string) (*Brand, error) {
 	}
 	req.Header.Set("Authorization", "Bearer "+c.apiKey)
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("connection failed: %w", err)
 	}
@@ -170,13 +201,17 @@ func (c *Client) GetBrand(ctx context.Context, domain string) (*Brand, error) {
 	if err := json.Unmarshal(body, &brand); err != nil {
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
+	brand.Raw = body
 
 	return &brand, nil
 }
 
-// GetLogo returns a Logo API CDN URL based on the provided options.
+// GetLogo returns a Logo API CDN URL based on the provided options. It makes
+// no request; use ResolveLogo to check what the CDN serves.
 func (c *Client) GetLogo(ctx context.Context, opts LogoOptions) (*LogoResult, error) {
-	_ = ctx
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	if strings.TrimSpace(opts.Identifier) == "" {
 		return nil, fmt.Errorf("identifier is required")
 	}
@@ -214,7 +249,7 @@ func (c *Client) Search(ctx context.Context, query string, limit int) ([]SearchR
 		return nil, err
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("connection failed: %w", err)
 	}
@@ -349,7 +384,7 @@ func (c *Client) CreateTransaction(ctx context.Context, label, countryCode strin
 	req.Header.Set("Authorization", "Bearer "+c.apiKey)
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("connection failed: %w", err)
 	}
@@ -368,6 +403,7 @@ func (c *Client) CreateTransaction(ctx context.Context, label, countryCode strin
 	if err := json.Unmarshal(body, &brand); err != nil {
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
+	brand.Raw = body
 
 	return &brand, nil
 }
@@ -396,7 +432,7 @@ func (c *Client) GraphQL(ctx context.Context, query string, variables map[string
 	req.Header.Set("Authorization", "Bearer "+c.apiKey)
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("connection failed: %w", err)
 	}
@@ -435,7 +471,7 @@ func (c *Client) GraphQLRaw(ctx context.Context, body io.Reader) (json.RawMessag
 	req.Header.Set("Authorization", "Bearer "+c.apiKey)
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("connection failed: %w", err)
 	}
@@ -1,10 +1,11 @@
-package api
+package brandfetch
 
 import (
 	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -194,4 +195,29 @@ func TestClient_CreateTransaction(t *testing.T) {
 	if brand.Name != "Spotify" {
 		t.Errorf("brand.Name = %v, want Spotify", brand.Name)
 	}
+	if len(brand.Raw) == 0 {
+		t.Error("brand.Raw is empty")
+	}
+}
+
+func TestClient_GetBrand_Raw(t *testing.T) {
+	body := `{"name":"GitHub","domain":"github.com","newField":{"shipped":true}}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client := NewClient("test_client_id", "test_api_key", WithBaseURL(server.URL))
+	brand, err := client.GetBrand(context.Background(), "github.com")
+	if err != nil {
+		t.Fatalf("GetBrand() error = %v", err)
+	}
+	if string(brand.Raw) != body {
+		t.Errorf("brand.Raw = %s, want %s", brand.Raw, body)
+	}
+
+	data, _ := json.Marshal(brand)
+	if strings.Contains(string(data), "newField") {
+		t.Errorf("Marshal(brand) includes Raw: %s", data)
+	}
 }
@@ -0,0 +1,177 @@
+package brandfetch
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// Company holds the firmographic data of a brand. Fields the API adds that
+// are not modelled here are kept in Extra and written back by MarshalJSON.
+type Company struct {
+	Employees   int        `json:"employees,omitempty"`
+	FoundedYear int        `json:"foundedYear,omitempty"`
+	Kind        string     `json:"kind,omitempty"`
+	Location    *Location  `json:"location,omitempty"`
+	Industries  []Industry `json:"industries,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// Location is where a company is headquartered.
+type Location struct {
+	City        string `json:"city,omitempty"`
+	State       string `json:"state,omitempty"`
+	Region      string `json:"region,omitempty"`
+	Country     string `json:"country,omitempty"`
+	CountryCode string `json:"countryCode,omitempty"`
+	Subregion   string `json:"subregion,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// Industry is an industry a company operates in. Score is the confidence of
+// the classification, from 0 to 1; Parent is the broader industry, if any.
+type Industry struct {
+	ID     string    `json:"id,omitempty"`
+	Name   string    `json:"name"`
+	Slug   string    `json:"slug"`
+	Emoji  string    `json:"emoji,omitempty"`
+	Score  float64   `json:"score,omitempty"`
+	Parent *Industry `json:"parent,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// Tag labels a logo or image. The API sends tags as objects; plain strings
+// are accepted as tag names.
+type Tag struct {
+	Name string `json:"name,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// InIndustry reports whether the company is classified under industry, matched
+// case-insensitively against the name or slug of each industry and its parent.
+func (c *Company) InIndustry(industry string) bool {
+	industry = strings.TrimSpace(industry)
+	if c == nil || industry == "" {
+		return false
+	}
+	for i := range c.Industries {
+		for ind := &c.Industries[i]; ind != nil; ind = ind.Parent {
+			if strings.EqualFold(ind.Slug, industry) || strings.EqualFold(ind.Name, industry) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *Company) UnmarshalJSON(data []byte) error {
+	type plain Company
+	return unmarshalWithExtra(data, (*plain)(c), &c.Extra)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (c Company) MarshalJSON() ([]byte, error) {
+	type plain Company
+	return marshalWithExtra(plain(c), c.Extra)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (l *Location) UnmarshalJSON(data []byte) error {
+	type plain Location
+	return unmarshalWithExtra(data, (*plain)(l), &l.Extra)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (l Location) MarshalJSON() ([]byte, error) {
+	type plain Location
+	return marshalWithExtra(plain(l), l.Extra)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (i *Industry) UnmarshalJSON(data []byte) error {
+	type plain Industry
+	return unmarshalWithExtra(data, (*plain)(i), &i.Extra)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (i Industry) MarshalJSON() ([]byte, error) {
+	type plain Industry
+	return marshalWithExtra(plain(i), i.Extra)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *Tag) UnmarshalJSON(data []byte) error {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '"' {
+		*t = Tag{}
+		return json.Unmarshal(trimmed, &t.Name)
+	}
+	type plain Tag
+	return unmarshalWithExtra(data, (*plain)(t), &t.Extra)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t Tag) MarshalJSON() ([]byte, error) {
+	type plain Tag
+	return marshalWithExtra(plain(t), t.Extra)
+}
+
+// unmarshalWithExtra decodes data into v, a pointer to a struct, and collects
+// the object keys v has no field for into extra.
+func unmarshalWithExtra(data []byte, v interface{}, extra *map[string]json.RawMessage) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	for name := range jsonFieldNames(reflect.TypeOf(v).Elem()) {
+		delete(fields, name)
+	}
+	*extra = nil
+	if len(fields) > 0 {
+		*extra = fields
+	}
+	return nil
+}
+
+// marshalWithExtra encodes v and adds the fields in extra that v does not set.
+func marshalWithExtra(v interface{}, extra map[string]json.RawMessage) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for name, value := range extra {
+		if _, ok := fields[name]; !ok {
+			fields[name] = value
+		}
+	}
+	return json.Marshal(fields)
+}
+
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := make(map[string]bool, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" || !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = field.Name
+		}
+		names[name] = true
+	}
+	return names
+}
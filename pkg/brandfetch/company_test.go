@@ -0,0 +1,124 @@
+package brandfetch
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const companyJSON = `{
+	"employees": 5001,
+	"foundedYear": 2010,
+	"kind": "PRIVATELY_HELD",
+	"revenue": "1B+",
+	"location": {"city": "South San Francisco", "country": "United States", "countryCode": "US", "timezone": "PST"},
+	"industries": [
+		{"id": "a1", "name": "Financial Services", "slug": "financial-services", "score": 0.91, "emoji": "💳",
+		 "parent": {"id": "a0", "name": "Finance", "slug": "finance"}}
+	]
+}`
+
+func TestCompany_Unmarshal(t *testing.T) {
+	var c Company
+	if err := json.Unmarshal([]byte(companyJSON), &c); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if c.Employees != 5001 || c.FoundedYear != 2010 || c.Kind != "PRIVATELY_HELD" {
+		t.Errorf("Company = %+v", c)
+	}
+	if c.Location == nil || c.Location.City != "South San Francisco" || c.Location.CountryCode != "US" {
+		t.Errorf("Location = %+v", c.Location)
+	}
+	if len(c.Industries) != 1 {
+		t.Fatalf("Industries = %+v", c.Industries)
+	}
+	ind := c.Industries[0]
+	if ind.Slug != "financial-services" || ind.Score != 0.91 || ind.Parent == nil || ind.Parent.Slug != "finance" {
+		t.Errorf("Industry = %+v", ind)
+	}
+	if string(c.Extra["revenue"]) != `"1B+"` {
+		t.Errorf("Extra = %v, want revenue kept", c.Extra)
+	}
+	if _, ok := c.Extra["employees"]; ok {
+		t.Error("known field employees also kept in Extra")
+	}
+	if string(c.Location.Extra["timezone"]) != `"PST"` {
+		t.Errorf("Location.Extra = %v, want timezone kept", c.Location.Extra)
+	}
+}
+
+func TestCompany_MarshalPreservesUnknownFields(t *testing.T) {
+	var c Company
+	if err := json.Unmarshal([]byte(companyJSON), &c); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var got, want interface{}
+	_ = json.Unmarshal(data, &got)
+	_ = json.Unmarshal([]byte(companyJSON), &want)
+	gotJSON, _ := json.Marshal(got)
+	wantJSON, _ := json.Marshal(want)
+	if string(gotJSON) != string(wantJSON) {
+		t.Errorf("round trip =\n%s\nwant\n%s", gotJSON, wantJSON)
+	}
+}
+
+func TestBrand_CompanyNull(t *testing.T) {
+	var b Brand
+	if err := json.Unmarshal([]byte(`{"name":"X","company":null}`), &b); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if b.Company != nil {
+		t.Errorf("Company = %+v, want nil", b.Company)
+	}
+	if b.Company.InIndustry("finance") {
+		t.Error("nil company matched an industry")
+	}
+}
+
+func TestTag_Unmarshal(t *testing.T) {
+	var tags []Tag
+	if err := json.Unmarshal([]byte(`["photographic", {"name": "wordmark", "source": "ai"}]`), &tags); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if len(tags) != 2 || tags[0].Name != "photographic" || tags[1].Name != "wordmark" {
+		t.Fatalf("tags = %+v", tags)
+	}
+	if string(tags[1].Extra["source"]) != `"ai"` {
+		t.Errorf("Extra = %v, want source kept", tags[1].Extra)
+	}
+
+	data, err := json.Marshal(tags[1])
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if string(data) != `{"name":"wordmark","source":"ai"}` {
+		t.Errorf("Marshal() = %s", data)
+	}
+}
+
+func TestCompany_InIndustry(t *testing.T) {
+	var c Company
+	if err := json.Unmarshal([]byte(companyJSON), &c); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	tests := []struct {
+		industry string
+		want     bool
+	}{
+		{"financial-services", true},
+		{"Financial Services", true},
+		{"FINANCE", true},
+		{" finance ", true},
+		{"software", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := c.InIndustry(tt.industry); got != tt.want {
+			t.Errorf("InIndustry(%q) = %v, want %v", tt.industry, got, tt.want)
+		}
+	}
+}
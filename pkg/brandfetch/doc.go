@@ -0,0 +1,21 @@
+// Package brandfetch is a Go client for the Brandfetch Brand, Logo, Search,
+// Transaction and GraphQL APIs, plus exporters that turn brand colours and
+// fonts into CSS, Tailwind and design token files.
+//
+// Create a client with the Logo API client ID and the Brand API key, and tune
+// it with options:
+//
+//	client := brandfetch.NewClient(clientID, apiKey,
+//		brandfetch.WithTimeout(10*time.Second),
+//		brandfetch.WithRetry(3, time.Second),
+//	)
+//	brand, err := client.GetBrand(ctx, "stripe.com")
+//	if errors.Is(err, brandfetch.ErrNotFound) {
+//		// ...
+//	}
+//	fmt.Println(brandfetch.ExportCSS(brandfetch.ThemeOf(brand)))
+//
+// API failures are returned as *APIError, which matches ErrUnauthorized,
+// ErrNotFound and ErrRateLimited with errors.Is; GraphQL failures are
+// returned as *GraphQLError.
+package brandfetch
@@ -1,4 +1,4 @@
-package api
+package brandfetch
 
 import (
 	"errors"
@@ -41,7 +41,7 @@ func WrapAPIError(statusCode int, body string) error {
 	case 401:
 		return &APIError{
 			StatusCode: 401,
-			Message:    "Invalid API key. Run `brandfetch auth set` to configure.",
+			Message:    "Invalid API key.",
 		}
 	case 404:
 		return &APIError{
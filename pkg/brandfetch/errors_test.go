@@ -1,4 +1,4 @@
-package api
+package brandfetch
 
 import (
 	"errors"
@@ -41,7 +41,7 @@ func TestWrapAPIError(t *testing.T) {
 		body    string
 		wantMsg string
 	}{
-		{401, "bad key", "Invalid API key. Run `brandfetch auth set` to configure."},
+		{401, "bad key", "Invalid API key."},
 		{404, "not found", "Brand not found"},
 		{429, "rate limit", "Rate limit exceeded. Try again later."},
 		{500, "server error", "API error (500): server error"},
@@ -0,0 +1,28 @@
+package brandfetch_test
+
+import (
+	"fmt"
+
+	"github.com/salmonumbrella/brandfetch-cli/pkg/brandfetch"
+)
+
+func ExampleClient_BuildLogoURL() {
+	client := brandfetch.NewClient("your-client-id", "")
+	u, _ := client.BuildLogoURL(brandfetch.LogoOptions{Identifier: "https://www.github.com", Theme: "dark", Type: "icon", Format: "png"})
+	fmt.Println(u)
+	// Output: https://cdn.brandfetch.io/github.com/theme/dark/type/icon.png?c=your-client-id
+}
+
+func ExampleExportCSS() {
+	theme := brandfetch.Theme{
+		Name:   "Stripe",
+		Domain: "stripe.com",
+		Colors: []brandfetch.Color{{Hex: "#635bff", Type: "accent"}},
+	}
+	fmt.Println(brandfetch.ExportCSS(theme))
+	// Output:
+	// :root {
+	//   /* Colors */
+	//   --color-accent: #635bff;
+	// }
+}
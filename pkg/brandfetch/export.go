@@ -0,0 +1,524 @@
+package brandfetch
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Theme is the part of a brand the exporters turn into code: its colours and fonts.
+type Theme struct {
+	Name   string
+	Domain string
+	Colors []Color
+	Fonts  []Font
+}
+
+// ThemeOf returns the exportable theme of a brand.
+func ThemeOf(b *Brand) Theme {
+	return Theme{Name: b.Name, Domain: b.Domain, Colors: b.Colors, Fonts: b.Fonts}
+}
+
+// ExportCSS renders themes as CSS custom properties in a :root block. A single
+// theme uses --color-<type> and --font-<type>; several themes are prefixed
+// with their domain, e.g. --stripe-color-accent.
+func ExportCSS(themes ...Theme) string {
+	if len(themes) == 0 {
+		return ":root {\n}"
+	}
+	if len(themes) == 1 {
+		return exportCSSSingle(themes[0])
+	}
+
+	var sb strings.Builder
+	sb.WriteString(":root {\n")
+
+	for i, theme := range themes {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		brandPrefix := sanitizeCSSName(theme.Domain)
+		sb.WriteString(fmt.Sprintf("  /* %s */\n", theme.Name))
+
+		// Colors
+		if len(theme.Colors) > 0 {
+			colorVars := buildColorVariablesWithPrefix(theme.Colors, brandPrefix)
+			for _, v := range colorVars {
+				sb.WriteString(fmt.Sprintf("  %s: %s;\n", v.name, v.value))
+			}
+		}
+
+		// Fonts
+		if len(theme.Fonts) > 0 {
+			fontVars := buildFontVariablesWithPrefix(theme.Fonts, brandPrefix)
+			for _, v := range fontVars {
+				sb.WriteString(fmt.Sprintf("  %s: %s;\n", v.name, v.value))
+			}
+		}
+	}
+
+	sb.WriteString("}")
+	return sb.String()
+}
+
+func exportCSSSingle(theme Theme) string {
+	var sb strings.Builder
+	sb.WriteString(":root {\n")
+
+	// Colors
+	if len(theme.Colors) > 0 {
+		sb.WriteString("  /* Colors */\n")
+		colorVars := buildColorVariables(theme.Colors)
+		for _, v := range colorVars {
+			sb.WriteString(fmt.Sprintf("  %s: %s;\n", v.name, v.value))
+		}
+	}
+
+	// Fonts
+	if len(theme.Fonts) > 0 {
+		if len(theme.Colors) > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString("  /* Fonts */\n")
+		fontVars := buildFontVariables(theme.Fonts)
+		for _, v := range fontVars {
+			sb.WriteString(fmt.Sprintf("  %s: %s;\n", v.name, v.value))
+		}
+	}
+
+	sb.WriteString("}")
+	return sb.String()
+}
+
+// ExportTailwind renders themes as a Tailwind CSS theme.extend config. Several
+// themes are nested under a key derived from their domain.
+func ExportTailwind(themes ...Theme) string {
+	if len(themes) == 0 {
+		return "module.exports = {\n}"
+	}
+	if len(themes) == 1 {
+		return exportTailwindSingle(themes[0])
+	}
+
+	var sb strings.Builder
+	sb.WriteString("// Tailwind CSS config for multiple brands\n")
+	sb.WriteString("// Add to your tailwind.config.js theme.extend\n")
+	sb.WriteString("module.exports = {\n")
+
+	// Colors section
+	hasColors := false
+	for _, theme := range themes {
+		if len(theme.Colors) > 0 {
+			hasColors = true
+			break
+		}
+	}
+
+	if hasColors {
+		sb.WriteString("  colors: {\n")
+		for _, theme := range themes {
+			if len(theme.Colors) == 0 {
+				continue
+			}
+			brandKey := sanitizeTailwindKey(theme.Domain)
+			sb.WriteString(fmt.Sprintf("    %s: {\n", brandKey))
+			colorEntries := buildTailwindColorsNested(theme.Colors)
+			for _, entry := range colorEntries {
+				sb.WriteString(entry)
+			}
+			sb.WriteString("    },\n")
+		}
+		sb.WriteString("  },\n")
+	}
+
+	// Fonts section
+	hasFonts := false
+	for _, theme := range themes {
+		if len(theme.Fonts) > 0 {
+			hasFonts = true
+			break
+		}
+	}
+
+	if hasFonts {
+		sb.WriteString("  fontFamily: {\n")
+		for _, theme := range themes {
+			if len(theme.Fonts) == 0 {
+				continue
+			}
+			brandKey := sanitizeTailwindKey(theme.Domain)
+			sb.WriteString(fmt.Sprintf("    %s: {\n", brandKey))
+			fontEntries := buildTailwindFontsNested(theme.Fonts)
+			for _, entry := range fontEntries {
+				sb.WriteString(entry)
+			}
+			sb.WriteString("    },\n")
+		}
+		sb.WriteString("  },\n")
+	}
+
+	sb.WriteString("}")
+	return sb.String()
+}
+
+func exportTailwindSingle(theme Theme) string {
+	var sb strings.Builder
+
+	// Header comment
+	sb.WriteString(fmt.Sprintf("// Tailwind CSS config for %s\n", theme.Name))
+	sb.WriteString("// Add to your tailwind.config.js theme.extend\n")
+	sb.WriteString("module.exports = {\n")
+
+	// Colors
+	if len(theme.Colors) > 0 {
+		sb.WriteString("  colors: {\n")
+		colorEntries := buildTailwindColors(theme.Colors)
+		for _, entry := range colorEntries {
+			sb.WriteString(entry)
+		}
+		sb.WriteString("  },\n")
+	}
+
+	// Fonts
+	if len(theme.Fonts) > 0 {
+		sb.WriteString("  fontFamily: {\n")
+		fontEntries := buildTailwindFonts(theme.Fonts)
+		for _, entry := range fontEntries {
+			sb.WriteString(entry)
+		}
+		sb.WriteString("  },\n")
+	}
+
+	sb.WriteString("}")
+	return sb.String()
+}
+
+// designToken is a single entry in the design tokens export.
+type designToken struct {
+	Type  string `json:"$type"`
+	Value string `json:"$value"`
+}
+
+// ExportDesignTokens renders themes as design tokens JSON ($type/$value), keyed by brand.
+func ExportDesignTokens(themes ...Theme) string {
+	tokens := make(map[string]map[string]map[string]designToken, len(themes))
+	for _, theme := range themes {
+		group := map[string]map[string]designToken{}
+		if len(theme.Colors) > 0 {
+			colors := make(map[string]designToken)
+			for _, v := range buildColorVariables(theme.Colors) {
+				colors[strings.TrimPrefix(v.name, "--color-")] = designToken{Type: "color", Value: v.value}
+			}
+			group["color"] = colors
+		}
+		if len(theme.Fonts) > 0 {
+			fonts := make(map[string]designToken)
+			seen := make(map[string]bool)
+			typeCounts := make(map[string]int)
+			for _, f := range theme.Fonts {
+				typeCounts[f.Type]++
+			}
+			typeIndex := make(map[string]int)
+			for _, f := range theme.Fonts {
+				key := f.Name + "|" + f.Type
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				name := f.Type
+				if typeCounts[f.Type] > 1 {
+					typeIndex[f.Type]++
+					name = fmt.Sprintf("%s-%d", f.Type, typeIndex[f.Type])
+				}
+				fonts[name] = designToken{Type: "fontFamily", Value: f.Name}
+			}
+			group["font"] = fonts
+		}
+		tokens[sanitizeCSSName(theme.Domain)] = group
+	}
+
+	// encoding/json sorts map keys, which keeps the export deterministic.
+	data, _ := json.MarshalIndent(tokens, "", "  ")
+	return string(data)
+}
+
+type cssVar struct {
+	name  string
+	value string
+}
+
+// buildColorVariables generates CSS variable names for colors, handling duplicates.
+func buildColorVariables(colors []Color) []cssVar {
+	// Count occurrences of each type
+	typeCounts := make(map[string]int)
+	for _, c := range colors {
+		typeCounts[c.Type]++
+	}
+
+	// Track which types we've seen (for numbering duplicates)
+	typeIndex := make(map[string]int)
+
+	var vars []cssVar
+	for _, c := range colors {
+		varName := fmt.Sprintf("--color-%s", c.Type)
+
+		// If there are duplicates of this type, append a number
+		if typeCounts[c.Type] > 1 {
+			typeIndex[c.Type]++
+			varName = fmt.Sprintf("--color-%s-%d", c.Type, typeIndex[c.Type])
+		}
+
+		vars = append(vars, cssVar{name: varName, value: c.Hex})
+	}
+
+	return vars
+}
+
+// buildTailwindColors generates Tailwind color entries, handling duplicates with object nesting.
+func buildTailwindColors(colors []Color) []string {
+	// Group colors by type to handle duplicates properly
+	typeColors := make(map[string][]string)
+	typeOrder := []string{} // Preserve order of first occurrence
+
+	for _, c := range colors {
+		if _, exists := typeColors[c.Type]; !exists {
+			typeOrder = append(typeOrder, c.Type)
+		}
+		typeColors[c.Type] = append(typeColors[c.Type], c.Hex)
+	}
+
+	var entries []string
+	for _, colorType := range typeOrder {
+		hexValues := typeColors[colorType]
+		if len(hexValues) == 1 {
+			// Single color: simple key-value
+			entries = append(entries, fmt.Sprintf("    %s: '%s',\n", colorType, hexValues[0]))
+		} else {
+			// Multiple colors: nested object
+			var nested strings.Builder
+			nested.WriteString(fmt.Sprintf("    %s: {\n", colorType))
+			for i, hex := range hexValues {
+				nested.WriteString(fmt.Sprintf("      %d: '%s',\n", i+1, hex))
+			}
+			nested.WriteString("    },\n")
+			entries = append(entries, nested.String())
+		}
+	}
+
+	return entries
+}
+
+// buildTailwindFonts generates Tailwind fontFamily entries, handling duplicates.
+func buildTailwindFonts(fonts []Font) []string {
+	// Count occurrences of each type
+	typeCounts := make(map[string]int)
+	for _, f := range fonts {
+		typeCounts[f.Type]++
+	}
+
+	// Track which types we've seen (for numbering duplicates)
+	typeIndex := make(map[string]int)
+
+	// Track unique fonts for deduplication (same name + type = skip)
+	seen := make(map[string]bool)
+
+	var entries []string
+	for _, f := range fonts {
+		key := f.Name + "|" + f.Type
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		if typeCounts[f.Type] > 1 {
+			// Duplicate types - but for fonts we typically just list them
+			// The spec says to use object nesting for colors, but for fonts
+			// we'll follow the same pattern as CSS and number them
+			typeIndex[f.Type]++
+			entries = append(entries, fmt.Sprintf("    %s%d: ['\"%s\"', 'sans-serif'],\n", f.Type, typeIndex[f.Type], f.Name))
+		} else {
+			entries = append(entries, fmt.Sprintf("    %s: ['\"%s\"', 'sans-serif'],\n", f.Type, f.Name))
+		}
+	}
+
+	return entries
+}
+
+// buildFontVariables generates CSS variable names for fonts, handling duplicates.
+func buildFontVariables(fonts []Font) []cssVar {
+	// Count occurrences of each type
+	typeCounts := make(map[string]int)
+	for _, f := range fonts {
+		typeCounts[f.Type]++
+	}
+
+	// Track which types we've seen (for numbering duplicates)
+	typeIndex := make(map[string]int)
+
+	// Track unique fonts for deduplication (same name + type = skip)
+	seen := make(map[string]bool)
+
+	var vars []cssVar
+	for _, f := range fonts {
+		key := f.Name + "|" + f.Type
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		varName := fmt.Sprintf("--font-%s", f.Type)
+
+		// If there are duplicates of this type (after dedup), append a number
+		if typeCounts[f.Type] > 1 {
+			typeIndex[f.Type]++
+			varName = fmt.Sprintf("--font-%s-%d", f.Type, typeIndex[f.Type])
+		}
+
+		// Quote font name and add sans-serif fallback
+		value := fmt.Sprintf("'%s', sans-serif", f.Name)
+		vars = append(vars, cssVar{name: varName, value: value})
+	}
+
+	return vars
+}
+
+// sanitizeCSSName converts a domain to a valid CSS variable name prefix.
+func sanitizeCSSName(domain string) string {
+	// Remove common TLDs
+	name := strings.TrimSuffix(domain, ".com")
+	name = strings.TrimSuffix(name, ".io")
+	name = strings.TrimSuffix(name, ".org")
+	name = strings.TrimSuffix(name, ".net")
+	name = strings.TrimSuffix(name, ".co")
+	// Replace dots with hyphens
+	name = strings.ReplaceAll(name, ".", "-")
+	return name
+}
+
+// buildColorVariablesWithPrefix generates CSS variable names with brand prefix.
+func buildColorVariablesWithPrefix(colors []Color, prefix string) []cssVar {
+	typeCounts := make(map[string]int)
+	for _, c := range colors {
+		typeCounts[c.Type]++
+	}
+
+	typeIndex := make(map[string]int)
+
+	var vars []cssVar
+	for _, c := range colors {
+		varName := fmt.Sprintf("--%s-color-%s", prefix, c.Type)
+
+		if typeCounts[c.Type] > 1 {
+			typeIndex[c.Type]++
+			varName = fmt.Sprintf("--%s-color-%s-%d", prefix, c.Type, typeIndex[c.Type])
+		}
+
+		vars = append(vars, cssVar{name: varName, value: c.Hex})
+	}
+
+	return vars
+}
+
+// buildFontVariablesWithPrefix generates CSS font variable names with brand prefix.
+func buildFontVariablesWithPrefix(fonts []Font, prefix string) []cssVar {
+	typeCounts := make(map[string]int)
+	for _, f := range fonts {
+		typeCounts[f.Type]++
+	}
+
+	typeIndex := make(map[string]int)
+	seen := make(map[string]bool)
+
+	var vars []cssVar
+	for _, f := range fonts {
+		key := f.Name + "|" + f.Type
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		varName := fmt.Sprintf("--%s-font-%s", prefix, f.Type)
+
+		if typeCounts[f.Type] > 1 {
+			typeIndex[f.Type]++
+			varName = fmt.Sprintf("--%s-font-%s-%d", prefix, f.Type, typeIndex[f.Type])
+		}
+
+		value := fmt.Sprintf("'%s', sans-serif", f.Name)
+		vars = append(vars, cssVar{name: varName, value: value})
+	}
+
+	return vars
+}
+
+// sanitizeTailwindKey converts a domain to a valid Tailwind config key.
+func sanitizeTailwindKey(domain string) string {
+	name := strings.TrimSuffix(domain, ".com")
+	name = strings.TrimSuffix(name, ".io")
+	name = strings.TrimSuffix(name, ".org")
+	name = strings.TrimSuffix(name, ".net")
+	name = strings.TrimSuffix(name, ".co")
+	name = strings.ReplaceAll(name, ".", "_")
+	name = strings.ReplaceAll(name, "-", "_")
+	return name
+}
+
+// buildTailwindColorsNested generates Tailwind color entries for nesting inside a brand object.
+func buildTailwindColorsNested(colors []Color) []string {
+	typeColors := make(map[string][]string)
+	typeOrder := []string{}
+
+	for _, c := range colors {
+		if _, exists := typeColors[c.Type]; !exists {
+			typeOrder = append(typeOrder, c.Type)
+		}
+		typeColors[c.Type] = append(typeColors[c.Type], c.Hex)
+	}
+
+	var entries []string
+	for _, colorType := range typeOrder {
+		hexValues := typeColors[colorType]
+		if len(hexValues) == 1 {
+			entries = append(entries, fmt.Sprintf("      %s: '%s',\n", colorType, hexValues[0]))
+		} else {
+			var nested strings.Builder
+			nested.WriteString(fmt.Sprintf("      %s: {\n", colorType))
+			for i, hex := range hexValues {
+				nested.WriteString(fmt.Sprintf("        %d: '%s',\n", i+1, hex))
+			}
+			nested.WriteString("      },\n")
+			entries = append(entries, nested.String())
+		}
+	}
+
+	return entries
+}
+
+// buildTailwindFontsNested generates Tailwind font entries for nesting inside a brand object.
+func buildTailwindFontsNested(fonts []Font) []string {
+	typeCounts := make(map[string]int)
+	for _, f := range fonts {
+		typeCounts[f.Type]++
+	}
+
+	typeIndex := make(map[string]int)
+	seen := make(map[string]bool)
+
+	var entries []string
+	for _, f := range fonts {
+		key := f.Name + "|" + f.Type
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		if typeCounts[f.Type] > 1 {
+			typeIndex[f.Type]++
+			entries = append(entries, fmt.Sprintf("      %s%d: ['\"%s\"', 'sans-serif'],\n", f.Type, typeIndex[f.Type], f.Name))
+		} else {
+			entries = append(entries, fmt.Sprintf("      %s: ['\"%s\"', 'sans-serif'],\n", f.Type, f.Name))
+		}
+	}
+
+	return entries
+}
@@ -0,0 +1,83 @@
+package brandfetch
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExportCSS(t *testing.T) {
+	stripe := Theme{
+		Name:   "Stripe",
+		Domain: "stripe.com",
+		Colors: []Color{{Hex: "#635bff", Type: "accent"}, {Hex: "#0a2540", Type: "dark"}},
+		Fonts:  []Font{{Name: "Sohne", Type: "title"}},
+	}
+	github := Theme{Name: "GitHub", Domain: "github.com", Colors: []Color{{Hex: "#24292f", Type: "dark"}}}
+
+	single := ExportCSS(stripe)
+	for _, want := range []string{"--color-accent: #635bff;", "--font-title: 'Sohne', sans-serif;"} {
+		if !strings.Contains(single, want) {
+			t.Errorf("ExportCSS() missing %q:\n%s", want, single)
+		}
+	}
+
+	batch := ExportCSS(stripe, github)
+	for _, want := range []string{"--stripe-color-accent: #635bff;", "--github-color-dark: #24292f;"} {
+		if !strings.Contains(batch, want) {
+			t.Errorf("ExportCSS() batch missing %q:\n%s", want, batch)
+		}
+	}
+}
+
+func TestThemeOf(t *testing.T) {
+	brand := &Brand{Name: "Stripe", Domain: "stripe.com", Colors: []Color{{Hex: "#635bff", Type: "accent"}}}
+	if got := ExportTailwind(ThemeOf(brand)); !strings.Contains(got, "accent: '#635bff',") {
+		t.Errorf("ExportTailwind() = %s", got)
+	}
+	if got := ExportDesignTokens(ThemeOf(brand)); !strings.Contains(got, `"$value": "#635bff"`) {
+		t.Errorf("ExportDesignTokens() = %s", got)
+	}
+}
+
+func TestSanitizeCSSName(t *testing.T) {
+	tests := []struct {
+		domain string
+		want   string
+	}{
+		{"stripe.com", "stripe"},
+		{"github.com", "github"},
+		{"example.io", "example"},
+		{"api.stripe.com", "api-stripe"},
+		{"my-app.org", "my-app"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.domain, func(t *testing.T) {
+			got := sanitizeCSSName(tt.domain)
+			if got != tt.want {
+				t.Errorf("sanitizeCSSName(%q) = %q, want %q", tt.domain, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSanitizeTailwindKey(t *testing.T) {
+	tests := []struct {
+		domain string
+		want   string
+	}{
+		{"stripe.com", "stripe"},
+		{"github.com", "github"},
+		{"my-app.io", "my_app"},
+		{"api.stripe.com", "api_stripe"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.domain, func(t *testing.T) {
+			got := sanitizeTailwindKey(tt.domain)
+			if got != tt.want {
+				t.Errorf("sanitizeTailwindKey(%q) = %q, want %q", tt.domain, got, tt.want)
+			}
+		})
+	}
+}
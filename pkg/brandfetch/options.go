@@ -0,0 +1,144 @@
+package brandfetch
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// DefaultTimeout is the per-request timeout used unless WithTimeout or
+// WithHTTPClient says otherwise.
+const DefaultTimeout = 30 * time.Second
+
+// maxRetryWait caps how long a retry waits; a longer Retry-After is returned
+// to the caller as a rate limit error instead.
+const maxRetryWait = time.Minute
+
+// Option configures a Client.
+type Option func(*clientConfig)
+
+type clientConfig struct {
+	baseURL     string
+	logoBaseURL string
+	graphQLURL  string
+	httpClient  *http.Client
+	timeout     time.Duration
+	timeoutSet  bool
+	transport   http.RoundTripper
+	retry       retryPolicy
+}
+
+type retryPolicy struct {
+	maxRetries int
+	backoff    time.Duration
+}
+
+// WithBaseURL sets the Brand, Search and Transaction API base URL.
+func WithBaseURL(u string) Option {
+	return func(c *clientConfig) { c.baseURL = u }
+}
+
+// WithLogoBaseURL sets the Logo API CDN base URL used by BuildLogoURL.
+func WithLogoBaseURL(u string) Option {
+	return func(c *clientConfig) { c.logoBaseURL = u }
+}
+
+// WithGraphQLURL sets the GraphQL endpoint.
+func WithGraphQLURL(u string) Option {
+	return func(c *clientConfig) { c.graphQLURL = u }
+}
+
+// WithHTTPClient sends requests through a copy of hc. WithTimeout and
+// WithTransport, when also given, override the copy's settings.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *clientConfig) { c.httpClient = hc }
+}
+
+// WithTimeout sets the per-request timeout. Zero or a negative d disables
+// the timeout.
+func WithTimeout(d time.Duration) Option {
+	return func(c *clientConfig) { c.timeout, c.timeoutSet = max(d, 0), true }
+}
+
+// WithTransport sets the HTTP transport, e.g. one from NewTransport or a
+// recording transport in tests.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *clientConfig) { c.transport = rt }
+}
+
+// WithRetry retries rate-limited requests, and idempotent requests that fail
+// with a connection error or a 502, 503 or 504, up to maxRetries times. Waits
+// start at backoff and double per attempt; a Retry-After header takes
+// precedence.
+func WithRetry(maxRetries int, backoff time.Duration) Option {
+	return func(c *clientConfig) {
+		c.retry = retryPolicy{maxRetries: maxRetries, backoff: backoff}
+	}
+}
+
+// do sends req, retrying according to the client's retry policy.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := c.httpClient.Do(req)
+		if attempt >= c.retry.maxRetries || !retryable(req, resp, err) {
+			return resp, err
+		}
+		wait := c.retry.wait(attempt, resp)
+		if wait > maxRetryWait {
+			return resp, err
+		}
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+	}
+}
+
+func retryable(req *http.Request, resp *http.Response, err error) bool {
+	// A consumed body that cannot be rebuilt cannot be sent again.
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	idempotent := req.Method == http.MethodGet || req.Method == http.MethodHead
+	if err != nil {
+		return idempotent && req.Context().Err() == nil
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return idempotent
+	}
+	return false
+}
+
+func (p retryPolicy) wait(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if after := resp.Header.Get("Retry-After"); after != "" {
+			if secs, err := strconv.Atoi(after); err == nil && secs >= 0 {
+				return time.Duration(secs) * time.Second
+			}
+			if at, err := http.ParseTime(after); err == nil {
+				return time.Until(at)
+			}
+		}
+	}
+	return p.backoff << attempt
+}
@@ -0,0 +1,114 @@
+package brandfetch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// flakyServer fails the first failures requests with status, then serves an empty search.
+func flakyServer(t *testing.T, failures int32, status int) (*httptest.Server, *int32) {
+	t.Helper()
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) <= failures {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(status)
+			return
+		}
+		if r.Method == http.MethodPost {
+			_, _ = w.Write([]byte(`{"name":"Stripe"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func TestWithRetry_RateLimited(t *testing.T) {
+	server, calls := flakyServer(t, 2, http.StatusTooManyRequests)
+	client := NewClient("cid", "key", WithBaseURL(server.URL), WithRetry(3, time.Millisecond))
+
+	if _, err := client.Search(context.Background(), "stripe", 0); err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if *calls != 3 {
+		t.Errorf("calls = %d, want 3", *calls)
+	}
+}
+
+func TestWithRetry_GivesUp(t *testing.T) {
+	server, calls := flakyServer(t, 5, http.StatusServiceUnavailable)
+	client := NewClient("cid", "key", WithBaseURL(server.URL), WithRetry(1, time.Millisecond))
+
+	_, err := client.Search(context.Background(), "stripe", 0)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Search() error = %v, want 503 APIError", err)
+	}
+	if *calls != 2 {
+		t.Errorf("calls = %d, want 2", *calls)
+	}
+}
+
+func TestWithRetry_NonIdempotent(t *testing.T) {
+	server, calls := flakyServer(t, 1, http.StatusServiceUnavailable)
+	client := NewClient("cid", "key", WithBaseURL(server.URL), WithRetry(3, time.Millisecond))
+	if _, err := client.CreateTransaction(context.Background(), "STRIPE", ""); err == nil {
+		t.Error("expected the 503 to be returned for a POST")
+	}
+	if *calls != 1 {
+		t.Errorf("calls = %d, want 1", *calls)
+	}
+
+	// A rate-limited POST was not processed, so it is safe to resend.
+	server, calls = flakyServer(t, 1, http.StatusTooManyRequests)
+	client = NewClient("cid", "key", WithBaseURL(server.URL), WithRetry(3, time.Millisecond))
+	brand, err := client.CreateTransaction(context.Background(), "STRIPE", "")
+	if err != nil || brand.Name != "Stripe" || *calls != 2 {
+		t.Errorf("CreateTransaction() = %v, %v after %d calls", brand, err, *calls)
+	}
+}
+
+func TestWithRetry_ContextCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+	client := NewClient("cid", "key", WithBaseURL(server.URL), WithRetry(5, time.Second))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := client.Search(ctx, "stripe", 0); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Search() error = %v, want deadline exceeded", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Error("retry wait ignored context cancellation")
+	}
+}
+
+func TestWithHTTPClient_Copied(t *testing.T) {
+	hc := &http.Client{Timeout: time.Minute}
+	client := NewClient("cid", "key", WithHTTPClient(hc), WithTimeout(time.Second))
+	if client.httpClient.Timeout != time.Second {
+		t.Errorf("Timeout = %v, want 1s", client.httpClient.Timeout)
+	}
+	if hc.Timeout != time.Minute {
+		t.Error("WithHTTPClient must not modify the caller's client")
+	}
+}
+
+func TestWithTimeout_ZeroDisables(t *testing.T) {
+	if client := NewClient("cid", "key"); client.httpClient.Timeout != DefaultTimeout {
+		t.Errorf("default Timeout = %v, want %v", client.httpClient.Timeout, DefaultTimeout)
+	}
+	if client := NewClient("cid", "key", WithTimeout(0)); client.httpClient.Timeout != 0 {
+		t.Errorf("WithTimeout(0) Timeout = %v, want none", client.httpClient.Timeout)
+	}
+}
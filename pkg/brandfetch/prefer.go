@@ -0,0 +1,130 @@
+package brandfetch
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// LogoVariant is one entry of a logo preference list. Empty fields keep the
+// value of the LogoOptions the variant is applied to.
+type LogoVariant struct {
+	Type   string
+	Theme  string
+	Format string
+}
+
+var (
+	logoTypes   = map[string]bool{"logo": true, "icon": true, "symbol": true}
+	logoThemes  = map[string]bool{"light": true, "dark": true}
+	logoFormats = map[string]string{
+		"svg":  "image/svg+xml",
+		"png":  "image/png",
+		"webp": "image/webp",
+		"jpg":  "image/jpeg",
+		"jpeg": "image/jpeg",
+	}
+)
+
+// ParseLogoVariants parses a comma-separated preference list such as
+// "svg:dark,png:dark,svg:light,icon". Each entry names any of a format
+// (svg, png, webp, jpg), a theme (light, dark) and a type (logo, icon,
+// symbol), separated by colons, in any order.
+func ParseLogoVariants(s string) ([]LogoVariant, error) {
+	var variants []LogoVariant
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		var v LogoVariant
+		for _, part := range strings.Split(entry, ":") {
+			part = strings.ToLower(strings.TrimSpace(part))
+			var field *string
+			switch {
+			case logoFormats[part] != "":
+				field = &v.Format
+			case logoThemes[part]:
+				field = &v.Theme
+			case logoTypes[part]:
+				field = &v.Type
+			default:
+				return nil, fmt.Errorf("invalid logo preference %q: unknown %q (want a format, theme or type)", entry, part)
+			}
+			if *field != "" {
+				return nil, fmt.Errorf("invalid logo preference %q: %q and %q conflict", entry, *field, part)
+			}
+			*field = part
+		}
+		variants = append(variants, v)
+	}
+	if len(variants) == 0 {
+		return nil, fmt.Errorf("logo preference list is empty")
+	}
+	return variants, nil
+}
+
+// Apply returns opts with the fields the variant sets replaced.
+func (v LogoVariant) Apply(opts LogoOptions) LogoOptions {
+	if v.Type != "" {
+		opts.Type = v.Type
+	}
+	if v.Theme != "" {
+		opts.Theme = v.Theme
+	}
+	if v.Format != "" {
+		opts.Format = v.Format
+	}
+	return opts
+}
+
+// String formats the variant as it is written in a preference list.
+func (v LogoVariant) String() string {
+	var parts []string
+	for _, p := range []string{v.Format, v.Theme, v.Type} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, ":")
+}
+
+// ResolvePreferredLogo tries each variant applied to opts, in order, and
+// returns the first the CDN serves as a real logo: a 2xx response that is
+// not a fallback image and whose content type matches the requested format.
+// It returns the variant that produced the logo alongside it. The chosen
+// logo's content is kept in Body so callers need not download it again.
+func (c *Client) ResolvePreferredLogo(ctx context.Context, opts LogoOptions, variants []LogoVariant) (*ResolvedLogo, LogoVariant, error) {
+	var rejected []string
+	for _, v := range variants {
+		candidate := v.Apply(opts)
+		resolved, err := c.resolveLogo(ctx, candidate, true)
+		if err != nil {
+			return nil, LogoVariant{}, err
+		}
+		if reason := rejectReason(resolved, candidate.Format); reason != "" {
+			rejected = append(rejected, fmt.Sprintf("%s (%s)", v, reason))
+			continue
+		}
+		return resolved, v, nil
+	}
+	return nil, LogoVariant{}, fmt.Errorf("no preferred logo available for %s: %s", opts.Identifier, strings.Join(rejected, ", "))
+}
+
+// rejectReason explains why a resolved logo is not a usable asset in format,
+// or returns "" if it is.
+func rejectReason(r *ResolvedLogo, format string) string {
+	switch {
+	case r.Fallback:
+		return "fallback served"
+	case r.StatusCode < 200 || r.StatusCode > 299:
+		return fmt.Sprintf("HTTP %d", r.StatusCode)
+	}
+	if want := logoFormats[format]; want != "" && r.ContentType != "" && r.ContentType != want {
+		return "served " + r.ContentType
+	}
+	if r.Size == 0 {
+		return "empty response"
+	}
+	return ""
+}
@@ -0,0 +1,156 @@
+package brandfetch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseLogoVariants(t *testing.T) {
+	got, err := ParseLogoVariants("svg:dark, png:dark,light:svg,icon,SYMBOL:png")
+	if err != nil {
+		t.Fatalf("ParseLogoVariants() error = %v", err)
+	}
+	want := []LogoVariant{
+		{Format: "svg", Theme: "dark"},
+		{Format: "png", Theme: "dark"},
+		{Format: "svg", Theme: "light"},
+		{Type: "icon"},
+		{Type: "symbol", Format: "png"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseLogoVariants() = %+v, want %+v", got, want)
+	}
+
+	for _, bad := range []string{"", " , ", "gif", "svg:png", "dark:light", "icon:logo"} {
+		if _, err := ParseLogoVariants(bad); err == nil {
+			t.Errorf("ParseLogoVariants(%q) error = nil, want error", bad)
+		}
+	}
+}
+
+func TestLogoVariant_ApplyAndString(t *testing.T) {
+	base := LogoOptions{Identifier: "github.com", Type: "logo", Theme: "light", Format: "svg", Width: 64}
+	v := LogoVariant{Theme: "dark", Format: "png"}
+
+	got := v.Apply(base)
+	want := LogoOptions{Identifier: "github.com", Type: "logo", Theme: "dark", Format: "png", Width: 64}
+	if got != want {
+		t.Errorf("Apply() = %+v, want %+v", got, want)
+	}
+	if s := v.String(); s != "png:dark" {
+		t.Errorf("String() = %q, want png:dark", s)
+	}
+	if s := (LogoVariant{Type: "icon"}).String(); s != "icon" {
+		t.Errorf("String() = %q, want icon", s)
+	}
+}
+
+// preferServer has a light SVG logo and a dark PNG icon; dark SVG requests
+// get the PNG, and anything else gets a fallback lettermark.
+func preferServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p := r.URL.Path
+		dark := strings.Contains(p, "/theme/dark")
+		switch {
+		case strings.HasSuffix(p, "/type/logo.svg") && !dark:
+			w.Header().Set("Content-Type", "image/svg+xml")
+			w.Write([]byte(`<svg width="10" height="10"/>`))
+		case strings.HasSuffix(p, "/type/logo.svg") && dark:
+			w.Header().Set("Content-Type", "image/png")
+			w.Write([]byte("png"))
+		case strings.HasSuffix(p, "/type/icon.png") && dark:
+			w.Header().Set("Content-Type", "image/png")
+			w.Write([]byte("png"))
+		case strings.Contains(p, "/fallback/404"):
+			http.NotFound(w, r)
+		default:
+			w.Header().Set("Content-Type", "image/png")
+			w.Write([]byte("lettermark"))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestResolvePreferredLogo(t *testing.T) {
+	server := preferServer(t)
+	client := NewClient("test_client_id", "", WithLogoBaseURL(server.URL))
+	base := LogoOptions{Identifier: "github.com", Type: "logo", Theme: "light", Format: "svg"}
+
+	variants, _ := ParseLogoVariants("svg:dark,png:dark,icon:png:dark,svg:light")
+	resolved, variant, err := client.ResolvePreferredLogo(context.Background(), base, variants)
+	if err != nil {
+		t.Fatalf("ResolvePreferredLogo() error = %v", err)
+	}
+	if variant.String() != "png:dark:icon" {
+		t.Errorf("variant = %q, want png:dark:icon", variant)
+	}
+	if !strings.Contains(resolved.URL, "/theme/dark/type/icon.png") {
+		t.Errorf("URL = %q, want the dark PNG icon", resolved.URL)
+	}
+	if string(resolved.Body) != "png" {
+		t.Errorf("Body = %q, want the chosen logo's content", resolved.Body)
+	}
+
+	variants, _ = ParseLogoVariants("svg:dark,svg:light")
+	_, variant, err = client.ResolvePreferredLogo(context.Background(), base, variants)
+	if err != nil {
+		t.Fatalf("ResolvePreferredLogo() error = %v", err)
+	}
+	if variant.String() != "svg:light" {
+		t.Errorf("variant = %q, want svg:light", variant)
+	}
+}
+
+func TestResolvePreferredLogo_NoneAvailable(t *testing.T) {
+	server := preferServer(t)
+	client := NewClient("test_client_id", "", WithLogoBaseURL(server.URL))
+	base := LogoOptions{Identifier: "github.com", Type: "logo", Theme: "light", Format: "svg"}
+
+	variants, _ := ParseLogoVariants("svg:dark,webp")
+	_, _, err := client.ResolvePreferredLogo(context.Background(), base, variants)
+	if err == nil {
+		t.Fatal("ResolvePreferredLogo() error = nil, want error")
+	}
+	for _, want := range []string{"svg:dark (served image/png)", "webp (fallback served)"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error = %q, want it to mention %q", err, want)
+		}
+	}
+}
+
+func TestResolvePreferredLogo_OneRequestPerCandidate(t *testing.T) {
+	var requests []string
+	inner := preferServer(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		inner.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+	client := NewClient("test_client_id", "", WithLogoBaseURL(server.URL))
+	base := LogoOptions{Identifier: "github.com", Type: "logo", Theme: "light", Format: "svg"}
+
+	variants, _ := ParseLogoVariants("webp,svg:dark,svg:light")
+	resolved, variant, err := client.ResolvePreferredLogo(context.Background(), base, variants)
+	if err != nil {
+		t.Fatalf("ResolvePreferredLogo() error = %v", err)
+	}
+	if variant.String() != "svg:light" {
+		t.Errorf("variant = %q, want svg:light", variant)
+	}
+	if len(requests) != len(variants) {
+		t.Errorf("requests = %v, want one per candidate", requests)
+	}
+	for _, r := range requests {
+		if !strings.HasPrefix(r, "GET ") || !strings.Contains(r, "/fallback/404") {
+			t.Errorf("request %q, want a GET with fallback 404", r)
+		}
+	}
+	if strings.Contains(resolved.URL, "/fallback/") {
+		t.Errorf("URL = %q, want the URL without the fallback probe", resolved.URL)
+	}
+}
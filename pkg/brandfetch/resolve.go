@@ -0,0 +1,260 @@
+package brandfetch
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/xml"
+	"fmt"
+	"image"
+	_ "image/gif"  // register GIF for image.DecodeConfig
+	_ "image/jpeg" // register JPEG for image.DecodeConfig
+	_ "image/png"  // register PNG for image.DecodeConfig
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// sniffLimit is how much of a logo is kept to read its dimensions from.
+const sniffLimit = 64 << 10
+
+// maxLogoBody caps the content ResolvePreferredLogo keeps in Body.
+const maxLogoBody = 50 << 20
+
+// ResolvedLogo describes the asset the Logo CDN serves for a logo URL.
+type ResolvedLogo struct {
+	URL         string `json:"url"`
+	FinalURL    string `json:"finalUrl"`
+	StatusCode  int    `json:"status"`
+	ContentType string `json:"contentType,omitempty"`
+	Size        int64  `json:"size"`
+	Width       int    `json:"width,omitempty"`
+	Height      int    `json:"height,omitempty"`
+	Fallback    bool   `json:"fallback"`
+
+	// Body is the served content when the logo came from ResolvePreferredLogo
+	// and is at most 50 MiB; it is nil otherwise.
+	Body []byte `json:"-"`
+}
+
+// ResolveLogo requests the logo described by opts from the CDN, following
+// redirects, and reports what was served. Width and Height are read from
+// PNG, JPEG, GIF, WebP and SVG content; they are zero for other formats.
+//
+// Fallback is set when the CDN has no logo for the identifier and served a
+// fallback image instead; it is detected with a HEAD request using fallback
+// 404. A non-2xx status is reported in StatusCode rather than as an error.
+func (c *Client) ResolveLogo(ctx context.Context, opts LogoOptions) (*ResolvedLogo, error) {
+	return c.resolveLogo(ctx, opts, false)
+}
+
+// resolveLogo implements ResolveLogo. When preferred is set, as for
+// ResolvePreferredLogo, the content is kept in Body if it fits in maxLogoBody,
+// and the logo is requested with fallback 404 so that a single GET both
+// fetches it and detects a fallback; a 404 is then reported as Fallback.
+func (c *Client) resolveLogo(ctx context.Context, opts LogoOptions, preferred bool) (*ResolvedLogo, error) {
+	u, err := c.BuildLogoURL(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	fetchURL := u
+	probe := opts.Fallback != "404"
+	if preferred && probe {
+		strict := opts
+		strict.Fallback = "404"
+		if fetchURL, err = c.BuildLogoURL(strict); err != nil {
+			return nil, err
+		}
+		probe = false
+	}
+
+	resp, err := c.requestLogo(ctx, http.MethodGet, fetchURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	limit := sniffLimit
+	if preferred {
+		limit = maxLogoBody + 1
+	}
+	var head bytes.Buffer
+	n, err := io.Copy(io.Discard, io.TeeReader(resp.Body, &limitedWriter{w: &head, n: limit}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read logo: %w", err)
+	}
+
+	// A custom RoundTripper may return a response without its request.
+	finalURL := fetchURL
+	if resp.Request != nil && resp.Request.URL != nil {
+		finalURL = resp.Request.URL.String()
+	}
+	resolved := &ResolvedLogo{
+		URL:         u,
+		FinalURL:    finalURL,
+		StatusCode:  resp.StatusCode,
+		ContentType: resp.Header.Get("Content-Type"),
+		Size:        n,
+	}
+	if mediaType, _, err := mime.ParseMediaType(resolved.ContentType); err == nil {
+		resolved.ContentType = mediaType
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		resolved.Fallback = fetchURL != u && resp.StatusCode == http.StatusNotFound
+		return resolved, nil
+	}
+	data := head.Bytes()
+	if len(data) > sniffLimit {
+		data = data[:sniffLimit]
+	}
+	resolved.Width, resolved.Height = imageDimensions(resolved.ContentType, data)
+	if preferred && n <= maxLogoBody {
+		resolved.Body = head.Bytes()
+	}
+
+	if probe {
+		strict := opts
+		strict.Fallback = "404"
+		strictURL, err := c.BuildLogoURL(strict)
+		if err != nil {
+			return nil, err
+		}
+		strictResp, err := c.requestLogo(ctx, http.MethodHead, strictURL)
+		if err != nil {
+			return nil, err
+		}
+		strictResp.Body.Close()
+		resolved.Fallback = strictResp.StatusCode == http.StatusNotFound
+	}
+	return resolved, nil
+}
+
+func (c *Client) requestLogo(ctx context.Context, method, u string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("connection failed: %w", err)
+	}
+	return resp, nil
+}
+
+// limitedWriter keeps the first n bytes written to it and drops the rest.
+type limitedWriter struct {
+	w io.Writer
+	n int
+}
+
+func (l *limitedWriter) Write(p []byte) (int, error) {
+	if l.n > 0 {
+		keep := p
+		if len(keep) > l.n {
+			keep = keep[:l.n]
+		}
+		l.n -= len(keep)
+		if _, err := l.w.Write(keep); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// imageDimensions reads the pixel size from the start of an image.
+func imageDimensions(contentType string, data []byte) (width, height int) {
+	if w, h, ok := webpDimensions(data); ok {
+		return w, h
+	}
+	if cfg, _, err := image.DecodeConfig(bytes.NewReader(data)); err == nil {
+		return cfg.Width, cfg.Height
+	}
+	if strings.Contains(contentType, "svg") || bytes.Contains(data, []byte("<svg")) {
+		return svgDimensions(data)
+	}
+	return 0, 0
+}
+
+// webpDimensions reads the canvas size from a lossy (VP8), lossless (VP8L)
+// or extended (VP8X) WebP header.
+func webpDimensions(data []byte) (width, height int, ok bool) {
+	if len(data) < 30 || string(data[0:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return 0, 0, false
+	}
+	switch string(data[12:16]) {
+	case "VP8 ":
+		if data[23] != 0x9d || data[24] != 0x01 || data[25] != 0x2a {
+			return 0, 0, false
+		}
+		width = int(binary.LittleEndian.Uint16(data[26:28]) & 0x3fff)
+		height = int(binary.LittleEndian.Uint16(data[28:30]) & 0x3fff)
+	case "VP8L":
+		if data[20] != 0x2f {
+			return 0, 0, false
+		}
+		b := data[21:25]
+		width = 1 + (int(b[0]) | int(b[1]&0x3f)<<8)
+		height = 1 + (int(b[1])>>6 | int(b[2])<<2 | int(b[3]&0x0f)<<10)
+	case "VP8X":
+		width = 1 + (int(data[24]) | int(data[25])<<8 | int(data[26])<<16)
+		height = 1 + (int(data[27]) | int(data[28])<<8 | int(data[29])<<16)
+	default:
+		return 0, 0, false
+	}
+	return width, height, true
+}
+
+// svgDimensions reads width and height from the root <svg> element, falling
+// back to its viewBox. Lengths in units other than px are ignored.
+func svgDimensions(data []byte) (width, height int) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	dec.Strict = false
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return 0, 0
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if start.Name.Local != "svg" {
+			return 0, 0
+		}
+		var viewBox string
+		for _, attr := range start.Attr {
+			switch attr.Name.Local {
+			case "width":
+				width = svgLength(attr.Value)
+			case "height":
+				height = svgLength(attr.Value)
+			case "viewBox":
+				viewBox = attr.Value
+			}
+		}
+		if (width == 0 || height == 0) && viewBox != "" {
+			fields := strings.FieldsFunc(viewBox, func(r rune) bool { return r == ' ' || r == ',' })
+			if len(fields) == 4 {
+				if width == 0 {
+					width = svgLength(fields[2])
+				}
+				if height == 0 {
+					height = svgLength(fields[3])
+				}
+			}
+		}
+		return width, height
+	}
+}
+
+func svgLength(s string) int {
+	s = strings.TrimSuffix(strings.TrimSpace(s), "px")
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f <= 0 {
+		return 0
+	}
+	return int(f + 0.5)
+}
@@ -0,0 +1,198 @@
+package brandfetch
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/brandfetch-cli/pkg/brandfetch/brandfetchtest"
+)
+
+func newMockLogoClient(t *testing.T) *Client {
+	t.Helper()
+	server := brandfetchtest.NewServer(t, brandfetchtest.Options{})
+	return NewClient("test_client_id", "test_api_key", WithLogoBaseURL(brandfetchtest.EndpointsFor(server.URL).CDN))
+}
+
+func TestResolveLogo(t *testing.T) {
+	client := newMockLogoClient(t)
+
+	tests := []struct {
+		name        string
+		opts        LogoOptions
+		contentType string
+		width       int
+		height      int
+		fallback    bool
+	}{
+		{"svg", LogoOptions{Identifier: "github.com", Type: "logo", Format: "svg"}, "image/svg+xml", 128, 128, false},
+		{"png", LogoOptions{Identifier: "github.com", Type: "icon", Format: "png", Width: 64}, "image/png", 64, 64, false},
+		{"webp", LogoOptions{Identifier: "stripe.com", Type: "icon", Format: "webp"}, "image/webp", 1, 1, false},
+		{"fallback", LogoOptions{Identifier: "unknown.example", Type: "icon", Format: "png", Width: 32}, "image/png", 32, 32, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.ResolveLogo(context.Background(), tt.opts)
+			if err != nil {
+				t.Fatalf("ResolveLogo() error = %v", err)
+			}
+			if got.StatusCode != http.StatusOK {
+				t.Errorf("StatusCode = %d, want 200", got.StatusCode)
+			}
+			if got.ContentType != tt.contentType {
+				t.Errorf("ContentType = %q, want %q", got.ContentType, tt.contentType)
+			}
+			if got.Size <= 0 {
+				t.Errorf("Size = %d, want > 0", got.Size)
+			}
+			if got.Width != tt.width || got.Height != tt.height {
+				t.Errorf("dimensions = %dx%d, want %dx%d", got.Width, got.Height, tt.width, tt.height)
+			}
+			if got.Fallback != tt.fallback {
+				t.Errorf("Fallback = %v, want %v", got.Fallback, tt.fallback)
+			}
+			if got.FinalURL != got.URL {
+				t.Errorf("FinalURL = %q, want %q", got.FinalURL, got.URL)
+			}
+		})
+	}
+}
+
+func TestResolveLogo_ProbesFallbackWithHEAD(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "image/svg+xml")
+		w.Write([]byte(`<svg width="10" height="10"/>`))
+	}))
+	defer server.Close()
+	client := NewClient("test_client_id", "", WithLogoBaseURL(server.URL))
+
+	got, err := client.ResolveLogo(context.Background(), LogoOptions{Identifier: "github.com", Format: "svg"})
+	if err != nil {
+		t.Fatalf("ResolveLogo() error = %v", err)
+	}
+	if len(requests) != 2 || !strings.HasPrefix(requests[0], "GET ") || !strings.HasPrefix(requests[1], "HEAD ") || !strings.Contains(requests[1], "/fallback/404") {
+		t.Errorf("requests = %v, want GET then a HEAD fallback probe", requests)
+	}
+	if got.Body != nil {
+		t.Errorf("ResolveLogo kept %d bytes of content, want nil", len(got.Body))
+	}
+}
+
+func TestResolveLogo_NotFound(t *testing.T) {
+	client := newMockLogoClient(t)
+
+	got, err := client.ResolveLogo(context.Background(), LogoOptions{Identifier: "unknown.example", Fallback: "404"})
+	if err != nil {
+		t.Fatalf("ResolveLogo() error = %v", err)
+	}
+	if got.StatusCode != http.StatusNotFound {
+		t.Errorf("StatusCode = %d, want 404", got.StatusCode)
+	}
+}
+
+func TestResolveLogo_FollowsRedirects(t *testing.T) {
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/svg+xml; charset=utf-8")
+		w.Write([]byte(`<?xml version="1.0"?><svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 300 150"/>`))
+	}))
+	defer target.Close()
+	cdn := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target.URL+"/asset.svg", http.StatusFound)
+	}))
+	defer cdn.Close()
+
+	client := NewClient("test_client_id", "", WithLogoBaseURL(cdn.URL))
+	got, err := client.ResolveLogo(context.Background(), LogoOptions{Identifier: "github.com", Fallback: "404"})
+	if err != nil {
+		t.Fatalf("ResolveLogo() error = %v", err)
+	}
+	if got.FinalURL != target.URL+"/asset.svg" {
+		t.Errorf("FinalURL = %q, want redirect target", got.FinalURL)
+	}
+	if !strings.HasPrefix(got.URL, cdn.URL) {
+		t.Errorf("URL = %q, want the CDN URL", got.URL)
+	}
+	if got.ContentType != "image/svg+xml" {
+		t.Errorf("ContentType = %q, want image/svg+xml", got.ContentType)
+	}
+	if got.Width != 300 || got.Height != 150 {
+		t.Errorf("dimensions = %dx%d, want 300x150", got.Width, got.Height)
+	}
+}
+
+// requestlessTransport answers every request without setting resp.Request,
+// as some custom RoundTrippers do.
+type requestlessTransport struct{}
+
+func (requestlessTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"image/svg+xml"}},
+		Body:       io.NopCloser(strings.NewReader(`<svg width="10" height="10"/>`)),
+	}, nil
+}
+
+func TestResolveLogo_ResponseWithoutRequest(t *testing.T) {
+	client := NewClient("test_client_id", "", WithLogoBaseURL("https://cdn.example"), WithTransport(requestlessTransport{}))
+
+	got, err := client.ResolveLogo(context.Background(), LogoOptions{Identifier: "github.com", Format: "svg", Fallback: "404"})
+	if err != nil {
+		t.Fatalf("ResolveLogo() error = %v", err)
+	}
+	if got.FinalURL != got.URL {
+		t.Errorf("FinalURL = %q, want the request URL %q", got.FinalURL, got.URL)
+	}
+}
+
+func TestSVGDimensions(t *testing.T) {
+	tests := []struct {
+		svg           string
+		width, height int
+	}{
+		{`<svg width="120" height="40"></svg>`, 120, 40},
+		{`<svg width="120px" height="40.4px"/>`, 120, 40},
+		{`<svg width="100%" height="100%" viewBox="0,0,64,32"/>`, 64, 32},
+		{`<!-- logo --><svg viewBox="0 0 10 20"/>`, 10, 20},
+		{`<svg width="2in" height="1in"/>`, 0, 0},
+		{`<html><svg width="10" height="10"/></html>`, 0, 0},
+	}
+	for _, tt := range tests {
+		w, h := svgDimensions([]byte(tt.svg))
+		if w != tt.width || h != tt.height {
+			t.Errorf("svgDimensions(%s) = %dx%d, want %dx%d", tt.svg, w, h, tt.width, tt.height)
+		}
+	}
+}
+
+func TestWebPDimensions(t *testing.T) {
+	header := func(chunk string, payload ...byte) []byte {
+		data := append([]byte("RIFF\x00\x00\x00\x00WEBP"+chunk+"\x00\x00\x00\x00"), payload...)
+		for len(data) < 30 {
+			data = append(data, 0)
+		}
+		return data
+	}
+	tests := []struct {
+		name          string
+		data          []byte
+		width, height int
+	}{
+		{"lossy", header("VP8 ", 0, 0, 0, 0x9d, 0x01, 0x2a, 0x90, 0x01, 0xc8, 0x00), 400, 200},
+		{"lossless", header("VP8L", 0x2f, 0x3f, 0xc0, 0x1f, 0x00), 64, 128},
+		{"extended", header("VP8X", 0, 0, 0, 0, 0xff, 0x03, 0x00, 0xff, 0x01, 0x00), 1024, 512},
+	}
+	for _, tt := range tests {
+		w, h, ok := webpDimensions(tt.data)
+		if !ok || w != tt.width || h != tt.height {
+			t.Errorf("%s: webpDimensions() = %dx%d, %v, want %dx%d", tt.name, w, h, ok, tt.width, tt.height)
+		}
+	}
+	if _, _, ok := webpDimensions([]byte("not a webp file at all, really")); ok {
+		t.Error("webpDimensions() accepted non-WebP data")
+	}
+}
@@ -0,0 +1,107 @@
+package brandfetch
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewClient_Options(t *testing.T) {
+	client := NewClient("cid", "key",
+		WithBaseURL("http://api.local/"),
+		WithLogoBaseURL("http://cdn.local/"),
+		WithGraphQLURL("http://graphql.local/"),
+		WithTimeout(5*time.Second),
+	)
+	if client.baseURL != "http://api.local" || client.graphQLBaseURL != "http://graphql.local/" {
+		t.Errorf("endpoints not applied: %s %s", client.baseURL, client.graphQLBaseURL)
+	}
+	if client.httpClient.Timeout != 5*time.Second {
+		t.Errorf("Timeout = %v, want 5s", client.httpClient.Timeout)
+	}
+	u, err := client.BuildLogoURL(LogoOptions{Identifier: "github.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(u, "http://cdn.local/github.com") {
+		t.Errorf("BuildLogoURL() = %s", u)
+	}
+}
+
+func TestNewTransport_Proxy(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer proxy.Close()
+
+	transport, err := NewTransport(TransportOptions{Proxy: proxy.URL})
+	if err != nil {
+		t.Fatalf("NewTransport() error = %v", err)
+	}
+	client := NewClient("cid", "", WithBaseURL("http://api.brandfetch.test"), WithTransport(transport))
+	if _, err := client.Search(context.Background(), "github", 0); err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if !strings.HasPrefix(proxied, "http://api.brandfetch.test/v2/search/github") {
+		t.Errorf("request not sent through proxy: %q", proxied)
+	}
+
+	if _, err := NewTransport(TransportOptions{Proxy: "not a url"}); err == nil {
+		t.Error("expected error for an invalid proxy URL")
+	}
+}
+
+func TestNewTransport_CACert(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	block := &pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}
+	if err := os.WriteFile(caFile, pem.EncodeToMemory(block), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	untrusted := NewClient("cid", "", WithBaseURL(server.URL))
+	if _, err := untrusted.Search(context.Background(), "github", 0); err == nil {
+		t.Fatal("expected TLS error without the CA bundle")
+	}
+
+	transport, err := NewTransport(TransportOptions{CACertFile: caFile})
+	if err != nil {
+		t.Fatalf("NewTransport() error = %v", err)
+	}
+	trusted := NewClient("cid", "", WithBaseURL(server.URL), WithTransport(transport))
+	if _, err := trusted.Search(context.Background(), "github", 0); err != nil {
+		t.Fatalf("Search() with CA bundle error = %v", err)
+	}
+}
+
+func TestNewTransport_Errors(t *testing.T) {
+	dir := t.TempDir()
+	empty := filepath.Join(dir, "empty.pem")
+	if err := os.WriteFile(empty, []byte("no certs"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []TransportOptions{
+		{CACertFile: filepath.Join(dir, "missing.pem")},
+		{CACertFile: empty},
+		{ClientCertFile: empty},
+		{ClientCertFile: empty, ClientKeyFile: empty},
+	}
+	for _, opts := range tests {
+		if _, err := NewTransport(opts); err == nil {
+			t.Errorf("NewTransport(%+v) expected error", opts)
+		}
+	}
+}
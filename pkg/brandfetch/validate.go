@@ -0,0 +1,152 @@
+package brandfetch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Quota headers returned by the Brandfetch API.
+const (
+	quotaHeader = "x-api-key-quota"
+	usageHeader = "x-api-key-approximate-usage"
+)
+
+// validationProbe is the brand looked up when validating credentials.
+const validationProbe = "brandfetch.com"
+
+// validationQuery is the GraphQL query used to validate the API key. GraphQL
+// requests do not count against the Brand API quota.
+const validationQuery = `query ListWebhooks { webhooks { edges { node { urn } } } }`
+
+// KeyCheck is the result of validating a credential against the API.
+type KeyCheck struct {
+	Valid     bool   `json:"valid"`
+	Status    int    `json:"status"`
+	Quota     *int64 `json:"quota,omitempty"`
+	Usage     *int64 `json:"usage,omitempty"`
+	Remaining *int64 `json:"remaining,omitempty"`
+	Message   string `json:"message,omitempty"`
+}
+
+// ValidateClientID checks the Logo API client ID with a one-result search.
+func (c *Client) ValidateClientID(ctx context.Context) (*KeyCheck, error) {
+	params := url.Values{}
+	params.Set("c", c.clientID)
+	u := fmt.Sprintf("%s/v2/search/%s?%s", c.baseURL, url.PathEscape(validationProbe), params.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	check, _, err := c.checkKey(req)
+	return check, err
+}
+
+// ValidateAPIKey checks the API key with an authenticated GraphQL query,
+// which does not spend Brand API quota. The GraphQL API does not report
+// quota; use CheckAPIKeyQuota for that.
+func (c *Client) ValidateAPIKey(ctx context.Context) (*KeyCheck, error) {
+	payload, err := json.Marshal(map[string]string{"query": validationQuery})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", c.graphQLBaseURL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	check, body, err := c.checkKey(req)
+	if err != nil || !check.Valid {
+		return check, err
+	}
+	// GraphQL servers may report authentication failures inside a 200 response.
+	var envelope struct {
+		Errors []struct {
+			Extensions struct {
+				Code string `json:"code"`
+			} `json:"extensions"`
+		} `json:"errors"`
+	}
+	if json.Unmarshal(body, &envelope) == nil {
+		for _, e := range envelope.Errors {
+			if e.Extensions.Code == "UNAUTHENTICATED" || e.Extensions.Code == "FORBIDDEN" {
+				check.Valid = false
+				check.Message = "Key rejected by the API"
+			}
+		}
+	}
+	return check, nil
+}
+
+// CheckAPIKeyQuota checks the Brand API key with a single brand lookup and
+// reports its quota. The lookup counts against the Brand API quota.
+func (c *Client) CheckAPIKeyQuota(ctx context.Context) (*KeyCheck, error) {
+	u := fmt.Sprintf("%s/v2/brands/%s", c.baseURL, url.PathEscape(validationProbe))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	check, _, err := c.checkKey(req)
+	return check, err
+}
+
+// checkKey sends req and classifies the response. Only transport failures are
+// returned as errors; rejected keys are reported in the KeyCheck.
+func (c *Client) checkKey(req *http.Request) (*KeyCheck, []byte, error) {
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("connection failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	check := &KeyCheck{Status: resp.StatusCode}
+	check.Quota = parseQuotaHeader(resp.Header.Get(quotaHeader))
+	check.Usage = parseQuotaHeader(resp.Header.Get(usageHeader))
+	if check.Quota != nil && check.Usage != nil {
+		remaining := *check.Quota - *check.Usage
+		if remaining < 0 {
+			remaining = 0
+		}
+		check.Remaining = &remaining
+	}
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		check.Valid = true
+	case resp.StatusCode == http.StatusTooManyRequests:
+		// The key was accepted but its quota is used up.
+		check.Valid = true
+		check.Message = "Rate limit or quota exceeded"
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		check.Message = "Key rejected by the API"
+	default:
+		check.Message = WrapAPIError(resp.StatusCode, string(body)).Error()
+	}
+	return check, body, nil
+}
+
+func parseQuotaHeader(value string) *int64 {
+	if value == "" {
+		return nil
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil
+	}
+	return &n
+}
@@ -0,0 +1,123 @@
+package brandfetch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestValidateClientID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/search/brandfetch.com" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("c") != "test-client" {
+			t.Errorf("client id = %q", r.URL.Query().Get("c"))
+		}
+		w.Header().Set("x-api-key-quota", "1000")
+		w.Header().Set("x-api-key-approximate-usage", "250")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-client", "")
+	client.baseURL = server.URL
+
+	check, err := client.ValidateClientID(context.Background())
+	if err != nil {
+		t.Fatalf("ValidateClientID() error = %v", err)
+	}
+	if !check.Valid || check.Status != 200 {
+		t.Errorf("check = %+v, want valid 200", check)
+	}
+	if check.Remaining == nil || *check.Remaining != 750 {
+		t.Errorf("Remaining = %v, want 750", check.Remaining)
+	}
+}
+
+func TestValidateAPIKey(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		body      string
+		wantValid bool
+	}{
+		{"valid", 200, `{"data":{"webhooks":{"edges":[]}}}`, true},
+		{"unauthorized", 401, `{}`, false},
+		{"forbidden", 403, `{}`, false},
+		{"unauthenticated in body", 200, `{"errors":[{"message":"bad key","extensions":{"code":"UNAUTHENTICATED"}}]}`, false},
+		{"other GraphQL error", 200, `{"errors":[{"message":"nope","extensions":{"code":"GRAPHQL_VALIDATION_FAILED"}}]}`, true},
+		{"server error", 500, `{}`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/graphql" {
+					t.Errorf("request = %s %s, want POST /graphql", r.Method, r.URL.Path)
+				}
+				if r.Header.Get("Authorization") != "Bearer test-key" {
+					t.Errorf("Authorization = %q", r.Header.Get("Authorization"))
+				}
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := NewClient("", "test-key")
+			client.baseURL = server.URL + "/brand-api-must-not-be-called"
+			client.graphQLBaseURL = server.URL + "/graphql"
+
+			check, err := client.ValidateAPIKey(context.Background())
+			if err != nil {
+				t.Fatalf("ValidateAPIKey() error = %v", err)
+			}
+			if check.Valid != tt.wantValid || check.Status != tt.status {
+				t.Errorf("check = %+v, want valid=%v status=%d", check, tt.wantValid, tt.status)
+			}
+		})
+	}
+}
+
+func TestCheckAPIKeyQuota(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		wantValid bool
+	}{
+		{"valid", 200, true},
+		{"unauthorized", 401, false},
+		{"quota exhausted", 429, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v2/brands/brandfetch.com" {
+					t.Errorf("path = %s", r.URL.Path)
+				}
+				if r.Header.Get("Authorization") != "Bearer test-key" {
+					t.Errorf("Authorization = %q", r.Header.Get("Authorization"))
+				}
+				w.Header().Set("x-api-key-quota", "100")
+				w.Header().Set("x-api-key-approximate-usage", "40")
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(`{}`))
+			}))
+			defer server.Close()
+
+			client := NewClient("", "test-key")
+			client.baseURL = server.URL
+
+			check, err := client.CheckAPIKeyQuota(context.Background())
+			if err != nil {
+				t.Fatalf("CheckAPIKeyQuota() error = %v", err)
+			}
+			if check.Valid != tt.wantValid || check.Status != tt.status {
+				t.Errorf("check = %+v, want valid=%v status=%d", check, tt.wantValid, tt.status)
+			}
+			if check.Remaining == nil || *check.Remaining != 60 {
+				t.Errorf("Remaining = %v, want 60", check.Remaining)
+			}
+		})
+	}
+}